@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cline/cline/cli/pkg/cli"
+	"github.com/cline/cline/cli/pkg/clierr"
+)
+
+func main() {
+	if err := cli.Execute(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "cline: %v\n", err)
+		os.Exit(clierr.Code(err))
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/cline/cline/cli
+
+go 1.21
@@ -0,0 +1,132 @@
+// Package approvals holds the auto-approve policy consulted before a task
+// action (shell command, file edit) runs without asking the user. The policy
+// lives in plain settings keys so it syncs with the rest of the config.
+package approvals
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// Settings keys for the approval policy.
+const (
+	SettingAutoEdits    = "approvals.autoEditsInWorkspace"
+	SettingAutoReadOnly = "approvals.autoReadOnlyCommands"
+	SettingAllowlist    = "approvals.allow"
+	SettingDenylist     = "approvals.deny"
+)
+
+// Policy is the resolved auto-approve configuration.
+type Policy struct {
+	// AutoEditsInWorkspace approves file edits under the workspace root.
+	AutoEditsInWorkspace bool
+	// AutoReadOnly approves known read-only shell commands.
+	AutoReadOnly bool
+	// Allow and Deny are command prefixes; deny wins over everything.
+	Allow []string
+	Deny  []string
+}
+
+// readOnlyCommands are shell commands that cannot change state, safe to
+// auto-approve when AutoReadOnly is on.
+var readOnlyCommands = map[string]bool{
+	"ls": true, "cat": true, "head": true, "tail": true, "grep": true,
+	"rg": true, "find": true, "pwd": true, "wc": true, "file": true,
+	"which": true, "stat": true, "du": true, "df": true,
+}
+
+// readOnlyGitSubcommands extend the read-only set for `git <sub>`.
+var readOnlyGitSubcommands = map[string]bool{
+	"status": true, "diff": true, "log": true, "show": true, "blame": true, "branch": true,
+}
+
+// FromSettings builds the policy from settings; absent keys default to
+// requiring approval for everything.
+func FromSettings(settings map[string]string) *Policy {
+	return &Policy{
+		AutoEditsInWorkspace: settings[SettingAutoEdits] == "true",
+		AutoReadOnly:         settings[SettingAutoReadOnly] == "true",
+		Allow:                splitList(settings[SettingAllowlist]),
+		Deny:                 splitList(settings[SettingDenylist]),
+	}
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return nil
+	}
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// Decision explains whether an action may run without asking. Denied is set
+// only by deny rules so callers can enforce them structurally instead of
+// matching on the display-only Reason text.
+type Decision struct {
+	Auto   bool
+	Denied bool
+	Reason string
+}
+
+// EvaluateCommand decides a shell command. Deny rules always force a prompt,
+// then explicit allow rules, then the read-only set.
+func (p *Policy) EvaluateCommand(command string) Decision {
+	command = strings.TrimSpace(command)
+	for _, prefix := range p.Deny {
+		if strings.HasPrefix(command, prefix) {
+			return Decision{Denied: true, Reason: "matches deny rule " + quote(prefix)}
+		}
+	}
+	for _, prefix := range p.Allow {
+		if strings.HasPrefix(command, prefix) {
+			return Decision{Auto: true, Reason: "matches allow rule " + quote(prefix)}
+		}
+	}
+	if p.AutoReadOnly && isReadOnly(command) {
+		return Decision{Auto: true, Reason: "read-only command"}
+	}
+	return Decision{Reason: "no rule matches; approval required"}
+}
+
+// EvaluateEdit decides a file edit given the workspace root.
+func (p *Policy) EvaluateEdit(path, workspace string) Decision {
+	if !p.AutoEditsInWorkspace {
+		return Decision{Reason: "workspace edits are not auto-approved"}
+	}
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return Decision{Reason: "unresolvable path; approval required"}
+	}
+	root, err := filepath.Abs(workspace)
+	if err != nil {
+		return Decision{Reason: "unresolvable workspace; approval required"}
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return Decision{Reason: "path is outside the workspace"}
+	}
+	return Decision{Auto: true, Reason: "edit within workspace"}
+}
+
+func isReadOnly(command string) bool {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return false
+	}
+	if readOnlyCommands[fields[0]] {
+		return true
+	}
+	if fields[0] == "git" && len(fields) > 1 {
+		return readOnlyGitSubcommands[fields[1]]
+	}
+	return false
+}
+
+func quote(s string) string {
+	return `"` + s + `"`
+}
@@ -0,0 +1,60 @@
+package approvals
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateCommandDenyWins(t *testing.T) {
+	p := FromSettings(map[string]string{
+		SettingAllowlist: "git push, npm test",
+		SettingDenylist:  "git push --force",
+	})
+	if d := p.EvaluateCommand("git push --force origin main"); d.Auto {
+		t.Errorf("deny rule ignored: %+v", d)
+	}
+	if d := p.EvaluateCommand("git push origin main"); !d.Auto {
+		t.Errorf("allow rule ignored: %+v", d)
+	}
+	if d := p.EvaluateCommand("npm test -- --watch"); !d.Auto {
+		t.Errorf("allow rule ignored: %+v", d)
+	}
+}
+
+func TestEvaluateCommandReadOnly(t *testing.T) {
+	p := FromSettings(map[string]string{SettingAutoReadOnly: "true"})
+	for _, cmd := range []string{"ls -la", "git status", "grep -r TODO ."} {
+		if d := p.EvaluateCommand(cmd); !d.Auto {
+			t.Errorf("%q: %+v, want auto", cmd, d)
+		}
+	}
+	for _, cmd := range []string{"rm -rf build", "git push", "npm install"} {
+		if d := p.EvaluateCommand(cmd); d.Auto {
+			t.Errorf("%q: %+v, want approval", cmd, d)
+		}
+	}
+}
+
+func TestEvaluateCommandDefaultRequiresApproval(t *testing.T) {
+	p := FromSettings(nil)
+	if d := p.EvaluateCommand("ls"); d.Auto {
+		t.Errorf("default policy auto-approved: %+v", d)
+	}
+}
+
+func TestEvaluateEdit(t *testing.T) {
+	workspace := t.TempDir()
+	p := FromSettings(map[string]string{SettingAutoEdits: "true"})
+	if d := p.EvaluateEdit(filepath.Join(workspace, "src", "main.go"), workspace); !d.Auto {
+		t.Errorf("workspace edit: %+v", d)
+	}
+	d := p.EvaluateEdit(filepath.Join(workspace, "..", "outside.go"), workspace)
+	if d.Auto || !strings.Contains(d.Reason, "outside") {
+		t.Errorf("outside edit: %+v", d)
+	}
+	p = FromSettings(nil)
+	if d := p.EvaluateEdit(filepath.Join(workspace, "main.go"), workspace); d.Auto {
+		t.Errorf("edits auto-approved without opt-in: %+v", d)
+	}
+}
@@ -0,0 +1,109 @@
+package approvals
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Request is one action a streamed task wants to perform, rendered to the
+// user for an inline decision.
+type Request struct {
+	// Kind is "command" or "edit".
+	Kind string
+	// Command is shown verbatim for command requests.
+	Command string
+	// Path and Diff describe edit requests.
+	Path string
+	Diff string
+	// FullFile is the complete post-edit file, available behind the v(iew)
+	// option so reviewers are not limited to the diff hunk.
+	FullFile string
+}
+
+// Choice is the user's decision.
+type Choice int
+
+const (
+	// Denied rejects this action.
+	Denied Choice = iota
+	// Approved allows this action once.
+	Approved
+	// Always allows this action and asks the caller to record a matching
+	// allow rule.
+	Always
+	// Edited approves a modified version of the action; see Response.Edited.
+	Edited
+)
+
+// Response carries the decision and, for Edited, the replacement command.
+type Response struct {
+	Choice Choice
+	Edited string
+}
+
+// Prompt renders the pending action and reads a keybind-style decision:
+// y(es) / n(o) / a(lways) / e(dit), plus v(iew) to page through the full file
+// for edits. The pager callback receives the full content; pass nil to print
+// inline.
+func Prompt(in io.Reader, out io.Writer, req Request, pager func(string) error) (Response, error) {
+	reader := bufio.NewReader(in)
+	switch req.Kind {
+	case "command":
+		fmt.Fprintf(out, "Task wants to run:\n\n    %s\n\n", req.Command)
+	case "edit":
+		fmt.Fprintf(out, "Task wants to edit %s:\n\n%s\n\n", req.Path, req.Diff)
+	default:
+		return Response{}, fmt.Errorf("unknown approval request kind %q", req.Kind)
+	}
+	options := "[y]es  [n]o  [a]lways  [e]dit"
+	if req.Kind == "edit" && req.FullFile != "" {
+		options += "  [v]iew full file"
+	}
+	for {
+		fmt.Fprintf(out, "%s: ", options)
+		line, err := reader.ReadString('\n')
+		if err != nil && strings.TrimSpace(line) == "" {
+			// EOF without an answer is a denial, never an approval.
+			return Response{Choice: Denied}, nil
+		}
+		switch strings.ToLower(strings.TrimSpace(line)) {
+		case "y", "yes":
+			return Response{Choice: Approved}, nil
+		case "n", "no", "":
+			return Response{Choice: Denied}, nil
+		case "a", "always":
+			return Response{Choice: Always}, nil
+		case "e", "edit":
+			if req.Kind != "command" {
+				fmt.Fprintln(out, "Only commands can be edited here.")
+				continue
+			}
+			fmt.Fprint(out, "Edited command: ")
+			edited, err := reader.ReadString('\n')
+			if err != nil && strings.TrimSpace(edited) == "" {
+				return Response{Choice: Denied}, nil
+			}
+			if edited = strings.TrimSpace(edited); edited != "" {
+				return Response{Choice: Edited, Edited: edited}, nil
+			}
+			fmt.Fprintln(out, "Empty command; action denied.")
+			return Response{Choice: Denied}, nil
+		case "v", "view":
+			if req.FullFile == "" {
+				fmt.Fprintln(out, "No full file available for this request.")
+				continue
+			}
+			if pager != nil {
+				if err := pager(req.FullFile); err != nil {
+					fmt.Fprintf(out, "pager failed: %v\n", err)
+				}
+			} else {
+				fmt.Fprintln(out, req.FullFile)
+			}
+		default:
+			fmt.Fprintln(out, "Please answer y, n, a or e.")
+		}
+	}
+}
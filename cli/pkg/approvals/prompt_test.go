@@ -0,0 +1,74 @@
+package approvals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPromptCommandChoices(t *testing.T) {
+	cases := []struct {
+		input  string
+		choice Choice
+		edited string
+	}{
+		{"y\n", Approved, ""},
+		{"n\n", Denied, ""},
+		{"a\n", Always, ""},
+		{"e\ngit push origin feature\n", Edited, "git push origin feature"},
+		{"x\nyes\n", Approved, ""}, // invalid answer re-prompts
+		{"", Denied, ""},           // EOF denies
+	}
+	for _, c := range cases {
+		var out strings.Builder
+		req := Request{Kind: "command", Command: "git push --force"}
+		resp, err := Prompt(strings.NewReader(c.input), &out, req, nil)
+		if err != nil {
+			t.Fatalf("input %q: %v", c.input, err)
+		}
+		if resp.Choice != c.choice || resp.Edited != c.edited {
+			t.Errorf("input %q: resp = %+v", c.input, resp)
+		}
+		if !strings.Contains(out.String(), "git push --force") {
+			t.Errorf("input %q: command not shown:\n%s", c.input, out.String())
+		}
+	}
+}
+
+func TestPromptEditShowsDiffAndPager(t *testing.T) {
+	var paged string
+	var out strings.Builder
+	req := Request{
+		Kind:     "edit",
+		Path:     "src/main.go",
+		Diff:     "-old line\n+new line",
+		FullFile: "package main\n\nfunc main() {}\n",
+	}
+	resp, err := Prompt(strings.NewReader("v\ny\n"), &out, req, func(content string) error {
+		paged = content
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Choice != Approved {
+		t.Errorf("resp = %+v", resp)
+	}
+	if paged != req.FullFile {
+		t.Errorf("paged = %q", paged)
+	}
+	if !strings.Contains(out.String(), "+new line") || !strings.Contains(out.String(), "src/main.go") {
+		t.Errorf("diff not shown:\n%s", out.String())
+	}
+}
+
+func TestPromptEditCannotBeEditedInline(t *testing.T) {
+	var out strings.Builder
+	req := Request{Kind: "edit", Path: "a.go", Diff: "+x"}
+	resp, err := Prompt(strings.NewReader("e\nn\n"), &out, req, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Choice != Denied {
+		t.Errorf("resp = %+v", resp)
+	}
+}
@@ -0,0 +1,154 @@
+// Package attach collects files named on the command line (paths or globs)
+// and fits them into a model's context budget, so `cline ask --file` can
+// replace manual piping without silently overflowing the context window.
+package attach
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/guardrails"
+)
+
+// File is one attachment: its content after budgeting, plus what happened to
+// it so the user can be told what the model actually saw.
+type File struct {
+	Path    string
+	Content string
+	// Tokens is the estimated size of Content as sent.
+	Tokens int
+	// Truncated and Omitted record budget decisions; Note says why.
+	Truncated bool
+	Omitted   bool
+	Note      string
+}
+
+// minUsefulTokens is the smallest truncated attachment worth sending; below
+// this the head of a file is more confusing than helpful, so the file is
+// omitted instead.
+const minUsefulTokens = 256
+
+// Expand resolves path arguments into concrete files. Arguments containing
+// glob metacharacters are matched with filepath.Glob and may legitimately
+// match several files; a plain path must exist. Duplicates are dropped,
+// order is preserved. A non-nil skip filters glob matches (ignore rules);
+// explicitly named paths are never filtered, since the user asked for them.
+func Expand(patterns []string, skip func(path string) bool) ([]string, error) {
+	var paths []string
+	seen := map[string]bool{}
+	for _, pattern := range patterns {
+		if strings.ContainsAny(pattern, "*?[") {
+			matches, err := filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("bad pattern %q: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("no files match %q", pattern)
+			}
+			for _, match := range matches {
+				if info, err := os.Stat(match); err == nil && info.IsDir() {
+					continue
+				}
+				if skip != nil && skip(match) {
+					continue
+				}
+				if !seen[match] {
+					seen[match] = true
+					paths = append(paths, match)
+				}
+			}
+			continue
+		}
+		if _, err := os.Stat(pattern); err != nil {
+			return nil, err
+		}
+		if !seen[pattern] {
+			seen[pattern] = true
+			paths = append(paths, pattern)
+		}
+	}
+	return paths, nil
+}
+
+// Load reads the expanded files. Binary files are kept in the list but
+// omitted from the content, so the summary can say why they were skipped.
+func Load(paths []string) ([]*File, error) {
+	var files []*File
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		f := &File{Path: path}
+		if bytes.IndexByte(data, 0) >= 0 {
+			f.Omitted = true
+			f.Note = "binary file"
+		} else {
+			f.Content = string(data)
+			f.Tokens = guardrails.EstimateTokens(f.Content)
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// FitBudget trims the attachments to roughly budgetTokens, in order: files
+// that fit are kept whole, the first file that crosses the budget is
+// truncated to the remaining room (if enough remains to be useful), and
+// everything after is omitted. A budget of zero or less means no limit.
+func FitBudget(files []*File, budgetTokens int) {
+	if budgetTokens <= 0 {
+		return
+	}
+	remaining := budgetTokens
+	for _, f := range files {
+		if f.Omitted {
+			continue
+		}
+		switch {
+		case f.Tokens <= remaining:
+			remaining -= f.Tokens
+		case remaining >= minUsefulTokens:
+			f.Content, _ = guardrails.Truncate(f.Content, remaining, guardrails.StrategyHead)
+			f.Tokens = remaining
+			f.Truncated = true
+			f.Note = "truncated to fit the context budget"
+			remaining = 0
+		default:
+			f.Content = ""
+			f.Omitted = true
+			f.Note = "over the context budget"
+		}
+	}
+}
+
+// Render formats the surviving attachments as a prompt preamble.
+func Render(files []*File) string {
+	var b strings.Builder
+	for _, f := range files {
+		if f.Omitted {
+			continue
+		}
+		fmt.Fprintf(&b, "File: %s\n```\n%s\n```\n\n", f.Path, strings.TrimRight(f.Content, "\n"))
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// Summary reports, one line per file, what was attached and what was cut.
+func Summary(files []*File) string {
+	var b strings.Builder
+	for _, f := range files {
+		switch {
+		case f.Omitted:
+			fmt.Fprintf(&b, "attached %s: omitted (%s)\n", f.Path, f.Note)
+		case f.Truncated:
+			fmt.Fprintf(&b, "attached %s: ~%d tokens (%s)\n", f.Path, f.Tokens, f.Note)
+		default:
+			fmt.Fprintf(&b, "attached %s: ~%d tokens\n", f.Path, f.Tokens)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,115 @@
+package attach
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestExpandGlobsAndDedupes(t *testing.T) {
+	dir := t.TempDir()
+	a := writeFile(t, dir, "a.go", "package a\n")
+	b := writeFile(t, dir, "b.go", "package b\n")
+	writeFile(t, dir, "notes.txt", "notes\n")
+
+	paths, err := Expand([]string{a, filepath.Join(dir, "*.go")}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 2 || paths[0] != a || paths[1] != b {
+		t.Errorf("paths = %v", paths)
+	}
+
+	if _, err := Expand([]string{filepath.Join(dir, "*.rs")}, nil); err == nil {
+		t.Error("no error for a glob matching nothing")
+	}
+	if _, err := Expand([]string{filepath.Join(dir, "missing.go")}, nil); err == nil {
+		t.Error("no error for a missing plain path")
+	}
+}
+
+func TestLoadSkipsBinary(t *testing.T) {
+	dir := t.TempDir()
+	text := writeFile(t, dir, "a.txt", "hello\n")
+	bin := writeFile(t, dir, "a.bin", "he\x00llo")
+
+	files, err := Load([]string{text, bin})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if files[0].Omitted || files[0].Content != "hello\n" {
+		t.Errorf("text file: %+v", files[0])
+	}
+	if !files[1].Omitted || files[1].Note != "binary file" {
+		t.Errorf("binary file: %+v", files[1])
+	}
+}
+
+func TestFitBudget(t *testing.T) {
+	files := []*File{
+		{Path: "small", Content: strings.Repeat("a", 400), Tokens: 100},
+		{Path: "big", Content: strings.Repeat("b", 8000), Tokens: 2000},
+		{Path: "last", Content: strings.Repeat("c", 400), Tokens: 100},
+	}
+	FitBudget(files, 600)
+	if files[0].Truncated || files[0].Omitted {
+		t.Errorf("small file should fit whole: %+v", files[0])
+	}
+	if !files[1].Truncated || files[1].Tokens != 500 {
+		t.Errorf("big file should be truncated to the remainder: %+v", files[1])
+	}
+	if !files[2].Omitted {
+		t.Errorf("file after the budget should be omitted: %+v", files[2])
+	}
+
+	rendered := Render(files)
+	if !strings.Contains(rendered, "File: small") || strings.Contains(rendered, "File: last") {
+		t.Errorf("rendered = %q", rendered)
+	}
+	summary := Summary(files)
+	for _, want := range []string{"attached small: ~100 tokens", "truncated", "omitted"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary %q missing %q", summary, want)
+		}
+	}
+}
+
+func TestFitBudgetUnlimited(t *testing.T) {
+	files := []*File{{Path: "a", Content: "x", Tokens: 1}}
+	FitBudget(files, 0)
+	if files[0].Truncated || files[0].Omitted {
+		t.Errorf("zero budget must mean unlimited: %+v", files[0])
+	}
+}
+
+func TestExpandSkipFiltersGlobMatchesOnly(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"keep.go", "drop.go"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte("package x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	skip := func(path string) bool { return filepath.Base(path) == "drop.go" }
+	paths, err := Expand([]string{filepath.Join(dir, "*.go")}, skip)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) != 1 || filepath.Base(paths[0]) != "keep.go" {
+		t.Fatalf("paths = %v", paths)
+	}
+	// An explicitly named path is honored even when the filter matches it.
+	paths, err = Expand([]string{filepath.Join(dir, "drop.go")}, skip)
+	if err != nil || len(paths) != 1 {
+		t.Fatalf("explicit path = %v, %v", paths, err)
+	}
+}
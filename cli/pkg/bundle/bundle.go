@@ -0,0 +1,240 @@
+// Package bundle packages provider definitions, cached model catalogs, and
+// optionally the CLI binary itself into a single gzipped tar, so an
+// air-gapped machine can be brought up to date by carrying one file across
+// the gap.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// manifestName is the first entry of every bundle.
+const manifestName = "manifest.json"
+
+// Manifest describes a bundle so apply can refuse artifacts it does not
+// understand (or that were built for another platform's binary).
+type Manifest struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	GOOS      string    `json:"goos"`
+	GOARCH    string    `json:"goarch"`
+	// Binary reports whether the bundle carries the CLI executable.
+	Binary bool `json:"binary,omitempty"`
+}
+
+// DefinitionsOverridePath is where an applied bundle's definitions document
+// lives; when present, it replaces the definitions embedded in the binary.
+func DefinitionsOverridePath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "definitions.json"), nil
+}
+
+// Create writes a bundle to w: the embedded provider definitions, every
+// cached catalog under the data directory, and (when includeBinary) the
+// running executable. It returns the entry names written.
+func Create(w io.Writer, includeBinary bool) ([]string, error) {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	var entries []string
+	add := func(name string, mode int64, data []byte) error {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: mode, Size: int64(len(data)), ModTime: time.Now()}); err != nil {
+			return err
+		}
+		if _, err := tw.Write(data); err != nil {
+			return err
+		}
+		entries = append(entries, name)
+		return nil
+	}
+
+	manifest, err := json.Marshal(Manifest{
+		Version:   1,
+		CreatedAt: time.Now().UTC(),
+		GOOS:      runtime.GOOS,
+		GOARCH:    runtime.GOARCH,
+		Binary:    includeBinary,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := add(manifestName, 0o600, manifest); err != nil {
+		return nil, err
+	}
+	if err := add("definitions.json", 0o600, generated.DefinitionsJSON()); err != nil {
+		return nil, err
+	}
+
+	dataDir, err := config.GetDataDir()
+	if err != nil {
+		return nil, err
+	}
+	catalogs, err := os.ReadDir(filepath.Join(dataDir, "catalogs"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range catalogs {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dataDir, "catalogs", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if err := add("catalogs/"+entry.Name(), 0o600, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if includeBinary {
+		exe, err := os.Executable()
+		if err != nil {
+			return nil, err
+		}
+		data, err := os.ReadFile(exe)
+		if err != nil {
+			return nil, err
+		}
+		if err := add("bin/"+filepath.Base(exe), 0o700, data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return entries, gz.Close()
+}
+
+// Apply reads a bundle and populates the local caches: definitions go to the
+// override path, catalogs into the catalog cache. A bundled binary is written
+// next to the running executable with a ".new" suffix for the operator to
+// swap in; a running process cannot safely replace itself on every platform.
+// It returns one line per applied entry.
+func Apply(r io.Reader) ([]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a bundle: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	var applied []string
+	seenManifest := false
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return applied, err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return applied, err
+		}
+		name := hdr.Name
+		if !seenManifest {
+			if name != manifestName {
+				return applied, fmt.Errorf("bundle does not start with %s", manifestName)
+			}
+			var m Manifest
+			if err := json.Unmarshal(data, &m); err != nil {
+				return applied, fmt.Errorf("decoding manifest: %w", err)
+			}
+			if m.Version != 1 {
+				return applied, fmt.Errorf("unsupported bundle version %d", m.Version)
+			}
+			if m.Binary && (m.GOOS != runtime.GOOS || m.GOARCH != runtime.GOARCH) {
+				return applied, fmt.Errorf("bundle binary is %s/%s, this machine is %s/%s", m.GOOS, m.GOARCH, runtime.GOOS, runtime.GOARCH)
+			}
+			seenManifest = true
+			continue
+		}
+		switch {
+		case name == "definitions.json":
+			if _, err := generated.NewProviderRegistryFromJSON(data); err != nil {
+				return applied, fmt.Errorf("bundle definitions: %w", err)
+			}
+			path, err := DefinitionsOverridePath()
+			if err != nil {
+				return applied, err
+			}
+			if err := writeEntry(path, data, 0o600); err != nil {
+				return applied, err
+			}
+			applied = append(applied, "definitions -> "+path)
+		case strings.HasPrefix(name, "catalogs/"):
+			dataDir, err := config.GetDataDir()
+			if err != nil {
+				return applied, err
+			}
+			// filepath.Base defuses any path traversal in the entry name.
+			path := filepath.Join(dataDir, "catalogs", filepath.Base(name))
+			if err := writeEntry(path, data, 0o600); err != nil {
+				return applied, err
+			}
+			applied = append(applied, "catalog -> "+path)
+		case strings.HasPrefix(name, "bin/"):
+			exe, err := os.Executable()
+			if err != nil {
+				return applied, err
+			}
+			path := exe + ".new"
+			if err := writeEntry(path, data, 0o700); err != nil {
+				return applied, err
+			}
+			applied = append(applied, fmt.Sprintf("binary -> %s (replace %s with it to finish the update)", path, exe))
+		default:
+			return applied, fmt.Errorf("unexpected bundle entry %q", name)
+		}
+	}
+	if !seenManifest {
+		return applied, fmt.Errorf("bundle is empty")
+	}
+	return applied, nil
+}
+
+// List names a bundle's entries without applying anything, for dry runs and
+// inspection.
+func List(r io.Reader) ([]string, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a bundle: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+	var entries []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return entries, nil
+		}
+		if err != nil {
+			return entries, err
+		}
+		entries = append(entries, hdr.Name)
+	}
+}
+
+// writeEntry writes one applied file, creating its directory.
+func writeEntry(path string, data []byte, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, mode)
+}
@@ -0,0 +1,109 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateAndApply(t *testing.T) {
+	source := t.TempDir()
+	t.Setenv("CLINE_CONFIG_DIR", source)
+	if err := os.MkdirAll(filepath.Join(source, "catalogs"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	catalog := []byte(`{"url":"https://example.test/models","body":"e30="}`)
+	if err := os.WriteFile(filepath.Join(source, "catalogs", "abc123.json"), catalog, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	entries, err := Create(&buf, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"manifest.json", "definitions.json", "catalogs/abc123.json"}
+	if len(entries) != len(want) {
+		t.Fatalf("entries = %v", entries)
+	}
+	for i, name := range want {
+		if entries[i] != name {
+			t.Errorf("entries[%d] = %q, want %q", i, entries[i], name)
+		}
+	}
+
+	target := t.TempDir()
+	t.Setenv("CLINE_CONFIG_DIR", target)
+	applied, err := Apply(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("applied = %v", applied)
+	}
+	if _, err := os.Stat(filepath.Join(target, "definitions.json")); err != nil {
+		t.Error(err)
+	}
+	got, err := os.ReadFile(filepath.Join(target, "catalogs", "abc123.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, catalog) {
+		t.Errorf("catalog = %q", got)
+	}
+}
+
+func TestApplyRejectsUnexpectedEntries(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	add := func(name string, data []byte) {
+		t.Helper()
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o600, Size: int64(len(data)), ModTime: time.Now()}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	add("manifest.json", []byte(`{"version":1,"goos":"linux","goarch":"amd64"}`))
+	add("../escape", []byte("nope"))
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Apply(&buf); err == nil || !strings.Contains(err.Error(), "unexpected bundle entry") {
+		t.Errorf("err = %v", err)
+	}
+}
+
+func TestApplyRejectsWrongVersion(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	manifest := []byte(`{"version":2}`)
+	if err := tw.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0o600, Size: int64(len(manifest)), ModTime: time.Now()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write(manifest); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Apply(&buf); err == nil || !strings.Contains(err.Error(), "unsupported bundle version") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,131 @@
+// Package checks defines the shared result model for validation and doctor
+// checks, plus the JUnit and SARIF renderers CI systems consume.
+package checks
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+)
+
+// Result is the outcome of one named check.
+type Result struct {
+	// ID is a stable, dash-separated check identifier (e.g.
+	// "config-file-permissions").
+	ID          string
+	Description string
+	OK          bool
+	// Detail explains a failure and suggests a remediation.
+	Detail string
+	// Path optionally names the file the check applies to.
+	Path string
+}
+
+// Failed counts the failing results.
+func Failed(results []Result) int {
+	n := 0
+	for _, r := range results {
+		if !r.OK {
+			n++
+		}
+	}
+	return n
+}
+
+// JUnit renders results as a JUnit XML test suite, one test case per check.
+func JUnit(suite string, results []Result) ([]byte, error) {
+	type failure struct {
+		Message string `xml:"message,attr"`
+		Body    string `xml:",chardata"`
+	}
+	type testcase struct {
+		Name      string   `xml:"name,attr"`
+		ClassName string   `xml:"classname,attr"`
+		Failure   *failure `xml:"failure,omitempty"`
+	}
+	type testsuite struct {
+		XMLName  xml.Name   `xml:"testsuite"`
+		Name     string     `xml:"name,attr"`
+		Tests    int        `xml:"tests,attr"`
+		Failures int        `xml:"failures,attr"`
+		Cases    []testcase `xml:"testcase"`
+	}
+	ts := testsuite{Name: suite, Tests: len(results), Failures: Failed(results)}
+	for _, r := range results {
+		tc := testcase{Name: r.ID, ClassName: suite}
+		if !r.OK {
+			tc.Failure = &failure{Message: r.Description, Body: r.Detail}
+		}
+		ts.Cases = append(ts.Cases, tc)
+	}
+	out, err := xml.MarshalIndent(ts, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+// SARIF renders failing results as a SARIF 2.1.0 log so pull requests that
+// change committed configs get annotations.
+func SARIF(tool string, results []Result) ([]byte, error) {
+	type location struct {
+		PhysicalLocation struct {
+			ArtifactLocation struct {
+				URI string `json:"uri"`
+			} `json:"artifactLocation"`
+		} `json:"physicalLocation"`
+	}
+	type sarifResult struct {
+		RuleID  string `json:"ruleId"`
+		Level   string `json:"level"`
+		Message struct {
+			Text string `json:"text"`
+		} `json:"message"`
+		Locations []location `json:"locations,omitempty"`
+	}
+	type rule struct {
+		ID               string `json:"id"`
+		ShortDescription struct {
+			Text string `json:"text"`
+		} `json:"shortDescription"`
+	}
+	var rules []rule
+	var sarifResults []sarifResult
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if r.OK {
+			continue
+		}
+		if !seen[r.ID] {
+			seen[r.ID] = true
+			ru := rule{ID: r.ID}
+			ru.ShortDescription.Text = r.Description
+			rules = append(rules, ru)
+		}
+		sr := sarifResult{RuleID: r.ID, Level: "error"}
+		sr.Message.Text = r.Detail
+		if sr.Message.Text == "" {
+			sr.Message.Text = r.Description
+		}
+		if r.Path != "" {
+			var loc location
+			loc.PhysicalLocation.ArtifactLocation.URI = r.Path
+			sr.Locations = append(sr.Locations, loc)
+		}
+		sarifResults = append(sarifResults, sr)
+	}
+	log := map[string]any{
+		"version": "2.1.0",
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"runs": []map[string]any{{
+			"tool": map[string]any{
+				"driver": map[string]any{
+					"name":  fmt.Sprintf("cline %s", tool),
+					"rules": rules,
+				},
+			},
+			"results": sarifResults,
+		}},
+	}
+	return json.MarshalIndent(log, "", "  ")
+}
@@ -0,0 +1,75 @@
+package checks
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+var sample = []Result{
+	{ID: "config-parse", Description: "Config file parses", OK: true},
+	{ID: "config-file-permissions", Description: "Config file is owner-only", OK: false,
+		Detail: "config is readable by other users", Path: "/home/u/.cline/config.json"},
+}
+
+func TestJUnit(t *testing.T) {
+	out, err := JUnit("doctor", sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var suite struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Cases    []struct {
+			Name    string `xml:"name,attr"`
+			Failure *struct {
+				Message string `xml:"message,attr"`
+			} `xml:"failure"`
+		} `xml:"testcase"`
+	}
+	if err := xml.Unmarshal(out, &suite); err != nil {
+		t.Fatalf("output is not valid XML: %v\n%s", err, out)
+	}
+	if suite.Tests != 2 || suite.Failures != 1 {
+		t.Errorf("tests=%d failures=%d", suite.Tests, suite.Failures)
+	}
+	if suite.Cases[0].Failure != nil {
+		t.Error("passing check rendered a <failure>")
+	}
+	if suite.Cases[1].Failure == nil {
+		t.Error("failing check missing <failure>")
+	}
+}
+
+func TestSARIF(t *testing.T) {
+	out, err := SARIF("doctor", sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var log struct {
+		Version string `json:"version"`
+		Runs    []struct {
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Locations []any  `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}
+	if err := json.Unmarshal(out, &log); err != nil {
+		t.Fatalf("output is not valid JSON: %v", err)
+	}
+	if log.Version != "2.1.0" {
+		t.Errorf("version = %q", log.Version)
+	}
+	results := log.Runs[0].Results
+	if len(results) != 1 || results[0].RuleID != "config-file-permissions" {
+		t.Errorf("results = %+v", results)
+	}
+	if len(results[0].Locations) != 1 {
+		t.Error("failing check missing file location")
+	}
+	if strings.Contains(string(out), "config-parse\",\n      \"level\": \"error\"") {
+		t.Error("passing check emitted as error")
+	}
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/cline/cline/cli/pkg/cloudsync"
+)
+
+func adminCommand() *Command {
+	return &Command{
+		Name:    "admin",
+		Summary: "Administrative operations against the account service.",
+		Sub: []*Command{
+			{
+				Name:    "revoke",
+				Summary: "Invalidate a user's account session; their machines wipe local keys per policy.",
+				Usage:   "cline admin revoke --user <id>",
+				Run:     runAdminRevoke,
+			},
+		},
+	}
+}
+
+func runAdminRevoke(app *App, args []string) error {
+	fs := flag.NewFlagSet("admin revoke", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	user := fs.String("user", "", "account ID of the user to offboard")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *user == "" {
+		return fmt.Errorf("usage: cline admin revoke --user <id>")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	endpoint := cfg.Settings[cloudsync.SettingEndpoint]
+	token := cfg.Settings[cloudsync.SettingToken]
+	if endpoint == "" || token == "" {
+		return fmt.Errorf("admin operations need %s and %s configured", cloudsync.SettingEndpoint, cloudsync.SettingToken)
+	}
+	if app.dryRun {
+		app.dryRunSkip(fmt.Sprintf("DELETE %s/v1/sessions/%s", endpoint, *user))
+		return nil
+	}
+	if err := cloudsync.Revoke(context.Background(), endpoint, token, *user); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Revoked the session for %s; their machines will wipe stored keys on next run if policy sets wipe-keys-on-revoke.\n", *user)
+	return nil
+}
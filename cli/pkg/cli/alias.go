@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func aliasCommand() *Command {
+	return &Command{
+		Name:    "alias",
+		Summary: "Manage command shortcuts expanded before dispatch.",
+		Usage:   "cline alias",
+		Run:     runAliasList,
+		Sub: []*Command{
+			{
+				Name:    "set",
+				Summary: "Define a shortcut for a longer command line.",
+				Usage:   `cline alias set <name> "<command line>"`,
+				Run:     runAliasSet,
+			},
+			{
+				Name:    "remove",
+				Summary: "Delete a shortcut.",
+				Usage:   "cline alias remove <name>",
+				Run:     runAliasRemove,
+			},
+		},
+	}
+}
+
+func runAliasList(app *App, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: cline alias")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	if len(cfg.CommandAliases) == 0 {
+		fmt.Fprintln(app.Stdout, "No command aliases defined.")
+		return nil
+	}
+	for _, name := range sortedKeys(cfg.CommandAliases) {
+		fmt.Fprintf(app.Stdout, "%-16s %s\n", name, cfg.CommandAliases[name])
+	}
+	return nil
+}
+
+func runAliasSet(app *App, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf(`usage: cline alias set <name> "<command line>"`)
+	}
+	name, expansion := args[0], strings.TrimSpace(args[1])
+	if expansion == "" {
+		return fmt.Errorf("alias expansion must not be empty")
+	}
+	if strings.HasPrefix(name, "-") {
+		return fmt.Errorf("alias name %q must not start with a dash", name)
+	}
+	if rootCommand().find(name) != nil {
+		return fmt.Errorf("%q is a built-in command and cannot be aliased", name)
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		if cfg.CommandAliases == nil {
+			cfg.CommandAliases = make(map[string]string)
+		}
+		cfg.CommandAliases[name] = expansion
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Aliased %q to %q.\n", name, expansion)
+	return nil
+}
+
+func runAliasRemove(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline alias remove <name>")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	removed := false
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		if _, ok := cfg.CommandAliases[args[0]]; ok {
+			delete(cfg.CommandAliases, args[0])
+			removed = true
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("no alias named %q", args[0])
+	}
+	fmt.Fprintf(app.Stdout, "Removed alias %q.\n", args[0])
+	return nil
+}
+
+// expandCommandAlias rewrites args when the first token is a user-defined
+// alias. Built-in commands always win over aliases, and expansion happens
+// once: an alias cannot refer to another alias.
+func (a *App) expandCommandAlias(args []string) []string {
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return args
+	}
+	if rootCommand().find(args[0]) != nil {
+		return args
+	}
+	manager, err := a.ConfigManager()
+	if err != nil {
+		return args
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return args
+	}
+	expansion, ok := cfg.CommandAliases[args[0]]
+	if !ok {
+		return args
+	}
+	return append(strings.Fields(expansion), args[1:]...)
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/approvals"
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func approvalsCommand() *Command {
+	return &Command{
+		Name:    "approvals",
+		Summary: "Configure auto-approve rules for task actions.",
+		Sub: []*Command{
+			{
+				Name:    "show",
+				Summary: "Print the current approval policy.",
+				Usage:   "cline settings approvals show",
+				Run:     runApprovalsShow,
+			},
+			{
+				Name:    "set",
+				Summary: "Set an approval rule.",
+				Usage:   "cline settings approvals set auto-edits|read-only|allow|deny <value>",
+				Run:     runApprovalsSet,
+			},
+			{
+				Name:    "explain",
+				Summary: "Dry-run: show what a given action would require.",
+				Usage:   "cline settings approvals explain --command <cmd> | --edit <path>",
+				Run:     runApprovalsExplain,
+			},
+		},
+	}
+}
+
+// approvalRuleKeys maps the short rule names the command takes to the
+// underlying settings keys.
+var approvalRuleKeys = map[string]string{
+	"auto-edits": approvals.SettingAutoEdits,
+	"read-only":  approvals.SettingAutoReadOnly,
+	"allow":      approvals.SettingAllowlist,
+	"deny":       approvals.SettingDenylist,
+}
+
+func runApprovalsShow(app *App, args []string) error {
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	policy := approvals.FromSettings(cfg.Settings)
+	fmt.Fprintf(app.Stdout, "auto-edits (workspace file edits): %v\n", policy.AutoEditsInWorkspace)
+	fmt.Fprintf(app.Stdout, "read-only (read-only commands):    %v\n", policy.AutoReadOnly)
+	fmt.Fprintf(app.Stdout, "allow: %s\n", joinOrNone(policy.Allow))
+	fmt.Fprintf(app.Stdout, "deny:  %s\n", joinOrNone(policy.Deny))
+	return nil
+}
+
+func joinOrNone(items []string) string {
+	if len(items) == 0 {
+		return "(none)"
+	}
+	return strings.Join(items, ", ")
+}
+
+func runApprovalsSet(app *App, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: cline settings approvals set auto-edits|read-only|allow|deny <value>")
+	}
+	key, ok := approvalRuleKeys[args[0]]
+	if !ok {
+		return fmt.Errorf("unknown approval rule %q (want auto-edits, read-only, allow or deny)", args[0])
+	}
+	if args[0] == "auto-edits" || args[0] == "read-only" {
+		if args[1] != "true" && args[1] != "false" {
+			return fmt.Errorf("%s takes true or false", args[0])
+		}
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	return manager.Update(func(cfg *config.ClineConfig) error {
+		if cfg.Settings == nil {
+			cfg.Settings = make(map[string]string)
+		}
+		cfg.Settings[key] = args[1]
+		return nil
+	})
+}
+
+func runApprovalsExplain(app *App, args []string) error {
+	fs := flag.NewFlagSet("settings approvals explain", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	command := fs.String("command", "", "shell command to evaluate")
+	edit := fs.String("edit", "", "file path to evaluate as an edit")
+	workspace := fs.String("workspace", ".", "workspace root for edit evaluation")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if (*command == "") == (*edit == "") {
+		return fmt.Errorf("pass exactly one of --command or --edit")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	policy := approvals.FromSettings(cfg.Settings)
+	var decision approvals.Decision
+	if *command != "" {
+		decision = policy.EvaluateCommand(*command)
+	} else {
+		decision = policy.EvaluateEdit(*edit, *workspace)
+	}
+	verdict := "requires approval"
+	if decision.Auto {
+		verdict = "auto-approved"
+	}
+	fmt.Fprintf(app.Stdout, "%s: %s\n", verdict, decision.Reason)
+	return nil
+}
@@ -0,0 +1,439 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/attach"
+	"github.com/cline/cline/cli/pkg/clipboard"
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/guardrails"
+	"github.com/cline/cline/cli/pkg/history"
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/redact"
+	"github.com/cline/cline/cli/pkg/respcache"
+	"github.com/cline/cline/cli/pkg/schema"
+	"github.com/cline/cline/cli/pkg/task"
+	"github.com/cline/cline/cli/pkg/tools"
+	usageledger "github.com/cline/cline/cli/pkg/usage"
+)
+
+// stringList is a repeatable string flag.
+type stringList []string
+
+func (s *stringList) String() string { return strings.Join(*s, ",") }
+
+func (s *stringList) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
+
+func askCommand() *Command {
+	return &Command{
+		Name:    "ask",
+		Summary: "Send a one-off prompt to the default (or given) provider.",
+		Usage:   "cline ask [--provider <id>] [--model <id>] [--file <path-or-glob>] [--with-context] [--no-cache] <prompt>",
+		Run:     runAsk,
+	}
+}
+
+func runAsk(app *App, args []string) error {
+	fs := flag.NewFlagSet("ask", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	model := fs.String("model", "", "model ID override")
+	var imagePaths stringList
+	fs.Var(&imagePaths, "image", "image file to attach (repeatable; model must support images)")
+	var filePatterns stringList
+	fs.Var(&filePatterns, "file", "file or glob to attach as context (repeatable)")
+	instructionsName := fs.String("instructions", "", "instruction profile to use (default: workspace default, if set)")
+	schemaPath := fs.String("schema", "", "JSON schema file the reply must conform to (prints only the validated JSON)")
+	toolsPath := fs.String("tools", "", "tool definitions file; the model may call the tools and they run as local commands")
+	var tagPairs stringList
+	fs.Var(&tagPairs, "tag", "cost attribution tag as key=value (repeatable; see `cline usage show --by tag`)")
+	withContext := fs.Bool("with-context", false, "attach the cached workspace context summary (see `cline context build`)")
+	noCache := fs.Bool("no-cache", false, "bypass the response cache even when cache.responses is enabled")
+	fromClipboard := fs.Bool("from-clipboard", false, "read the prompt from the system clipboard")
+	toClipboard := fs.Bool("to-clipboard", false, "copy the reply to the system clipboard")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+
+	prompt := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if *fromClipboard {
+		clip, err := clipboard.Read()
+		if err != nil {
+			return err
+		}
+		// An explicit prompt becomes the question about the pasted content.
+		prompt = strings.TrimSpace(strings.TrimSpace(clip) + "\n\n" + prompt)
+	}
+	if prompt == "" {
+		data, err := io.ReadAll(app.Stdin)
+		if err != nil {
+			return err
+		}
+		prompt = strings.TrimSpace(string(data))
+	}
+	if prompt == "" {
+		return fmt.Errorf("usage: cline ask <prompt> (or pipe the prompt on stdin)")
+	}
+
+	var images []llm.Image
+	for _, path := range imagePaths {
+		img, err := llm.LoadImage(path)
+		if err != nil {
+			return err
+		}
+		images = append(images, img)
+	}
+	system, err := resolveInstructions(cfg, *instructionsName)
+	if err != nil {
+		return err
+	}
+	var files []*attach.File
+	if len(filePatterns) > 0 {
+		paths, err := attach.Expand(filePatterns, ignoreSkip())
+		if err != nil {
+			return err
+		}
+		if files, err = attach.Load(paths); err != nil {
+			return err
+		}
+	}
+
+	req := askRequest{
+		providerID: *provider,
+		modelID:    *model,
+		system:     system,
+		text:       prompt,
+		images:     images,
+		files:      files,
+		noCache:    *noCache,
+	}
+	if *withContext {
+		if req.context, err = workspaceContext(); err != nil {
+			return err
+		}
+	}
+	if req.tags, err = parseTags(tagPairs); err != nil {
+		return err
+	}
+	var reply string
+	switch {
+	case *schemaPath != "" && *toolsPath != "":
+		return fmt.Errorf("--schema and --tools cannot be combined")
+	case *schemaPath != "":
+		reply, err = app.sendWithSchema(cfg, req, *schemaPath)
+	case *toolsPath != "":
+		var defs []tools.Definition
+		if defs, err = tools.Load(*toolsPath); err != nil {
+			return err
+		}
+		reply, err = app.runToolLoop(cfg, req, defs)
+	default:
+		reply, err = app.sendPrompt(cfg, req)
+	}
+	if err != nil {
+		return err
+	}
+	if *toClipboard {
+		if err := clipboard.Write(reply); err != nil {
+			fmt.Fprintf(app.Stderr, "warning: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// askRequest is one resolved one-shot prompt for sendPrompt.
+type askRequest struct {
+	providerID string // empty: configured default provider
+	modelID    string // empty: the entry's model
+	system     string
+	text       string
+	images     []llm.Image
+	// files are context attachments, fitted to the model's budget right
+	// before sending (the model is only known then).
+	files []*attach.File
+	// quiet suppresses printing the reply; callers that post-process the
+	// response (review, schema mode) render it themselves.
+	quiet bool
+	// forceJSON requests JSON-mode output where the provider supports it.
+	forceJSON bool
+	// noCache bypasses the opt-in response cache for this request.
+	noCache bool
+	// context is the workspace summary to prepend, trimmed to whatever the
+	// model's budget leaves over.
+	context string
+	// tags are cost attribution labels carried into the usage ledger.
+	tags map[string]string
+}
+
+// applyModelAlias expands a user-defined model alias in the request. An alias
+// whose value names a provider also supplies it, unless the caller gave one
+// explicitly.
+func applyModelAlias(cfg *config.ClineConfig, req askRequest) askRequest {
+	if provider, modelID, ok := cfg.ResolveModelAlias(req.modelID); ok {
+		req.modelID = modelID
+		if req.providerID == "" {
+			req.providerID = provider
+		}
+	}
+	return req
+}
+
+// minContextTokens is the least budget worth spending on the workspace
+// summary; below this it is dropped instead of truncated to uselessness.
+const minContextTokens = 256
+
+// maxSchemaRepairs bounds how many times a non-conforming reply is sent back
+// for correction before giving up.
+const maxSchemaRepairs = 2
+
+const schemaAskPrompt = `%s
+
+Respond with ONLY a JSON document conforming to this JSON schema:
+%s`
+
+const schemaRepairPrompt = `Your previous reply did not conform to the required JSON schema.
+
+Problems:
+%s
+
+Previous reply:
+%s
+
+Respond again with ONLY a corrected JSON document conforming to this JSON schema:
+%s`
+
+// sendWithSchema runs sendPrompt in structured-output mode: the reply must
+// parse as JSON and validate against the schema file, and non-conforming
+// replies are sent back for repair a bounded number of times. Only the
+// validated JSON document is printed, so scripts can pipe it straight into a
+// parser.
+func (a *App) sendWithSchema(cfg *config.ClineConfig, req askRequest, schemaPath string) (string, error) {
+	schemaDoc, err := os.ReadFile(schemaPath)
+	if err != nil {
+		return "", err
+	}
+	s, err := schema.Parse(schemaDoc)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", schemaPath, err)
+	}
+	req.quiet = true
+	req.forceJSON = true
+	req.text = fmt.Sprintf(schemaAskPrompt, req.text, strings.TrimSpace(string(schemaDoc)))
+	for attempt := 0; ; attempt++ {
+		reply, err := a.sendPrompt(cfg, req)
+		if err != nil {
+			return "", err
+		}
+		doc, problems := validateAgainstSchema(s, reply)
+		if len(problems) == 0 {
+			fmt.Fprintln(a.Stdout, doc)
+			return doc, nil
+		}
+		if attempt == maxSchemaRepairs {
+			return "", fmt.Errorf("reply still does not match %s after %d repair attempt(s): %s",
+				schemaPath, maxSchemaRepairs, strings.Join(problems, "; "))
+		}
+		fmt.Fprintf(a.Stderr, "warning: reply failed schema validation (%s); asking for a repair (%d/%d)\n",
+			strings.Join(problems, "; "), attempt+1, maxSchemaRepairs)
+		req.text = fmt.Sprintf(schemaRepairPrompt,
+			strings.Join(problems, "\n"), reply, strings.TrimSpace(string(schemaDoc)))
+	}
+}
+
+// validateAgainstSchema extracts the JSON document from a reply and validates
+// it, folding extraction errors into the problem list so they drive the same
+// repair loop.
+func validateAgainstSchema(s *schema.Schema, reply string) (string, []string) {
+	doc, err := schema.ExtractJSON(reply)
+	if err != nil {
+		return "", []string{err.Error()}
+	}
+	var value any
+	if err := json.Unmarshal([]byte(doc), &value); err != nil {
+		return "", []string{err.Error()}
+	}
+	return doc, s.Validate(value)
+}
+
+// sendPrompt runs the shared one-shot pipeline used by ask and prompt run:
+// provider resolution, repo policy checks, redaction, context guardrails, the
+// completion itself, and recording the exchange as a task.
+func (a *App) sendPrompt(cfg *config.ClineConfig, req askRequest) (string, error) {
+	req = applyModelAlias(cfg, req)
+	providerID := req.providerID
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	if providerID == "" {
+		return "", fmt.Errorf("no provider configured; run `cline auth add --provider <id>`")
+	}
+	entry, ok := cfg.Providers[providerID]
+	if !ok {
+		return "", fmt.Errorf("provider %q is not configured", providerID)
+	}
+	if req.modelID != "" {
+		entry.ModelID = req.modelID
+	}
+	if pol, err := a.Policy(); err == nil && pol != nil {
+		if !pol.AllowsProvider(providerID) {
+			return "", fmt.Errorf("provider %q is not allowed by %s", providerID, pol.Path)
+		}
+		if entry.ModelID != "" && !pol.AllowsModel(entry.ModelID) {
+			return "", fmt.Errorf("model %q is not allowed by %s", entry.ModelID, pol.Path)
+		}
+		if pol.RequireRetentionAck && cfg.Settings[config.RetentionAckSetting(providerID)] != "true" {
+			return "", fmt.Errorf("%s requires acknowledging %s's data-retention notice; review it with `cline providers show %s`, then run `cline settings set %s true`",
+				pol.Path, providerID, providerID, config.RetentionAckSetting(providerID))
+		}
+	}
+	registry, err := a.Registry()
+	if err != nil {
+		return "", err
+	}
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return "", err
+	}
+
+	redactor, err := redact.FromSettings(cfg.Settings)
+	if err != nil {
+		return "", err
+	}
+	prompt, matches := redactor.Apply(req.text)
+	if len(matches) > 0 {
+		fmt.Fprintf(a.Stderr, "warning: redacted %d span(s) before sending\n", len(matches))
+	}
+
+	limits, err := guardrails.ForProvider(def, entry, cfg.Settings)
+	if err != nil {
+		return "", err
+	}
+	if len(req.files) > 0 {
+		// Attachments get whatever the budget leaves after the prompt itself;
+		// the global truncation below stays as a safety net for the combined
+		// text.
+		fileBudget := 0
+		if limits.BudgetTokens > 0 {
+			fileBudget = limits.BudgetTokens - guardrails.EstimateTokens(prompt)
+		}
+		attach.FitBudget(req.files, fileBudget)
+		block, fileMatches := redactor.Apply(attach.Render(req.files))
+		if len(fileMatches) > 0 {
+			fmt.Fprintf(a.Stderr, "warning: redacted %d span(s) in attached files\n", len(fileMatches))
+		}
+		if block != "" {
+			prompt = block + "\n\n" + prompt
+		}
+		fmt.Fprint(a.Stderr, attach.Summary(req.files))
+	}
+	if req.context != "" {
+		// The workspace summary takes whatever the budget leaves after the
+		// prompt and attachments; when nothing useful is left it is dropped
+		// rather than crowding out the question.
+		block, ctxMatches := redactor.Apply(req.context)
+		if len(ctxMatches) > 0 {
+			fmt.Fprintf(a.Stderr, "warning: redacted %d span(s) in the workspace context\n", len(ctxMatches))
+		}
+		if limits.BudgetTokens > 0 {
+			ctxBudget := limits.BudgetTokens - guardrails.EstimateTokens(prompt)
+			if ctxBudget < minContextTokens {
+				fmt.Fprintln(a.Stderr, "warning: no budget left for the workspace context; skipping it")
+				block = ""
+			} else if trimmed, cut := guardrails.Truncate(block, ctxBudget, guardrails.StrategyHead); cut {
+				fmt.Fprintln(a.Stderr, "warning: workspace context truncated to fit the token budget")
+				block = trimmed
+			}
+		}
+		if block != "" {
+			prompt = block + "\n\n" + prompt
+		}
+	}
+	if est := guardrails.EstimateTokens(prompt); limits.BudgetTokens > 0 && est > limits.BudgetTokens {
+		fmt.Fprintf(a.Stderr, "warning: input is ~%d tokens, over the %d token budget; truncating (%s)\n",
+			est, limits.BudgetTokens, limits.Strategy)
+		prompt, _ = guardrails.Truncate(prompt, limits.BudgetTokens, limits.Strategy)
+	}
+
+	// The opt-in response cache sits right before the provider call, keyed
+	// on the final redacted prompt so a hit means the request really would
+	// have been identical. Images are not part of the key, so requests with
+	// images never cache.
+	useCache := respcache.Enabled(cfg.Settings) && !req.noCache && len(req.images) == 0
+	cacheKey := respcache.Key(providerID, entry.ModelID, req.system, prompt)
+	if useCache {
+		if reply, ok := respcache.Get(cacheKey, respcache.TTL(cfg.Settings)); ok {
+			fmt.Fprintln(a.Stderr, "cached reply (set --no-cache to bypass)")
+			if !req.quiet {
+				fmt.Fprintln(a.Stdout, reply)
+			}
+			return reply, nil
+		}
+	}
+
+	start := time.Now()
+	providerID, reply, usage, err := a.complete(context.Background(), registry, cfg, providerID, entry,
+		llm.Prompt{System: req.system, Text: prompt, Images: req.images, ForceJSON: req.forceJSON})
+	if err != nil {
+		return "", err
+	}
+	if usage.CacheReadTokens > 0 || usage.CacheWriteTokens > 0 {
+		fmt.Fprintf(a.Stderr, "prompt cache: %d token(s) read, %d written\n",
+			usage.CacheReadTokens, usage.CacheWriteTokens)
+	}
+	if !req.quiet {
+		fmt.Fprintln(a.Stdout, reply)
+	}
+	if useCache {
+		respcache.Put(cacheKey, reply, respcache.TTL(cfg.Settings))
+	}
+
+	// Record the exchange so it can be audited and exported later; failing to
+	// save must not fail the ask itself.
+	record := &task.Task{
+		ID:        task.NewID(),
+		StartedAt: start,
+		Provider:  providerID,
+		ModelID:   entry.ModelID,
+		Events: []task.Event{
+			{Time: start, Role: "user", Content: prompt},
+			{Time: time.Now(), Role: "assistant", Content: reply, CostUSD: usage.CostUSD},
+		},
+	}
+	if err := task.Save(record); err != nil {
+		fmt.Fprintf(a.Stderr, "warning: could not record task: %v\n", err)
+	}
+	history.Record(cfg.Settings, history.Entry{
+		Command:  "ask",
+		Provider: providerID,
+		ModelID:  entry.ModelID,
+		Prompt:   prompt,
+		CostUSD:  usage.CostUSD,
+	})
+	usageledger.Add(usageledger.Record{
+		Command:      "ask",
+		Provider:     providerID,
+		ModelID:      entry.ModelID,
+		InputTokens:  usage.InputTokens,
+		OutputTokens: usage.OutputTokens,
+		CostUSD:      usage.CostUSD,
+		Tags:         req.tags,
+	})
+	return reply, nil
+}
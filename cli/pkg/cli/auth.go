@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/dotenv"
+	"github.com/cline/cline/cli/pkg/hooks"
+	"github.com/cline/cline/cli/pkg/wizard"
+)
+
+func authCommand() *Command {
+	return &Command{
+		Name:    "auth",
+		Summary: "Manage provider credentials.",
+		Sub: []*Command{
+			{
+				Name:    "add",
+				Summary: "Configure a provider, prompting for missing fields.",
+				Usage:   "cline auth add --provider <id>",
+				Run:     runAuthAdd,
+			},
+			{
+				Name:    "list",
+				Summary: "List configured providers.",
+				Usage:   "cline auth list",
+				Run:     runAuthList,
+			},
+			{
+				Name:    "remove",
+				Summary: "Remove a configured provider.",
+				Usage:   "cline auth remove --provider <id>",
+				Run:     runAuthRemove,
+			},
+		},
+	}
+}
+
+func runAuthAdd(app *App, args []string) error {
+	fs := flag.NewFlagSet("auth add", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID to configure")
+	fromEnv := fs.String("from-env", "", "dotenv file to pull field values from (e.g. .env.cline)")
+	qr := fs.Bool("qr", false, "show the API-key page as a QR code (requires qrencode)")
+	watch := fs.Bool("watch-clipboard", false, "fill the API key by watching the clipboard instead of prompting")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *provider == "" {
+		return fmt.Errorf("--provider is required")
+	}
+	w, err := app.Wizard()
+	if err != nil {
+		return err
+	}
+	w.ShowQR = *qr
+	w.ClipboardWatch = *watch
+	env, err := app.dotenvValues(*fromEnv)
+	if err != nil {
+		return err
+	}
+	w.SetEnv(env)
+	if err := w.FastSetup(*provider); err != nil {
+		return err
+	}
+	if err := hooks.Fire(context.Background(), hooks.PostAuth, map[string]any{"provider": *provider}); err != nil {
+		fmt.Fprintf(app.Stderr, "warning: post-auth hook failed: %v\n", err)
+	}
+	fmt.Fprintf(app.Stdout, "Configured provider %s.\n", *provider)
+	return nil
+}
+
+func runAuthList(app *App, args []string) error {
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Providers) == 0 {
+		fmt.Fprintln(app.Stdout, "No providers configured. Run `cline auth add --provider <id>`.")
+		return nil
+	}
+	for _, id := range sortedKeys(cfg.Providers) {
+		entry := cfg.Providers[id]
+		marker := " "
+		if id == cfg.DefaultProvider {
+			marker = "*"
+		}
+		fmt.Fprintf(app.Stdout, "%s %-14s model=%s\n", marker, id, entry.ModelID)
+	}
+	return nil
+}
+
+func runAuthRemove(app *App, args []string) error {
+	fs := flag.NewFlagSet("auth remove", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID to remove")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *provider == "" {
+		return fmt.Errorf("--provider is required")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		if _, ok := cfg.Providers[*provider]; !ok {
+			return fmt.Errorf("provider %q is not configured", *provider)
+		}
+		delete(cfg.Providers, *provider)
+		if cfg.DefaultProvider == *provider {
+			cfg.DefaultProvider = ""
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Removed provider %s.\n", *provider)
+	return nil
+}
+
+// dotenvValues loads field values from a dotenv file. An explicit path wins;
+// otherwise `.env`/`.env.cline` in the working directory are discovered, but
+// only when the user opted in via `cline settings set dotenv.auto true`.
+func (a *App) dotenvValues(path string) (map[string]string, error) {
+	if path != "" {
+		return dotenv.Load(path)
+	}
+	cfg, err := a.loadConfig()
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Settings["dotenv.auto"] != "true" {
+		return nil, nil
+	}
+	return dotenv.Discover(".")
+}
+
+// Wizard returns a setup wizard bound to the app's streams and services.
+func (a *App) Wizard() (*wizard.SetupWizard, error) {
+	registry, err := a.Registry()
+	if err != nil {
+		return nil, err
+	}
+	manager, err := a.ConfigManager()
+	if err != nil {
+		return nil, err
+	}
+	w := wizard.NewSetupWizard(a.Stdin, a.Stdout, registry, manager)
+	w.Theme = a.Theme()
+	if pol, err := a.Policy(); err == nil && pol != nil {
+		w.RequireRetentionAck = pol.RequireRetentionAck
+	}
+	return w, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,311 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/metrics"
+	"github.com/cline/cline/cli/pkg/respcache"
+	"github.com/cline/cline/cli/pkg/stats"
+	usageledger "github.com/cline/cline/cli/pkg/usage"
+)
+
+func batchCommand() *Command {
+	return &Command{
+		Name:    "batch",
+		Summary: "Run many prompts with rate limiting and resumable output.",
+		Sub: []*Command{
+			{
+				Name:    "run",
+				Summary: "Execute a JSONL file of prompts, appending results incrementally.",
+				Usage:   "cline batch run <prompts.jsonl> [--concurrency <n>] [--rpm <n>] [--provider <id>] [--model <id>] [--out <file>]",
+				Run:     runBatchRun,
+			},
+		},
+	}
+}
+
+// batchJob is one input line: {"id": "...", "prompt": "..."}. A missing id
+// gets the line number, which is stable enough for resuming.
+type batchJob struct {
+	ID     string `json:"id"`
+	Prompt string `json:"prompt"`
+}
+
+// batchResult is one output line. Error and Reply are mutually exclusive;
+// resuming skips IDs whose last result has no error.
+type batchResult struct {
+	ID           string    `json:"id"`
+	Reply        string    `json:"reply,omitempty"`
+	Error        string    `json:"error,omitempty"`
+	CostUSD      float64   `json:"costUsd,omitempty"`
+	InputTokens  int       `json:"inputTokens,omitempty"`
+	OutputTokens int       `json:"outputTokens,omitempty"`
+	Seconds      float64   `json:"seconds,omitempty"`
+	At           time.Time `json:"at"`
+}
+
+func runBatchRun(app *App, args []string) error {
+	fs := flag.NewFlagSet("batch run", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	concurrency := fs.Int("concurrency", 2, "number of prompts in flight at once")
+	rpm := fs.Int("rpm", 0, "client-side request-per-minute cap (0: no cap)")
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	model := fs.String("model", "", "model ID override")
+	out := fs.String("out", "", "results file (default: <input>.results.jsonl)")
+	noCache := fs.Bool("no-cache", false, "bypass the response cache even when cache.responses is enabled")
+	var tagPairs stringList
+	fs.Var(&tagPairs, "tag", "cost attribution tag as key=value (repeatable; see `cline usage show --by tag`)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	tags, err := parseTags(tagPairs)
+	if err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline batch run <prompts.jsonl> [--concurrency <n>] [--rpm <n>]")
+	}
+	if *concurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+	inputPath := fs.Arg(0)
+	outPath := *out
+	if outPath == "" {
+		outPath = strings.TrimSuffix(inputPath, ".jsonl") + ".results.jsonl"
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	providerID := *provider
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	if providerID == "" {
+		return fmt.Errorf("no provider configured; run `cline auth add --provider <id>`")
+	}
+	baseEntry, ok := cfg.Providers[providerID]
+	if !ok {
+		return fmt.Errorf("provider %q is not configured", providerID)
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return err
+	}
+
+	jobs, err := loadBatchJobs(inputPath)
+	if err != nil {
+		return err
+	}
+	done := loadBatchDone(outPath)
+	pending := jobs[:0:0]
+	for _, job := range jobs {
+		if !done[job.ID] {
+			pending = append(pending, job)
+		}
+	}
+	if len(done) > 0 {
+		fmt.Fprintf(app.Stderr, "resuming: %d of %d prompt(s) already completed in %s\n", len(jobs)-len(pending), len(jobs), outPath)
+	}
+	if len(pending) == 0 {
+		fmt.Fprintln(app.Stdout, "Nothing to do; all prompts already have results.")
+		return nil
+	}
+
+	results, err := os.OpenFile(outPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	defer results.Close()
+
+	// The ticker spaces request starts out across every worker; nil means
+	// no cap and the receive below never blocks.
+	var limiter <-chan time.Time
+	if *rpm > 0 {
+		ticker := time.NewTicker(time.Minute / time.Duration(*rpm))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+
+	var (
+		mu        sync.Mutex
+		wg        sync.WaitGroup
+		completed int
+		failed    int
+		totalCost float64
+	)
+	queue := make(chan batchJob)
+	for i := 0; i < *concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for job := range queue {
+				entry := *baseEntry
+				if *model != "" {
+					entry.ModelID = *model
+				}
+				var (
+					reply   string
+					usage   llm.Usage
+					err     error
+					seconds float64
+					cached  bool
+				)
+				useCache := respcache.Enabled(cfg.Settings) && !*noCache
+				cacheKey := respcache.Key(providerID, entry.ModelID, "", job.Prompt)
+				if useCache {
+					reply, cached = respcache.Get(cacheKey, respcache.TTL(cfg.Settings))
+				}
+				if !cached {
+					if limiter != nil {
+						<-limiter
+					}
+					start := time.Now()
+					reply, usage, err = llm.CompleteWithRetry(context.Background(), def, &entry, llm.Prompt{Text: job.Prompt})
+					seconds = time.Since(start).Seconds()
+					metrics.RecordProviderRequest(providerID, seconds, usage.InputTokens, usage.OutputTokens, usage.CostUSD, err != nil)
+					stats.Record(providerID, seconds, err != nil)
+					if err == nil {
+						usageledger.Add(usageledger.Record{
+							Command:      "batch",
+							Provider:     providerID,
+							ModelID:      entry.ModelID,
+							InputTokens:  usage.InputTokens,
+							OutputTokens: usage.OutputTokens,
+							CostUSD:      usage.CostUSD,
+							Tags:         tags,
+						})
+					}
+					if useCache && err == nil {
+						respcache.Put(cacheKey, reply, respcache.TTL(cfg.Settings))
+					}
+				}
+				result := batchResult{
+					ID:           job.ID,
+					Reply:        reply,
+					CostUSD:      usage.CostUSD,
+					InputTokens:  usage.InputTokens,
+					OutputTokens: usage.OutputTokens,
+					Seconds:      seconds,
+					At:           time.Now().UTC(),
+				}
+				if err != nil {
+					result.Reply = ""
+					result.Error = err.Error()
+				}
+				mu.Lock()
+				line, merr := json.Marshal(result)
+				if merr == nil {
+					results.Write(append(line, '\n'))
+				}
+				completed++
+				totalCost += usage.CostUSD
+				if err != nil {
+					failed++
+					fmt.Fprintf(app.Stderr, "[%d/%d] %s FAILED: %v\n", completed, len(pending), job.ID, err)
+				} else if cached {
+					fmt.Fprintf(app.Stderr, "[%d/%d] %s ok (cached)\n", completed, len(pending), job.ID)
+				} else {
+					fmt.Fprintf(app.Stderr, "[%d/%d] %s ok (%s)\n", completed, len(pending), job.ID, app.Costs().Format(usage.CostUSD, 4))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for _, job := range pending {
+		queue <- job
+	}
+	close(queue)
+	wg.Wait()
+
+	fmt.Fprintf(app.Stdout, "%d succeeded, %d failed, %s total; results in %s\n",
+		completed-failed, failed, app.Costs().Format(totalCost, 4), outPath)
+	if failed > 0 {
+		return fmt.Errorf("%d prompt(s) failed; rerun the same command to retry just those", failed)
+	}
+	return nil
+}
+
+// loadBatchJobs reads the input JSONL, assigning line-number IDs where the
+// line does not carry one and rejecting duplicate IDs (resuming would skip
+// the wrong work).
+func loadBatchJobs(path string) ([]batchJob, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var jobs []batchJob
+	seen := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var job batchJob
+		if err := json.Unmarshal([]byte(line), &job); err != nil {
+			return nil, fmt.Errorf("%s line %d: %w", path, lineNo, err)
+		}
+		if job.Prompt == "" {
+			return nil, fmt.Errorf("%s line %d: missing prompt", path, lineNo)
+		}
+		if job.ID == "" {
+			job.ID = fmt.Sprintf("line-%d", lineNo)
+		}
+		if seen[job.ID] {
+			return nil, fmt.Errorf("%s line %d: duplicate id %q", path, lineNo, job.ID)
+		}
+		seen[job.ID] = true
+		jobs = append(jobs, job)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// loadBatchDone collects the IDs whose most recent result succeeded; failed
+// attempts are rerun on resume.
+func loadBatchDone(path string) map[string]bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+	done := map[string]bool{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		var result batchResult
+		if err := json.Unmarshal(scanner.Bytes(), &result); err != nil {
+			continue
+		}
+		done[result.ID] = result.Error == ""
+	}
+	for id, ok := range done {
+		if !ok {
+			delete(done, id)
+		}
+	}
+	return done
+}
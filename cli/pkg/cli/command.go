@@ -0,0 +1,258 @@
+// Package cli wires the cline command tree: flag parsing, command dispatch,
+// and the App context shared by command handlers.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/bundle"
+	"github.com/cline/cline/cli/pkg/clierr"
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/currency"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/hooks"
+	"github.com/cline/cline/cli/pkg/policy"
+	"github.com/cline/cline/cli/pkg/theme"
+)
+
+// Command is one node in the command tree. Leaf commands set Run; group
+// commands only carry Sub.
+type Command struct {
+	Name    string
+	Summary string
+	Usage   string
+	Hidden  bool
+	Run     func(app *App, args []string) error
+	Sub     []*Command
+}
+
+func (c *Command) find(name string) *Command {
+	for _, sub := range c.Sub {
+		if sub.Name == name {
+			return sub
+		}
+	}
+	return nil
+}
+
+// App carries the streams and shared services handed to command handlers.
+type App struct {
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+
+	configManager *config.ConfigManager
+	registry      *generated.ProviderRegistry
+	policy        *policy.Policy
+	policyLoaded  bool
+	// noPager disables the automatic pager for long outputs; set by the
+	// global --no-pager flag.
+	noPager bool
+	// dryRun suppresses config saves and external side effects, printing
+	// what would happen instead; set by the global --dry-run flag.
+	dryRun bool
+	// ephemeral runs with an in-memory config and a throwaway state
+	// directory, writing nothing persistent; set by the global --ephemeral
+	// flag.
+	ephemeral bool
+	theme     *theme.Theme
+	costs     *currency.Formatter
+}
+
+// Theme returns the active color theme, resolving it from settings and the
+// environment on first use. It never fails: a broken config just means the
+// default theme.
+func (a *App) Theme() *theme.Theme {
+	if a.theme == nil {
+		settings := map[string]string{}
+		if m, err := a.ConfigManager(); err == nil {
+			if cfg, err := m.Config(); err == nil {
+				settings = cfg.Settings
+			}
+		}
+		a.theme = theme.FromSettings(settings, stdoutIsTerminal(a))
+	}
+	return a.theme
+}
+
+// Costs returns the cost formatter for the configured currency and locale,
+// resolving it from settings on first use. Like Theme it never fails; a
+// broken config or unresolvable exchange rate just means plain USD.
+func (a *App) Costs() *currency.Formatter {
+	if a.costs == nil {
+		settings := map[string]string{}
+		if m, err := a.ConfigManager(); err == nil {
+			if cfg, err := m.Config(); err == nil {
+				settings = cfg.Settings
+			}
+		}
+		a.costs = currency.FromSettings(settings)
+	}
+	return a.costs
+}
+
+// ConfigManager returns the shared config manager, constructing it on first
+// use.
+func (a *App) ConfigManager() (*config.ConfigManager, error) {
+	if a.configManager == nil {
+		if a.ephemeral {
+			a.configManager = newEphemeralManager()
+			return a.configManager, nil
+		}
+		m, err := config.NewConfigManager()
+		if err != nil {
+			return nil, clierr.Config(err)
+		}
+		m.SetPreSaveHook(func(*config.ClineConfig) error {
+			return hooks.Fire(context.Background(), hooks.PreConfigSave, map[string]any{"path": m.Path()})
+		})
+		if a.dryRun {
+			m.SetDryRunReport(func(oldData, newData []byte) {
+				a.printConfigDiff(m.Path(), oldData, newData)
+			})
+		}
+		a.configManager = m
+	}
+	return a.configManager, nil
+}
+
+// Policy returns the repository policy for the working directory, loading it
+// on first use. It is nil when no policy file is present.
+func (a *App) Policy() (*policy.Policy, error) {
+	if !a.policyLoaded {
+		p, err := policy.Find(".")
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			// When a trusted signing key exists (baked into the build or
+			// configured), only signed, untampered policies are honored.
+			settings := map[string]string{}
+			if m, err := a.ConfigManager(); err == nil {
+				if cfg, err := m.Config(); err == nil {
+					settings = cfg.Settings
+				}
+			}
+			if key := policy.TrustedKey(settings); key != "" {
+				if err := policy.VerifyFile(p.Path, key); err != nil {
+					return nil, err
+				}
+			}
+		}
+		a.policy = p
+		a.policyLoaded = true
+	}
+	return a.policy, nil
+}
+
+// Registry returns the provider registry, constructing it on first use.
+func (a *App) Registry() (*generated.ProviderRegistry, error) {
+	if a.registry == nil {
+		// An applied offline bundle may have delivered definitions newer than
+		// the ones compiled into this binary; prefer those.
+		if path, err := bundle.DefinitionsOverridePath(); err == nil {
+			if data, err := os.ReadFile(path); err == nil {
+				r, err := generated.NewProviderRegistryFromJSON(data)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w (remove the file to use the built-in definitions)", path, err)
+				}
+				a.registry = r
+				return a.registry, nil
+			}
+		}
+		r, err := generated.NewProviderRegistry()
+		if err != nil {
+			return nil, err
+		}
+		a.registry = r
+	}
+	return a.registry, nil
+}
+
+// Execute parses args and dispatches to the matching command.
+func Execute(args []string) error {
+	app := &App{Stdin: os.Stdin, Stdout: os.Stdout, Stderr: os.Stderr}
+	return app.Execute(args)
+}
+
+// Execute resolves args against the command tree and runs the handler.
+func (a *App) Execute(args []string) error {
+	// --no-pager, --dry-run and --ephemeral are global: they may appear
+	// anywhere and apply to whichever command runs.
+	kept := make([]string, 0, len(args))
+	for _, arg := range args {
+		switch arg {
+		case "--no-pager":
+			a.noPager = true
+		case "--dry-run":
+			a.dryRun = true
+		case "--ephemeral":
+			a.ephemeral = true
+		default:
+			kept = append(kept, arg)
+		}
+	}
+	args = kept
+	if a.ephemeral {
+		cleanup, err := a.enterEphemeralMode()
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+	args = a.expandCommandAlias(args)
+	root := rootCommand()
+	cmd := root
+	var path []string
+	for len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		next := cmd.find(args[0])
+		if next == nil {
+			break
+		}
+		path = append(path, next.Name)
+		cmd, args = next, args[1:]
+	}
+	if len(args) > 0 && (args[0] == "--help" || args[0] == "-h" || args[0] == "help") {
+		printHelp(a.Stdout, cmd)
+		return nil
+	}
+	if cmd.Run == nil {
+		printHelp(a.Stdout, cmd)
+		if cmd == root && len(args) > 0 {
+			return fmt.Errorf("unknown command %q", args[0])
+		}
+		return nil
+	}
+	if err := a.checkCommandRole(strings.Join(path, " ")); err != nil {
+		return err
+	}
+	return cmd.Run(a, args)
+}
+
+func printHelp(w io.Writer, cmd *Command) {
+	if cmd.Usage != "" {
+		fmt.Fprintf(w, "Usage: %s\n", cmd.Usage)
+	}
+	if cmd.Summary != "" {
+		fmt.Fprintf(w, "%s\n", cmd.Summary)
+	}
+	visible := make([]*Command, 0, len(cmd.Sub))
+	for _, sub := range cmd.Sub {
+		if !sub.Hidden {
+			visible = append(visible, sub)
+		}
+	}
+	if len(visible) == 0 {
+		return
+	}
+	sort.Slice(visible, func(i, j int) bool { return visible[i].Name < visible[j].Name })
+	fmt.Fprintln(w, "\nCommands:")
+	for _, sub := range visible {
+		fmt.Fprintf(w, "  %-12s %s\n", sub.Name, sub.Summary)
+	}
+}
@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/metrics"
+	"github.com/cline/cline/cli/pkg/stats"
+)
+
+func compareCommand() *Command {
+	return &Command{
+		Name:    "compare",
+		Summary: "Send one prompt to several models and compare the answers.",
+		Usage:   `cline compare --models <ref,ref,...> "<prompt>"`,
+		Run:     runCompare,
+	}
+}
+
+// compareTarget is one resolved model to race.
+type compareTarget struct {
+	ref        string
+	providerID string
+	modelID    string
+}
+
+// compareResult is one model's answer with its latency and cost.
+type compareResult struct {
+	reply   string
+	usage   llm.Usage
+	seconds float64
+	err     error
+}
+
+func runCompare(app *App, args []string) error {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	refs := fs.String("models", "", "comma-separated model refs: provider/model, a model alias, or a model on the default provider")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	prompt := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if prompt == "" {
+		return fmt.Errorf(`usage: cline compare --models <ref,ref,...> "<prompt>"`)
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	var targets []compareTarget
+	for _, ref := range strings.Split(*refs, ",") {
+		ref = strings.TrimSpace(ref)
+		if ref == "" {
+			continue
+		}
+		target, err := resolveCompareRef(cfg, ref)
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+	if len(targets) < 2 {
+		return fmt.Errorf("--models needs at least two comma-separated refs")
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+
+	results := make([]compareResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target compareTarget) {
+			defer wg.Done()
+			def, err := registry.Provider(target.providerID)
+			if err != nil {
+				results[i].err = err
+				return
+			}
+			// Each goroutine completes against its own copy of the entry so
+			// two refs on one provider cannot race on ModelID.
+			entry := *cfg.Providers[target.providerID]
+			if target.modelID != "" {
+				entry.ModelID = target.modelID
+			}
+			start := time.Now()
+			reply, usage, err := llm.CompleteWithRetry(context.Background(), def, &entry, llm.Prompt{Text: prompt})
+			seconds := time.Since(start).Seconds()
+			metrics.RecordProviderRequest(target.providerID, seconds, usage.InputTokens, usage.OutputTokens, usage.CostUSD, err != nil)
+			stats.Record(target.providerID, seconds, err != nil)
+			results[i] = compareResult{reply: reply, usage: usage, seconds: seconds, err: err}
+		}(i, target)
+	}
+	wg.Wait()
+
+	th := app.Theme()
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	for i, target := range targets {
+		res := results[i]
+		fmt.Fprintln(out, th.Emphasis(fmt.Sprintf("=== %s ===", target.ref)))
+		if res.err != nil {
+			fmt.Fprintf(out, "%s %v\n\n", th.Failure("error:"), res.err)
+			continue
+		}
+		fmt.Fprintf(out, "%s\n\n", strings.TrimSpace(res.reply))
+	}
+	fmt.Fprintf(out, "%-36s %9s %10s %8s\n", "MODEL", "LATENCY", "TOKENS", "COST")
+	failed := 0
+	for i, target := range targets {
+		res := results[i]
+		if res.err != nil {
+			failed++
+			fmt.Fprintf(out, "%-36s %9s %10s %8s\n", target.ref, "-", "-", "-")
+			continue
+		}
+		fmt.Fprintf(out, "%-36s %8.2fs %10d %s\n",
+			target.ref, res.seconds, res.usage.InputTokens+res.usage.OutputTokens, app.Costs().Format(res.usage.CostUSD, 4))
+	}
+	if failed == len(targets) {
+		return fmt.Errorf("all %d model(s) failed", failed)
+	}
+	return nil
+}
+
+// resolveCompareRef turns one --models item into a provider and model:
+// aliases first, then "provider/model" on the first slash (so OpenRouter
+// model IDs keep their own slashes), then a bare model on the default
+// provider.
+func resolveCompareRef(cfg *config.ClineConfig, ref string) (compareTarget, error) {
+	target := compareTarget{ref: ref}
+	if provider, modelID, ok := cfg.ResolveModelAlias(ref); ok {
+		target.providerID, target.modelID = provider, modelID
+	} else if provider, modelID, found := strings.Cut(ref, "/"); found {
+		target.providerID, target.modelID = provider, modelID
+	} else {
+		target.modelID = ref
+	}
+	if target.providerID == "" {
+		target.providerID = cfg.DefaultProvider
+	}
+	if target.providerID == "" {
+		return target, fmt.Errorf("%q names no provider and no default provider is configured", ref)
+	}
+	if _, ok := cfg.Providers[target.providerID]; !ok {
+		return target, fmt.Errorf("provider %q (from %q) is not configured", target.providerID, ref)
+	}
+	return target, nil
+}
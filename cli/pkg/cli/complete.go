@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/metrics"
+	"github.com/cline/cline/cli/pkg/recents"
+	"github.com/cline/cline/cli/pkg/stats"
+)
+
+// complete runs one prompt against a provider under its retry policy,
+// recording metrics and latency samples, and falls back once to the entry's
+// configured fallback provider when every attempt failed retryably. It
+// returns the provider that actually answered.
+func (a *App) complete(ctx context.Context, registry *generated.ProviderRegistry, cfg *config.ClineConfig, providerID string, entry *config.ProviderConfig, prompt llm.Prompt) (string, string, llm.Usage, error) {
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return providerID, "", llm.Usage{}, err
+	}
+	start := time.Now()
+	reply, usage, err := llm.CompleteWithRetry(ctx, def, entry, prompt)
+	metrics.RecordProviderRequest(providerID, time.Since(start).Seconds(), usage.InputTokens, usage.OutputTokens, usage.CostUSD, err != nil)
+	metrics.RecordCacheUsage(providerID, usage.CacheReadTokens, usage.CacheWriteTokens)
+	stats.Record(providerID, time.Since(start).Seconds(), err != nil)
+	if err == nil {
+		recents.RecordUse(providerID, entry.ModelID)
+	}
+	if err == nil || !llm.Retryable(err) {
+		return providerID, reply, usage, err
+	}
+	fallbackID := llm.PolicyFor(entry).FallbackProvider
+	if fallbackID == "" || fallbackID == providerID {
+		return providerID, "", llm.Usage{}, err
+	}
+	fallbackEntry, ok := cfg.Providers[fallbackID]
+	if !ok {
+		return providerID, "", llm.Usage{}, fmt.Errorf("%w (fallback provider %q is not configured)", err, fallbackID)
+	}
+	fallbackDef, defErr := registry.Provider(fallbackID)
+	if defErr != nil {
+		return providerID, "", llm.Usage{}, fmt.Errorf("%w (fallback: %v)", err, defErr)
+	}
+	fmt.Fprintf(a.Stderr, "warning: %s failed (%v); falling back to %s\n", providerID, err, fallbackID)
+	start = time.Now()
+	reply, usage, err = llm.CompleteWithRetry(ctx, fallbackDef, fallbackEntry, prompt)
+	metrics.RecordProviderRequest(fallbackID, time.Since(start).Seconds(), usage.InputTokens, usage.OutputTokens, usage.CostUSD, err != nil)
+	metrics.RecordCacheUsage(fallbackID, usage.CacheReadTokens, usage.CacheWriteTokens)
+	stats.Record(fallbackID, time.Since(start).Seconds(), err != nil)
+	if err == nil {
+		recents.RecordUse(fallbackID, fallbackEntry.ModelID)
+	}
+	return fallbackID, reply, usage, err
+}
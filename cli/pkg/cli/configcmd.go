@@ -0,0 +1,514 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/checks"
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/gitsync"
+	"github.com/cline/cline/cli/pkg/interop"
+	"github.com/cline/cline/cli/pkg/wizard"
+)
+
+func configCommand() *Command {
+	return &Command{
+		Name:    "config",
+		Summary: "Inspect and edit the CLI configuration.",
+		Sub: []*Command{
+			{
+				Name:    "show",
+				Summary: "Print the current configuration with secrets redacted.",
+				Usage:   "cline config show",
+				Run:     runConfigShow,
+			},
+			{
+				Name:    "set",
+				Summary: "Set a settings key.",
+				Usage:   "cline config set <key> <value>",
+				Run:     runConfigSet,
+			},
+			{
+				Name:    "import",
+				Summary: "Import configuration from another tool on this machine.",
+				Usage:   "cline config import --from vscode [--path <settings.json>]",
+				Run:     runConfigImport,
+			},
+			{
+				Name:    "export",
+				Summary: "Export the configuration for another surface.",
+				Usage:   "cline config export --format vscode",
+				Run:     runConfigExport,
+			},
+			{
+				Name:    "validate",
+				Summary: "Validate configured providers against their definitions.",
+				Usage:   "cline config validate [--output text|junit|sarif]",
+				Run:     runConfigValidate,
+			},
+			{
+				Name:    "share-key",
+				Summary: "Print this machine's public key for receiving shared setups.",
+				Usage:   "cline config share-key",
+				Run:     runConfigShareKey,
+			},
+			{
+				Name:    "share",
+				Summary: "Seal provider entries for a teammate's public key.",
+				Usage:   "cline config share --to <public-key> [--providers a,b] [--no-keys] [--out <file>]",
+				Run:     runConfigShare,
+			},
+			{
+				Name:    "receive",
+				Summary: "Import provider entries sealed to this machine's key.",
+				Usage:   "cline config receive [<file>]",
+				Run:     runConfigReceive,
+			},
+			{
+				Name:    "remote",
+				Summary: "Manage the team config sync repository.",
+				Sub: []*Command{
+					{
+						Name:    "add",
+						Summary: "Set the git repository used by config pull and push.",
+						Usage:   "cline config remote add <url>",
+						Run:     runConfigRemoteAdd,
+					},
+				},
+			},
+			{
+				Name:    "pull",
+				Summary: "Merge the team's shared config over the local one, keeping local secrets.",
+				Usage:   "cline config pull",
+				Run:     runConfigPull,
+			},
+			{
+				Name:    "push",
+				Summary: "Publish the non-secret portions of the local config to the team repository.",
+				Usage:   "cline config push",
+				Run:     runConfigPush,
+			},
+			{
+				Name:    "migrate",
+				Summary: "Move a legacy ~/.cline directory to the XDG locations.",
+				Usage:   "cline config migrate",
+				Run:     runConfigMigrate,
+			},
+		},
+	}
+}
+
+func runConfigShow(app *App, args []string) error {
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	view := struct {
+		Version         int                            `json:"version"`
+		DefaultProvider string                         `json:"defaultProvider,omitempty"`
+		Providers       map[string]*configProviderView `json:"providers,omitempty"`
+		Settings        map[string]string              `json:"settings,omitempty"`
+	}{Version: cfg.Version, DefaultProvider: cfg.DefaultProvider, Settings: cfg.Settings}
+	view.Providers = make(map[string]*configProviderView, len(cfg.Providers))
+	for id, entry := range cfg.Providers {
+		view.Providers[id] = &configProviderView{
+			ModelID:     entry.ModelID,
+			APIKey:      redactSecret(entry.APIKey),
+			BaseURL:     entry.BaseURL,
+			Headers:     redactHeaders(entry.Headers),
+			ExtraConfig: entry.ExtraConfig,
+		}
+	}
+	data, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(app.Stdout, string(data))
+	return nil
+}
+
+type configProviderView struct {
+	ModelID     string            `json:"modelId,omitempty"`
+	APIKey      string            `json:"apiKey,omitempty"`
+	BaseURL     string            `json:"baseUrl,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	ExtraConfig map[string]string `json:"extraConfig,omitempty"`
+}
+
+// redactHeaders hides the values of custom headers whose names look like
+// credentials (gateway auth, signing tokens); plain routing headers stay
+// readable.
+func redactHeaders(headers map[string]string) map[string]string {
+	if len(headers) == 0 {
+		return nil
+	}
+	view := make(map[string]string, len(headers))
+	for name, value := range headers {
+		if looksLikeSecret(name) || strings.EqualFold(name, "authorization") {
+			value = redactSecret(value)
+		}
+		view[name] = value
+	}
+	return view
+}
+
+func redactSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	if len(s) <= 8 {
+		return "****"
+	}
+	return s[:4] + "..." + s[len(s)-4:]
+}
+
+func runConfigSet(app *App, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: cline config set <key> <value>")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	return manager.Update(func(cfg *config.ClineConfig) error {
+		if cfg.Settings == nil {
+			cfg.Settings = make(map[string]string)
+		}
+		cfg.Settings[args[0]] = args[1]
+		return nil
+	})
+}
+
+func runConfigRemoteAdd(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline config remote add <url>")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	return manager.Update(func(cfg *config.ClineConfig) error {
+		if cfg.Settings == nil {
+			cfg.Settings = make(map[string]string)
+		}
+		cfg.Settings[gitsync.SettingRemote] = args[0]
+		return nil
+	})
+}
+
+// syncRemote returns the configured sync repository URL.
+func syncRemote(cfg *config.ClineConfig) (string, error) {
+	remote := cfg.Settings[gitsync.SettingRemote]
+	if remote == "" {
+		return "", fmt.Errorf("no sync repository configured; run `cline config remote add <url>` first")
+	}
+	return remote, nil
+}
+
+func runConfigPush(app *App, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: cline config push")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	remote, err := syncRemote(cfg)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		shared := gitsync.Export(cfg)
+		app.dryRunSkip(fmt.Sprintf("commit and push shared.json (%d provider(s), no secrets) to %s", len(shared.Providers), remote))
+		return nil
+	}
+	dir, err := gitsync.Ensure(remote)
+	if err != nil {
+		return err
+	}
+	result, err := gitsync.Push(dir, cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(app.Stdout, result)
+	return nil
+}
+
+func runConfigPull(app *App, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: cline config pull")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	remote, err := syncRemote(cfg)
+	if err != nil {
+		return err
+	}
+	dir, err := gitsync.Ensure(remote)
+	if err != nil {
+		return err
+	}
+	shared, err := gitsync.Load(dir)
+	if err != nil {
+		return err
+	}
+	if err := manager.Update(func(cfg *config.ClineConfig) error {
+		gitsync.Merge(cfg, shared)
+		return nil
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Merged shared config (%d provider(s)); local API keys were kept.\n", len(shared.Providers))
+	return nil
+}
+
+// runConfigMigrate relocates a legacy ~/.cline directory: configuration goes
+// to $XDG_CONFIG_HOME/cline and generated data (stats, tasks, caches) to
+// $XDG_DATA_HOME/cline. Nothing changes for users who keep ~/.cline.
+func runConfigMigrate(app *App, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: cline config migrate")
+	}
+	moved, err := config.MigrateLegacyDir()
+	for _, m := range moved {
+		fmt.Fprintln(app.Stdout, m)
+	}
+	if err != nil {
+		return err
+	}
+	if len(moved) == 0 {
+		fmt.Fprintln(app.Stdout, "Nothing to migrate.")
+	}
+	return nil
+}
+
+func runConfigImport(app *App, args []string) error {
+	fs := flag.NewFlagSet("config import", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	from := fs.String("from", "vscode", "source to import from (vscode, aider, llm, continue)")
+	path := fs.String("path", "", "explicit source config path (default: auto-detect)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	settingsPath := *path
+	if settingsPath == "" {
+		if *from == "vscode" {
+			candidates := interop.LocateVSCodeSettings()
+			if len(candidates) == 0 {
+				return fmt.Errorf("no VS Code settings.json found; pass --path explicitly")
+			}
+			settingsPath = candidates[0]
+		} else {
+			settingsPath = interop.DefaultToolConfigPath(*from)
+			if settingsPath == "" {
+				return fmt.Errorf("unsupported import source %q", *from)
+			}
+		}
+	}
+	data, err := os.ReadFile(settingsPath)
+	if err != nil {
+		return err
+	}
+	var result *interop.ImportResult
+	if *from == "vscode" {
+		result, err = interop.ImportVSCode(data)
+	} else {
+		var registry *generated.ProviderRegistry
+		registry, err = app.Registry()
+		if err != nil {
+			return err
+		}
+		result, err = interop.ImportTool(*from, data, registry)
+	}
+	if err != nil {
+		return err
+	}
+	for _, name := range result.Unmapped {
+		fmt.Fprintf(app.Stderr, "warning: could not map %q onto a registry model\n", name)
+	}
+	if len(result.Providers) == 0 {
+		return fmt.Errorf("no Cline provider settings found in %s", settingsPath)
+	}
+	reader := bufio.NewReader(app.Stdin)
+	secrets := make(map[string]string)
+	for _, id := range result.MissingSecrets {
+		fmt.Fprintf(app.Stdout, "API key for %s (stored in VS Code secret storage, cannot be read; leave empty to skip): ", id)
+		line, _ := reader.ReadString('\n')
+		if key := strings.TrimSpace(line); key != "" {
+			secrets[id] = key
+		}
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		for id, imported := range result.Providers {
+			entry := cfg.Provider(id)
+			if imported.ModelID != "" {
+				entry.ModelID = imported.ModelID
+			}
+			if imported.BaseURL != "" {
+				entry.BaseURL = imported.BaseURL
+			}
+			for k, v := range imported.ExtraConfig {
+				entry.SetField(k, v)
+			}
+			if key := secrets[id]; key != "" {
+				entry.APIKey = key
+			}
+		}
+		if result.DefaultProvider != "" {
+			cfg.DefaultProvider = result.DefaultProvider
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Imported %d provider(s) from %s.\n", len(result.Providers), settingsPath)
+	return nil
+}
+
+// policyChecks validates the config against the repository policy, if one is
+// committed in the current repo.
+func policyChecks(app *App, cfg *config.ClineConfig) []checks.Result {
+	pol, err := app.Policy()
+	if err != nil {
+		return []checks.Result{{
+			ID:          "repo-policy-parse",
+			Description: "Repository policy parses",
+			Detail:      err.Error(),
+		}}
+	}
+	if pol == nil {
+		return nil
+	}
+	var results []checks.Result
+	ok := true
+	for _, id := range sortedKeys(cfg.Providers) {
+		entry := cfg.Providers[id]
+		if !pol.AllowsProvider(id) {
+			ok = false
+			results = append(results, checks.Result{
+				ID:          "repo-policy-provider",
+				Description: "Configured providers are allowed by repository policy",
+				Detail:      fmt.Sprintf("provider %q is not in the allowed list of %s", id, pol.Path),
+				Path:        pol.Path,
+			})
+		}
+		if entry.ModelID != "" && !pol.AllowsModel(entry.ModelID) {
+			ok = false
+			results = append(results, checks.Result{
+				ID:          "repo-policy-model",
+				Description: "Configured models are allowed by repository policy",
+				Detail:      fmt.Sprintf("model %q (provider %s) is not in the allowed list of %s", entry.ModelID, id, pol.Path),
+				Path:        pol.Path,
+			})
+		}
+	}
+	if ok {
+		results = append(results, checks.Result{
+			ID:          "repo-policy",
+			Description: "Config complies with repository policy",
+			OK:          true,
+			Path:        pol.Path,
+		})
+	}
+	return results
+}
+
+func runConfigExport(app *App, args []string) error {
+	fs := flag.NewFlagSet("config export", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	format := fs.String("format", "vscode", "export format (vscode)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "vscode" {
+		return fmt.Errorf("unsupported export format %q", *format)
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	exported := interop.ExportVSCode(cfg)
+	data, err := json.MarshalIndent(exported.Settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(app.Stdout, string(data))
+	if len(exported.SecretInstructions) > 0 {
+		fmt.Fprintln(app.Stderr, "\nAPI keys are not exported to settings.json. To finish:")
+		for _, line := range exported.SecretInstructions {
+			fmt.Fprintf(app.Stderr, "  - %s\n", line)
+		}
+	}
+	return nil
+}
+
+func runConfigValidate(app *App, args []string) error {
+	fs := flag.NewFlagSet("config validate", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	output := fs.String("output", "text", "output format (text, junit, sarif)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	var results []checks.Result
+	for _, err := range wizard.ValidateAll(registry, cfg) {
+		results = append(results, checks.Result{
+			ID:          "provider-required-fields",
+			Description: "Configured providers have all required fields",
+			Detail:      err.Error(),
+			Path:        manager.Path(),
+		})
+	}
+	if len(results) == 0 {
+		results = append(results, checks.Result{
+			ID:          "provider-required-fields",
+			Description: "Configured providers have all required fields",
+			OK:          true,
+			Detail:      fmt.Sprintf("%d provider(s) validated", len(cfg.Providers)),
+		})
+	}
+	results = append(results, securityLint(cfg, manager.Path())...)
+	results = append(results, policyChecks(app, cfg)...)
+	if err := renderChecks(app, "config-validate", *output, results); err != nil {
+		return err
+	}
+	if n := checks.Failed(results); n > 0 {
+		return fmt.Errorf("config validation failed with %d issue(s)", n)
+	}
+	return nil
+}
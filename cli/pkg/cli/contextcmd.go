@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/guardrails"
+	"github.com/cline/cline/cli/pkg/ignore"
+	"github.com/cline/cline/cli/pkg/workspace"
+)
+
+func contextCommand() *Command {
+	return &Command{
+		Name:    "context",
+		Summary: "Build and inspect the workspace context summary.",
+		Sub: []*Command{
+			{
+				Name:    "build",
+				Summary: "Summarize the current repo and cache it for --with-context.",
+				Usage:   "cline context build",
+				Run:     runContextBuild,
+			},
+			{
+				Name:    "explain",
+				Summary: "Show whether ignore rules would exclude a file from context.",
+				Usage:   "cline context explain <path>",
+				Run:     runContextExplain,
+			},
+		},
+	}
+}
+
+func runContextBuild(app *App, args []string) error {
+	fs := flag.NewFlagSet("context build", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: cline context build")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	summary, err := workspace.Build(wd)
+	if err != nil {
+		return err
+	}
+	workspace.Save(wd, summary)
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	fmt.Fprint(out, summary)
+	fmt.Fprintf(app.Stderr, "Cached (~%d tokens); attach it with `cline ask --with-context`.\n",
+		guardrails.EstimateTokens(summary))
+	return nil
+}
+
+func runContextExplain(app *App, args []string) error {
+	fs := flag.NewFlagSet("context explain", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline context explain <path>")
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	abs, err := filepath.Abs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return fmt.Errorf("%s is outside the current workspace", fs.Arg(0))
+	}
+	isDir := false
+	if info, err := os.Stat(abs); err == nil {
+		isDir = info.IsDir()
+	}
+	excluded, reason := ignore.Load(wd).Explain(rel, isDir)
+	switch {
+	case excluded:
+		fmt.Fprintf(app.Stdout, "%s: %s\n", rel, reason)
+	case reason != "":
+		fmt.Fprintf(app.Stdout, "%s: included (%s)\n", rel, reason)
+	default:
+		fmt.Fprintf(app.Stdout, "%s: included (no ignore rule matches)\n", rel)
+	}
+	return nil
+}
+
+// ignoreSkip builds the filter Expand applies to glob matches: paths inside
+// the workspace that its ignore files exclude. Paths outside the workspace
+// are never skipped.
+func ignoreSkip() func(path string) bool {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	matcher := ignore.Load(wd)
+	return func(path string) bool {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			return false
+		}
+		rel, err := filepath.Rel(wd, abs)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return false
+		}
+		return matcher.Excluded(rel, false)
+	}
+}
+
+// workspaceContext returns the cached summary for the current directory,
+// building and caching one on first use.
+func workspaceContext() (string, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	if summary, ok := workspace.Cached(wd); ok {
+		return summary, nil
+	}
+	summary, err := workspace.Build(wd)
+	if err != nil {
+		return "", err
+	}
+	workspace.Save(wd, summary)
+	return summary, nil
+}
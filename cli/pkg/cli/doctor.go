@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+
+	"github.com/cline/cline/cli/pkg/checks"
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/wizard"
+)
+
+func doctorCommand() *Command {
+	return &Command{
+		Name:    "doctor",
+		Summary: "Diagnose common setup problems.",
+		Usage:   "cline doctor [--output text|junit|sarif]",
+		Run:     runDoctor,
+		Sub: []*Command{
+			doctorSecretsCommand(),
+		},
+	}
+}
+
+func runDoctor(app *App, args []string) error {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	output := fs.String("output", "text", "output format (text, junit, sarif)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	results, err := app.doctorChecks()
+	if err != nil {
+		return err
+	}
+	if err := renderChecks(app, "doctor", *output, results); err != nil {
+		return err
+	}
+	if n := checks.Failed(results); n > 0 {
+		return fmt.Errorf("%d check(s) failed", n)
+	}
+	return nil
+}
+
+func (a *App) doctorChecks() ([]checks.Result, error) {
+	manager, err := a.ConfigManager()
+	if err != nil {
+		return nil, err
+	}
+	var results []checks.Result
+	path := manager.Path()
+
+	cfg, loadErr := manager.Config()
+	results = append(results, checks.Result{
+		ID:          "config-parse",
+		Description: "Config file parses",
+		OK:          loadErr == nil,
+		Detail:      errDetail(loadErr),
+		Path:        path,
+	})
+	if loadErr != nil {
+		return results, nil
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		permErr := config.CheckPrivate(path)
+		results = append(results, checks.Result{
+			ID:          "config-permissions",
+			Description: "Config file is private to this user",
+			OK:          permErr == nil,
+			Detail:      errDetail(permErr),
+			Path:        path,
+		})
+	}
+	if runtime.GOOS == "windows" {
+		results = append(results, dpapiCheck())
+	}
+
+	results = append(results, securityLint(cfg, path)...)
+
+	issues, err := generated.Verify()
+	if err != nil {
+		return nil, err
+	}
+	detail := ""
+	if len(issues) > 0 {
+		detail = fmt.Sprintf("%d issue(s); run `cline providers verify`", len(issues))
+	}
+	results = append(results, checks.Result{
+		ID:          "definitions-verify",
+		Description: "Generated definitions are consistent",
+		OK:          len(issues) == 0,
+		Detail:      detail,
+	})
+
+	registry, err := a.Registry()
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range wizard.ValidateAll(registry, cfg) {
+		results = append(results, checks.Result{
+			ID:          "provider-required-fields",
+			Description: "Configured providers have all required fields",
+			OK:          false,
+			Detail:      err.Error(),
+			Path:        path,
+		})
+	}
+	if len(cfg.Providers) > 0 && checks.Failed(results) == 0 {
+		results = append(results, checks.Result{
+			ID:          "provider-required-fields",
+			Description: "Configured providers have all required fields",
+			OK:          true,
+		})
+	}
+	return results, nil
+}
+
+// dpapiCheck verifies the DPAPI key-protection fallback round-trips on this
+// machine; it only runs on Windows, where ProtectSecret actually encrypts.
+func dpapiCheck() checks.Result {
+	probe := []byte("cline-doctor")
+	enc, err := config.ProtectSecret(probe)
+	if err == nil {
+		var dec []byte
+		dec, err = config.UnprotectSecret(enc)
+		if err == nil && string(dec) != string(probe) {
+			err = fmt.Errorf("round-trip mismatch")
+		}
+	}
+	return checks.Result{
+		ID:          "dpapi-protection",
+		Description: "DPAPI secret protection round-trips",
+		OK:          err == nil,
+		Detail:      errDetail(err),
+	}
+}
+
+// renderChecks writes check results in the requested format.
+func renderChecks(app *App, tool, format string, results []checks.Result) error {
+	switch format {
+	case "text":
+		th := app.Theme()
+		for _, r := range results {
+			// Pad before coloring: escape codes would throw off %-4s.
+			status := th.Success(fmt.Sprintf("%-4s", "ok"))
+			if !r.OK {
+				status = th.Failure(fmt.Sprintf("%-4s", "FAIL"))
+			}
+			fmt.Fprintf(app.Stdout, "%-30s %s %s\n", r.ID, status, r.Detail)
+		}
+	case "junit":
+		out, err := checks.JUnit(tool, results)
+		if err != nil {
+			return err
+		}
+		app.Stdout.Write(out)
+	case "sarif":
+		out, err := checks.SARIF(tool, results)
+		if err != nil {
+			return err
+		}
+		app.Stdout.Write(out)
+		fmt.Fprintln(app.Stdout)
+	default:
+		return fmt.Errorf("unsupported output format %q (text, junit, sarif)", format)
+	}
+	return nil
+}
+
+func errDetail(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.Error()
+}
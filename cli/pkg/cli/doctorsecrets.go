@@ -0,0 +1,75 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cline/cline/cli/pkg/secrets"
+)
+
+func doctorSecretsCommand() *Command {
+	return &Command{
+		Name:    "secrets",
+		Summary: "Scan shell history for configured API keys.",
+		Usage:   "cline doctor secrets",
+		Run:     runDoctorSecrets,
+	}
+}
+
+func runDoctorSecrets(app *App, args []string) error {
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	// Collect every secret-valued field per provider; keys are only ever
+	// compared by hash from here on.
+	keys := make(map[string]string)
+	for id, entry := range cfg.Providers {
+		if entry.APIKey != "" {
+			keys[id] = entry.APIKey
+		}
+		def, err := registry.Provider(id)
+		if err != nil {
+			continue
+		}
+		for _, field := range def.Fields {
+			if field.Secret {
+				if v := entry.Field(field.Name); v != "" {
+					keys[id] = v
+				}
+			}
+		}
+	}
+	if len(keys) == 0 {
+		fmt.Fprintln(app.Stdout, "No API keys configured; nothing to scan for.")
+		return nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return err
+	}
+	files := secrets.HistoryFiles(home)
+	if len(files) == 0 {
+		fmt.Fprintln(app.Stdout, "No shell history files found.")
+		return nil
+	}
+	leaks := secrets.Scan(files, keys)
+	if len(leaks) == 0 {
+		fmt.Fprintf(app.Stdout, "Scanned %d history file(s); no configured keys found.\n", len(files))
+		return nil
+	}
+	for _, leak := range leaks {
+		fmt.Fprintf(app.Stdout, "LEAK  %-14s %s:%d (key sha256 %s…)\n", leak.Provider, leak.File, leak.Line, leak.HashPrefix)
+	}
+	fmt.Fprintln(app.Stderr, "\nThese keys were typed on a command line and persist in shell history.")
+	fmt.Fprintln(app.Stderr, "Rotate them with the provider, then reconfigure via `cline auth add` (which prompts instead of taking keys as arguments).")
+	return fmt.Errorf("%d leaked key occurrence(s) found", len(leaks))
+}
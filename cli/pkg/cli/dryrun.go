@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The global --dry-run flag routes every config save through a diff report
+// instead of the disk, and commands with external side effects (pushes,
+// revocations, bundle applies) print the call they would make and stop.
+// Handlers that only read are unaffected.
+
+// printConfigDiff shows what a suppressed save would have changed, as
+// removed/added lines of the config file.
+func (a *App) printConfigDiff(path string, oldData, newData []byte) {
+	fmt.Fprintf(a.Stdout, "dry-run: would update %s:\n", path)
+	oldLines := strings.Split(strings.TrimRight(string(oldData), "\n"), "\n")
+	newLines := strings.Split(strings.TrimRight(string(newData), "\n"), "\n")
+	inOld := make(map[string]int, len(oldLines))
+	for _, line := range oldLines {
+		inOld[line]++
+	}
+	inNew := make(map[string]int, len(newLines))
+	for _, line := range newLines {
+		inNew[line]++
+	}
+	changed := false
+	for _, line := range oldLines {
+		if inNew[line] > 0 {
+			inNew[line]--
+			continue
+		}
+		fmt.Fprintln(a.Stdout, a.Theme().Failure("- "+line))
+		changed = true
+	}
+	for _, line := range newLines {
+		if inOld[line] > 0 {
+			inOld[line]--
+			continue
+		}
+		fmt.Fprintln(a.Stdout, a.Theme().Success("+ "+line))
+		changed = true
+	}
+	if !changed {
+		fmt.Fprintln(a.Stdout, "  (no changes)")
+	}
+}
+
+// dryRunSkip reports a suppressed external action, e.g. "PUT .../v1/settings".
+func (a *App) dryRunSkip(action string) {
+	fmt.Fprintf(a.Stdout, "dry-run: would %s\n", action)
+}
@@ -0,0 +1,85 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cline/cline/cli/pkg/llm"
+)
+
+func embedCommand() *Command {
+	return &Command{
+		Name:    "embed",
+		Summary: "Produce embedding vectors for a file's contents.",
+		Usage:   "cline embed <file> [--provider <id>] [--model <id>]",
+		Run:     runEmbed,
+	}
+}
+
+func runEmbed(app *App, args []string) error {
+	fs := flag.NewFlagSet("embed", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	model := fs.String("model", "", "embeddings model override")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline embed <file> (use - for stdin)")
+	}
+	var data []byte
+	var err error
+	if fs.Arg(0) == "-" {
+		data, err = io.ReadAll(app.Stdin)
+	} else {
+		data, err = os.ReadFile(fs.Arg(0))
+	}
+	if err != nil {
+		return err
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	providerID := *provider
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	if providerID == "" {
+		return fmt.Errorf("no provider configured; run `cline auth add --provider <id>`")
+	}
+	entry, ok := cfg.Providers[providerID]
+	if !ok {
+		return fmt.Errorf("provider %q is not configured", providerID)
+	}
+	if *model != "" {
+		entry.EmbeddingsModelID = *model
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return err
+	}
+	usedModel, vectors, err := llm.Embed(context.Background(), def, entry, []string{string(data)})
+	if err != nil {
+		return err
+	}
+	out := struct {
+		Provider   string      `json:"provider"`
+		Model      string      `json:"model"`
+		Embeddings [][]float64 `json:"embeddings"`
+	}{Provider: providerID, Model: usedModel, Embeddings: vectors}
+	encoded, err := json.Marshal(out)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(app.Stdout, string(encoded))
+	return nil
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// Environment variables seeding the ephemeral config with a ready-to-use
+// provider, so CI jobs and demos can skip the setup prompt entirely.
+const (
+	ephemeralProviderEnv = "CLINE_EPHEMERAL_PROVIDER"
+	ephemeralAPIKeyEnv   = "CLINE_EPHEMERAL_API_KEY"
+)
+
+// enterEphemeralMode points CLINE_CONFIG_DIR at a throwaway directory so the
+// best-effort state files (history, metrics, catalog caches) land somewhere
+// that is deleted when the command finishes. The config itself never touches
+// disk at all; see newEphemeralManager.
+func (a *App) enterEphemeralMode() (cleanup func(), err error) {
+	scratch, err := os.MkdirTemp("", "cline-ephemeral-")
+	if err != nil {
+		return nil, err
+	}
+	previous, had := os.LookupEnv("CLINE_CONFIG_DIR")
+	os.Setenv("CLINE_CONFIG_DIR", scratch)
+	return func() {
+		if had {
+			os.Setenv("CLINE_CONFIG_DIR", previous)
+		} else {
+			os.Unsetenv("CLINE_CONFIG_DIR")
+		}
+		os.RemoveAll(scratch)
+	}, nil
+}
+
+// newEphemeralManager builds the in-memory config for --ephemeral runs,
+// seeded from the environment when a provider and key are supplied there.
+// Without the seed, the usual setup and auth flows work as normal — their
+// writes just evaporate with the process.
+func newEphemeralManager() *config.ConfigManager {
+	m := config.NewEphemeralManager()
+	if provider := os.Getenv(ephemeralProviderEnv); provider != "" {
+		cfg, _ := m.Config()
+		cfg.DefaultProvider = provider
+		cfg.Provider(provider).APIKey = os.Getenv(ephemeralAPIKeyEnv)
+	}
+	return m
+}
@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/eval"
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/metrics"
+	"github.com/cline/cline/cli/pkg/stats"
+)
+
+func evalCommand() *Command {
+	return &Command{
+		Name:    "eval",
+		Summary: "Run prompt suites against configured models.",
+		Sub: []*Command{
+			{
+				Name:    "run",
+				Summary: "Execute a suite file and report a score per model.",
+				Usage:   "cline eval run <suite.yaml> [--models <ref,ref,...>]",
+				Run:     runEvalRun,
+			},
+		},
+	}
+}
+
+// gradeTemplate asks a model to judge a reply; the verdict must lead so the
+// first line can be parsed mechanically.
+const gradeTemplate = `You are grading a model's reply against acceptance criteria.
+
+Criteria: %s
+
+Reply:
+%s
+
+Answer with exactly PASS or FAIL on the first line, then one short sentence of reasoning.`
+
+func runEvalRun(app *App, args []string) error {
+	fs := flag.NewFlagSet("eval run", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	modelRefs := fs.String("models", "", "model refs to evaluate, overriding the suite's own list")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline eval run <suite.yaml> [--models <ref,ref,...>]")
+	}
+	suitePath := fs.Arg(0)
+	data, err := os.ReadFile(suitePath)
+	if err != nil {
+		return err
+	}
+	suite, err := eval.ParseSuite(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", suitePath, err)
+	}
+	refs := suite.Models
+	if *modelRefs != "" {
+		refs = strings.Split(*modelRefs, ",")
+	}
+	if len(refs) == 0 {
+		return fmt.Errorf("%s lists no models; pass --models", suitePath)
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	var targets []compareTarget
+	for _, ref := range refs {
+		target, err := resolveCompareRef(cfg, strings.TrimSpace(ref))
+		if err != nil {
+			return err
+		}
+		targets = append(targets, target)
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+
+	// Graded assertions are judged by the default provider so the model
+	// under evaluation never grades itself.
+	grade := func(criteria, reply string) (bool, string, error) {
+		graderID := cfg.DefaultProvider
+		if graderID == "" {
+			return false, "", fmt.Errorf("graded assertions need a default provider to judge with")
+		}
+		def, err := registry.Provider(graderID)
+		if err != nil {
+			return false, "", err
+		}
+		entry := *cfg.Providers[graderID]
+		verdict, _, err := llm.CompleteWithRetry(context.Background(), def, &entry,
+			llm.Prompt{Text: fmt.Sprintf(gradeTemplate, criteria, reply)})
+		if err != nil {
+			return false, "", fmt.Errorf("grading: %w", err)
+		}
+		first, _, _ := strings.Cut(strings.TrimSpace(verdict), "\n")
+		return strings.HasPrefix(strings.ToUpper(first), "PASS"), strings.TrimSpace(verdict), nil
+	}
+
+	th := app.Theme()
+	baseDir := filepath.Dir(suitePath)
+	failed := 0
+	for _, target := range targets {
+		def, err := registry.Provider(target.providerID)
+		if err != nil {
+			return err
+		}
+		passed := 0
+		var cost float64
+		fmt.Fprintln(app.Stdout, th.Emphasis(fmt.Sprintf("=== %s ===", target.ref)))
+		for _, c := range suite.Cases {
+			entry := *cfg.Providers[target.providerID]
+			if target.modelID != "" {
+				entry.ModelID = target.modelID
+			}
+			start := time.Now()
+			reply, usage, err := llm.CompleteWithRetry(context.Background(), def, &entry, llm.Prompt{Text: c.Prompt})
+			seconds := time.Since(start).Seconds()
+			metrics.RecordProviderRequest(target.providerID, seconds, usage.InputTokens, usage.OutputTokens, usage.CostUSD, err != nil)
+			stats.Record(target.providerID, seconds, err != nil)
+			cost += usage.CostUSD
+			detail := ""
+			ok := false
+			if err != nil {
+				detail = err.Error()
+			} else if ok, detail, err = c.Check(baseDir, reply, grade); err != nil {
+				return fmt.Errorf("case %q: %w", c.Name, err)
+			}
+			status := th.Success(fmt.Sprintf("%-4s", "ok"))
+			if !ok {
+				failed++
+				status = th.Failure(fmt.Sprintf("%-4s", "FAIL"))
+			} else {
+				passed++
+			}
+			fmt.Fprintf(app.Stdout, "%-24s %s %s\n", c.Name, status, detail)
+		}
+		fmt.Fprintf(app.Stdout, "%d/%d passed, %s\n\n", passed, len(suite.Cases), app.Costs().Format(cost, 4))
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d case(s) failed", failed)
+	}
+	return nil
+}
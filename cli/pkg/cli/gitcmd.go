@@ -0,0 +1,150 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/prompts"
+)
+
+// commitMsgTemplate is the built-in prompt; a prompts library template named
+// "commit-msg" takes precedence so teams can standardize their own.
+const commitMsgTemplate = `Write a git commit message for the staged diff below.
+Use an imperative subject line under 72 characters, a blank line, and a short
+body only when the change needs explanation. Output the message only, no
+code fences or commentary.
+
+{{.diff}}`
+
+func gitCommand() *Command {
+	return &Command{
+		Name:    "git",
+		Summary: "Git integration helpers.",
+		Sub: []*Command{
+			{
+				Name:    "commit-msg",
+				Summary: "Generate a commit message from the staged diff.",
+				Usage:   "cline git commit-msg [--provider <id>] [--model <id>] [<msg-file>]",
+				Run:     runGitCommitMsg,
+			},
+			{
+				Name:    "install-hooks",
+				Summary: "Install the prepare-commit-msg hook in this repository.",
+				Usage:   "cline git install-hooks [--force]",
+				Run:     runGitInstallHooks,
+			},
+		},
+	}
+}
+
+func runGitCommitMsg(app *App, args []string) error {
+	fs := flag.NewFlagSet("git commit-msg", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	model := fs.String("model", "", "model ID override")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 1 {
+		return fmt.Errorf("usage: cline git commit-msg [<msg-file>]")
+	}
+	diff, err := stagedDiff()
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		return fmt.Errorf("no staged changes; stage files with `git add` first")
+	}
+
+	tmpl := &prompts.Template{Name: "commit-msg", Body: commitMsgTemplate}
+	if shared, err := prompts.Load("commit-msg"); err == nil {
+		tmpl = shared
+	}
+	text, err := tmpl.Render(map[string]string{"diff": diff})
+	if err != nil {
+		return err
+	}
+
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	providerID := *provider
+	if providerID == "" {
+		providerID = tmpl.Provider
+	}
+	modelID := *model
+	if modelID == "" {
+		modelID = tmpl.ModelID
+	}
+	reply, err := app.sendPrompt(cfg, askRequest{providerID: providerID, modelID: modelID, text: text})
+	if err != nil {
+		return err
+	}
+	if fs.NArg() == 1 {
+		// prepare-commit-msg mode: the hook hands us the message file. Keep
+		// whatever git put there (comments, merge text) below our message.
+		existing, _ := os.ReadFile(fs.Arg(0))
+		content := strings.TrimSpace(reply) + "\n"
+		if len(existing) > 0 {
+			content += "\n" + string(existing)
+		}
+		return os.WriteFile(fs.Arg(0), []byte(content), 0o644)
+	}
+	return nil
+}
+
+// stagedDiff returns `git diff --cached` for the working directory.
+func stagedDiff() (string, error) {
+	out, err := exec.Command("git", "diff", "--cached").Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return "", fmt.Errorf("git diff --cached: %s", strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("git diff --cached: %w", err)
+	}
+	return string(out), nil
+}
+
+// prepareCommitMsgHook skips amended, merge and message-supplied commits so
+// the model only runs when git would otherwise open an empty editor.
+const prepareCommitMsgHook = `#!/bin/sh
+# Installed by cline git install-hooks.
+case "$2" in
+  message|template|merge|squash|commit) exit 0 ;;
+esac
+cline git commit-msg "$1" || exit 0
+`
+
+func runGitInstallHooks(app *App, args []string) error {
+	fs := flag.NewFlagSet("git install-hooks", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	force := fs.Bool("force", false, "overwrite an existing prepare-commit-msg hook")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	out, err := exec.Command("git", "rev-parse", "--git-dir").Output()
+	if err != nil {
+		return fmt.Errorf("not inside a git repository")
+	}
+	hookDir := filepath.Join(strings.TrimSpace(string(out)), "hooks")
+	if err := os.MkdirAll(hookDir, 0o755); err != nil {
+		return err
+	}
+	path := filepath.Join(hookDir, "prepare-commit-msg")
+	if _, err := os.Stat(path); err == nil && !*force {
+		return fmt.Errorf("%s already exists; re-run with --force to replace it", path)
+	}
+	if err := os.WriteFile(path, []byte(prepareCommitMsgHook), 0o755); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Installed %s.\n", path)
+	return nil
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/history"
+)
+
+func historyCommand() *Command {
+	return &Command{
+		Name:    "history",
+		Summary: "List past prompts and rerun them.",
+		Usage:   "cline history [-n <count>]",
+		Run:     runHistoryList,
+		Sub: []*Command{
+			{
+				Name:    "rerun",
+				Summary: "Re-execute a recorded prompt, optionally against another model.",
+				Usage:   "cline history rerun <id> [--provider <id>] [--model <id>]",
+				Run:     runHistoryRerun,
+			},
+			{
+				Name:    "search",
+				Summary: "Full-text search across recorded prompts.",
+				Usage:   "cline history search [--since <30d|2026-01-02>] [--provider <id>] [--model <id>] [--workspace] <query>",
+				Run:     runHistorySearch,
+			},
+			{
+				Name:    "purge",
+				Summary: "Delete recorded history, entirely or past a given age.",
+				Usage:   "cline history purge [--older-than <30d|12h>]",
+				Run:     runHistoryPurge,
+			},
+		},
+	}
+}
+
+func runHistoryList(app *App, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	count := fs.Int("n", 20, "number of entries to show (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	entries, err := history.List()
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		fmt.Fprintln(app.Stdout, "No history recorded.")
+		return nil
+	}
+	if *count > 0 && len(entries) > *count {
+		entries = entries[:*count]
+	}
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	for _, e := range entries {
+		target := e.Provider
+		if e.ModelID != "" {
+			target += "/" + e.ModelID
+		}
+		fmt.Fprintf(out, "%-22s %s %-7s %-40s %s %s\n",
+			e.ID, e.Time.Local().Format("2006-01-02 15:04"), e.Command, target, app.Costs().Format(e.CostUSD, 4), promptPreview(e.Prompt))
+	}
+	return nil
+}
+
+// promptPreview flattens and truncates a prompt for the one-line listing.
+func promptPreview(prompt string) string {
+	flat := strings.Join(strings.Fields(prompt), " ")
+	if len(flat) > 60 {
+		flat = flat[:57] + "..."
+	}
+	return flat
+}
+
+func runHistoryRerun(app *App, args []string) error {
+	fs := flag.NewFlagSet("history rerun", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider to rerun against (default: the original)")
+	model := fs.String("model", "", "model to rerun against (default: the original)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline history rerun <id> [--provider <id>] [--model <id>]")
+	}
+	entry, err := history.Find(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	req := askRequest{
+		providerID: entry.Provider,
+		modelID:    entry.ModelID,
+		text:       entry.Prompt,
+	}
+	if *provider != "" {
+		req.providerID = *provider
+		if *model == "" {
+			// The original model likely does not exist on another provider.
+			req.modelID = ""
+		}
+	}
+	if *model != "" {
+		req.modelID = *model
+	}
+	_, err = app.sendPrompt(cfg, req)
+	return err
+}
+
+func runHistorySearch(app *App, args []string) error {
+	fs := flag.NewFlagSet("history search", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	since := fs.String("since", "", "only entries newer than this (\"30d\", \"12h\", or a YYYY-MM-DD date)")
+	provider := fs.String("provider", "", "only entries answered by this provider")
+	model := fs.String("model", "", "only entries answered by this model")
+	workspace := fs.Bool("workspace", false, "only entries recorded in the current directory")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	query := strings.Join(fs.Args(), " ")
+	if query == "" && *since == "" && *provider == "" && *model == "" && !*workspace {
+		return fmt.Errorf("usage: cline history search [--since <30d>] [--provider <id>] [--model <id>] [--workspace] <query>")
+	}
+	filter := history.Filter{Provider: *provider, ModelID: *model}
+	if *since != "" {
+		if at, err := time.ParseInLocation("2006-01-02", *since, time.Local); err == nil {
+			filter.Since = at
+		} else if _, age := history.Retention(map[string]string{history.SettingRetention: *since}); age > 0 {
+			filter.Since = time.Now().Add(-age)
+		} else {
+			return fmt.Errorf("cannot parse %q as an age or date", *since)
+		}
+	}
+	if *workspace {
+		wd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		filter.Workspace = wd
+	}
+	matches, err := history.Search(query, filter)
+	if err != nil {
+		return err
+	}
+	if len(matches) == 0 {
+		fmt.Fprintln(app.Stdout, "No matching history entries.")
+		return nil
+	}
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	for _, e := range matches {
+		target := e.Provider
+		if e.ModelID != "" {
+			target += "/" + e.ModelID
+		}
+		fmt.Fprintf(out, "%-22s %s %-7s %-40s %s %s\n",
+			e.ID, e.Time.Local().Format("2006-01-02 15:04"), e.Command, target, app.Costs().Format(e.CostUSD, 4), promptPreview(e.Prompt))
+	}
+	return nil
+}
+
+func runHistoryPurge(app *App, args []string) error {
+	fs := flag.NewFlagSet("history purge", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	olderThan := fs.String("older-than", "", "only remove entries older than this (\"30d\", \"12h\"; default: everything)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: cline history purge [--older-than <30d|12h>]")
+	}
+	var age time.Duration
+	if *olderThan != "" {
+		// The retention parser already understands "30d" and Go durations.
+		_, age = history.Retention(map[string]string{history.SettingRetention: *olderThan})
+		if age == 0 {
+			return fmt.Errorf("cannot parse %q as an age", *olderThan)
+		}
+	}
+	if app.dryRun {
+		if age > 0 {
+			app.dryRunSkip(fmt.Sprintf("delete history entries older than %s", *olderThan))
+		} else {
+			app.dryRunSkip("delete all history entries")
+		}
+		return nil
+	}
+	removed, err := history.Purge(age)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Removed %d history record(s).\n", removed)
+	return nil
+}
@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cline/cline/cli/pkg/hooks"
+)
+
+func hooksCommand() *Command {
+	return &Command{
+		Name:    "hooks",
+		Summary: "Inspect and test lifecycle hooks.",
+		Sub: []*Command{
+			{
+				Name:    "list",
+				Summary: "List installed hooks and the events they fire on.",
+				Usage:   "cline hooks list",
+				Run:     runHooksList,
+			},
+			{
+				Name:    "run",
+				Summary: "Fire an event manually to test installed hooks.",
+				Usage:   "cline hooks run <event>",
+				Run:     runHooksRun,
+			},
+		},
+	}
+}
+
+var hookEvents = []hooks.Event{hooks.PreTask, hooks.PostTask, hooks.PreConfigSave, hooks.PostAuth}
+
+func runHooksList(app *App, args []string) error {
+	dir, err := hooks.Dir()
+	if err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) || len(entries) == 0 {
+		fmt.Fprintf(app.Stdout, "No hooks installed. Place executables named after an event (%s) in %s.\n", eventNames(), dir)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if !e.IsDir() {
+			names = append(names, e.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintln(app.Stdout, filepath.Join(dir, name))
+	}
+	return nil
+}
+
+func runHooksRun(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline hooks run <event> (one of %s)", eventNames())
+	}
+	event := hooks.Event(args[0])
+	valid := false
+	for _, e := range hookEvents {
+		if e == event {
+			valid = true
+			break
+		}
+	}
+	if !valid {
+		return fmt.Errorf("unknown event %q (one of %s)", args[0], eventNames())
+	}
+	if err := hooks.Fire(context.Background(), event, map[string]any{"manual": true}); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Fired %s.\n", event)
+	return nil
+}
+
+func eventNames() string {
+	s := ""
+	for i, e := range hookEvents {
+		if i > 0 {
+			s += ", "
+		}
+		s += string(e)
+	}
+	return s
+}
@@ -0,0 +1,165 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/instructions"
+)
+
+func instructionsCommand() *Command {
+	return &Command{
+		Name:    "instructions",
+		Summary: "Manage named system-prompt profiles.",
+		Sub: []*Command{
+			{
+				Name:    "list",
+				Summary: "List saved instruction profiles.",
+				Usage:   "cline instructions list",
+				Run:     runInstructionsList,
+			},
+			{
+				Name:    "show",
+				Summary: "Print a profile's instruction text.",
+				Usage:   "cline instructions show <name>",
+				Run:     runInstructionsShow,
+			},
+			{
+				Name:    "set",
+				Summary: "Create or update a profile from a file or stdin.",
+				Usage:   "cline instructions set <name> [--file <path>]",
+				Run:     runInstructionsSet,
+			},
+			{
+				Name:    "remove",
+				Summary: "Delete a profile.",
+				Usage:   "cline instructions remove <name>",
+				Run:     runInstructionsRemove,
+			},
+			{
+				Name:    "use",
+				Summary: "Make a profile the default for the current workspace.",
+				Usage:   "cline instructions use <name>",
+				Run:     runInstructionsUse,
+			},
+		},
+	}
+}
+
+func runInstructionsList(app *App, args []string) error {
+	names, err := instructions.List()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		fmt.Fprintln(app.Stdout, "No instruction profiles saved.")
+		return nil
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	workspaceDefault := instructions.DefaultFor(cfg.Settings, ".")
+	for _, name := range names {
+		marker := ""
+		if name == workspaceDefault {
+			marker = " (workspace default)"
+		}
+		fmt.Fprintf(app.Stdout, "%s%s\n", name, marker)
+	}
+	return nil
+}
+
+func runInstructionsShow(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline instructions show <name>")
+	}
+	text, err := instructions.Load(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintln(app.Stdout, text)
+	return nil
+}
+
+func runInstructionsSet(app *App, args []string) error {
+	fs := flag.NewFlagSet("instructions set", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	file := fs.String("file", "", "read the instruction text from a file instead of stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline instructions set <name> [--file <path>]")
+	}
+	var data []byte
+	var err error
+	if *file != "" {
+		data, err = os.ReadFile(*file)
+	} else {
+		data, err = io.ReadAll(app.Stdin)
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return fmt.Errorf("no instruction text provided")
+	}
+	if err := instructions.Save(fs.Arg(0), string(data)); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Saved profile %s.\n", fs.Arg(0))
+	return nil
+}
+
+func runInstructionsRemove(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline instructions remove <name>")
+	}
+	if err := instructions.Remove(args[0]); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Removed profile %s.\n", args[0])
+	return nil
+}
+
+func runInstructionsUse(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline instructions use <name>")
+	}
+	name := args[0]
+	if _, err := instructions.Load(name); err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	if err := manager.Update(func(cfg *config.ClineConfig) error {
+		if cfg.Settings == nil {
+			cfg.Settings = make(map[string]string)
+		}
+		return instructions.SetDefault(cfg.Settings, ".", name)
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Workspace default instructions set to %s.\n", name)
+	return nil
+}
+
+// resolveInstructions returns the instruction text for a command invocation:
+// the explicit --instructions name wins, then the workspace default; empty
+// means none.
+func resolveInstructions(cfg *config.ClineConfig, flagName string) (string, error) {
+	name := flagName
+	if name == "" {
+		name = instructions.DefaultFor(cfg.Settings, ".")
+	}
+	if name == "" {
+		return "", nil
+	}
+	return instructions.Load(name)
+}
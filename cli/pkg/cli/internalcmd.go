@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/pprof"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/fixture"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/policy"
+)
+
+func internalCommand() *Command {
+	return &Command{
+		Name:    "internal",
+		Summary: "Maintainer utilities.",
+		Hidden:  true,
+		Sub: []*Command{
+			{
+				Name:    "profile-startup",
+				Summary: "Measure cold-start phases and optionally emit pprof profiles.",
+				Usage:   "cline internal profile-startup [--pprof-dir <dir>] [--iterations <n>]",
+				Hidden:  true,
+				Run:     runProfileStartup,
+			},
+			{
+				Name:    "policy-keygen",
+				Summary: "Generate an Ed25519 key pair for policy signing.",
+				Usage:   "cline internal policy-keygen",
+				Hidden:  true,
+				Run:     runPolicyKeygen,
+			},
+			{
+				Name:    "policy-sign",
+				Summary: "Write the detached signature for a policy file.",
+				Usage:   "cline internal policy-sign <policy.yaml> (reads the private key from CLINE_POLICY_SIGNING_KEY)",
+				Hidden:  true,
+				Run:     runPolicySign,
+			},
+			{
+				Name:    "schema",
+				Summary: "Dump the command tree, config schema, settings keys and exit codes as JSON.",
+				Usage:   "cline internal schema",
+				Hidden:  true,
+				Run:     runInternalSchema,
+			},
+			{
+				Name:    "fixture",
+				Summary: "Generate a deterministic fake config for demos and tests.",
+				Usage:   "cline internal fixture [--providers <n>] [--seed <n>] [--samples <n>] [--write [--force]]",
+				Hidden:  true,
+				Run:     runInternalFixture,
+			},
+		},
+	}
+}
+
+func runPolicyKeygen(app *App, args []string) error {
+	pub, priv, err := policy.GenerateKeyPair()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "public key:  %s\n", pub)
+	fmt.Fprintf(app.Stdout, "private key: %s\n", priv)
+	fmt.Fprintf(app.Stderr, "\nBake the public key into builds with -ldflags \"-X %s=<public>\"\n", "github.com/cline/cline/cli/pkg/policy.BuiltinPublicKey")
+	fmt.Fprintf(app.Stderr, "or configure it per machine: cline config set %s <public>\n", policy.SettingPublicKey)
+	return nil
+}
+
+// runPolicySign takes the private key from the environment rather than an
+// argument, so it never lands in shell history.
+func runPolicySign(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline internal policy-sign <policy.yaml>")
+	}
+	key := os.Getenv("CLINE_POLICY_SIGNING_KEY")
+	if key == "" {
+		return fmt.Errorf("set CLINE_POLICY_SIGNING_KEY to the base64 private key from `cline internal policy-keygen`")
+	}
+	if err := policy.SignFile(args[0], key); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Wrote %s%s.\n", args[0], policy.SignatureSuffix)
+	return nil
+}
+
+// runInternalFixture prints (or, with --write, installs) a deterministic fake
+// config so demos and integration tests never touch real keys.
+func runInternalFixture(app *App, args []string) error {
+	fs := flag.NewFlagSet("internal fixture", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	providers := fs.Int("providers", 0, "number of provider entries (default 3)")
+	seed := fs.Int64("seed", 0, "generation seed; equal seeds give equal fixtures (default 1)")
+	samples := fs.Int("samples", 0, "usage samples per provider (default 25)")
+	write := fs.Bool("write", false, "install the fixture into the config directory")
+	force := fs.Bool("force", false, "overwrite an existing config file")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	opts := fixture.Options{Providers: *providers, Seed: *seed, Samples: *samples}
+	if !*write {
+		cfg, err := fixture.Config(opts)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(app.Stdout, string(data))
+		return nil
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	if _, err := os.Stat(manager.Path()); err == nil && !*force {
+		return fmt.Errorf("%s already exists; pass --force to overwrite it with fixture data", manager.Path())
+	}
+	if err := fixture.Install(manager, opts); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Fixture installed at %s.\n", manager.Path())
+	return nil
+}
+
+// startupPhase is one timed unit of cold-start work.
+type startupPhase struct {
+	name string
+	run  func() error
+}
+
+func runProfileStartup(app *App, args []string) error {
+	fs := flag.NewFlagSet("internal profile-startup", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	pprofDir := fs.String("pprof-dir", "", "write cpu.pprof and heap.pprof to this directory")
+	iterations := fs.Int("iterations", 1, "repeat each phase n times and report the total")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	phases := []startupPhase{
+		{"registry-index", func() error {
+			_, err := generated.NewProviderRegistry()
+			return err
+		}},
+		{"registry-full-decode", func() error {
+			r, err := generated.NewProviderRegistry()
+			if err != nil {
+				return err
+			}
+			for _, id := range r.ProviderIDs() {
+				if _, err := r.Provider(id); err != nil {
+					return err
+				}
+			}
+			return nil
+		}},
+		{"config-load", func() error {
+			m, err := config.NewConfigManager()
+			if err != nil {
+				return err
+			}
+			_, err = m.Load()
+			return err
+		}},
+	}
+
+	var cpuFile *os.File
+	if *pprofDir != "" {
+		if err := os.MkdirAll(*pprofDir, 0o755); err != nil {
+			return err
+		}
+		f, err := os.Create(filepath.Join(*pprofDir, "cpu.pprof"))
+		if err != nil {
+			return err
+		}
+		cpuFile = f
+		if err := pprof.StartCPUProfile(f); err != nil {
+			f.Close()
+			return err
+		}
+	}
+
+	fmt.Fprintf(app.Stdout, "%-22s %s\n", "phase", "total")
+	for _, phase := range phases {
+		start := time.Now()
+		for i := 0; i < *iterations; i++ {
+			if err := phase.run(); err != nil {
+				fmt.Fprintf(app.Stdout, "%-22s failed: %v\n", phase.name, err)
+				break
+			}
+		}
+		fmt.Fprintf(app.Stdout, "%-22s %s\n", phase.name, time.Since(start).Round(time.Microsecond))
+	}
+
+	if cpuFile != nil {
+		pprof.StopCPUProfile()
+		cpuFile.Close()
+		heap, err := os.Create(filepath.Join(*pprofDir, "heap.pprof"))
+		if err != nil {
+			return err
+		}
+		defer heap.Close()
+		if err := pprof.WriteHeapProfile(heap); err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Stdout, "profiles written to %s\n", *pprofDir)
+	}
+	return nil
+}
@@ -0,0 +1,780 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/guardrails"
+	"github.com/cline/cline/cli/pkg/models"
+	"github.com/cline/cline/cli/pkg/recents"
+	"github.com/cline/cline/cli/pkg/transcript"
+)
+
+func modelsCommand() *Command {
+	return &Command{
+		Name:    "models",
+		Summary: "Browse model catalogs.",
+		Sub: []*Command{
+			{
+				Name:    "list",
+				Summary: "List models for a provider.",
+				Usage:   "cline models list --provider <id>",
+				Run:     runModelsList,
+			},
+			{
+				Name:    "refresh",
+				Summary: "Detect retired configured models and migrate them.",
+				Usage:   "cline models refresh [--yes]",
+				Run:     runModelsRefresh,
+			},
+			{
+				Name:    "warm",
+				Summary: "Refresh catalog caches and watch default-model pricing (for cron or shell hooks).",
+				Usage:   "cline models warm",
+				Run:     runModelsWarm,
+			},
+			{
+				Name:    "use",
+				Summary: "Select the model a provider entry uses.",
+				Usage:   "cline models use [--provider <id>] [--yes] <model-id>",
+				Run:     runModelsUse,
+			},
+			{
+				Name:    "alias",
+				Summary: "Name a provider/model pair for use as --model <name>.",
+				Usage:   "cline models alias [<name> <provider/model-id>] [--remove <name>]",
+				Run:     runModelsAlias,
+			},
+			{
+				Name:    "override",
+				Summary: "Override a model's catalog metadata (context window, prices).",
+				Usage:   "cline models override --provider <id> <model-id> [field=value ...] [--clear]",
+				Run:     runModelsOverride,
+			},
+			{
+				Name:    "changelog",
+				Summary: "Show recorded pricing and context-window changes over time.",
+				Usage:   "cline models changelog [--provider <id>] [--model <id>]",
+				Run:     runModelsChangelog,
+			},
+			{
+				Name:    "probe",
+				Summary: "Ask an OpenAI-compatible server what a model can do.",
+				Usage:   "cline models probe [--provider <id>] <model-id>",
+				Run:     runModelsProbe,
+			},
+			{
+				Name:    "favorite",
+				Summary: "Star or unstar a model so lists float it to the top.",
+				Usage:   "cline models favorite --provider <id> <model-id>",
+				Run:     runModelsFavorite,
+			},
+		},
+	}
+}
+
+func runModelsRefresh(app *App, args []string) error {
+	fs := flag.NewFlagSet("models refresh", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	yes := fs.Bool("yes", false, "apply migrations without confirming")
+	record := fs.String("record", "", "directory to write sanitized HTTP transcripts to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := installRecorder(*record); err != nil {
+		return err
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	var migrations []models.Migration
+	for _, id := range sortedKeys(cfg.Providers) {
+		entry := cfg.Providers[id]
+		if entry.ModelID == "" {
+			continue
+		}
+		def, err := registry.Provider(id)
+		if err != nil {
+			continue
+		}
+		available := def.Models
+		if def.DynamicModels {
+			available, err = models.Fetch(context.Background(), id, entry, generated.ListOptions{})
+			if err != nil {
+				fmt.Fprintf(app.Stderr, "warning: skipping %s, could not fetch catalog: %v\n", id, err)
+				continue
+			}
+		}
+		ids := make([]string, 0, len(available))
+		current := false
+		for _, m := range available {
+			ids = append(ids, m.ID)
+			if m.ID == entry.ModelID {
+				current = true
+			}
+		}
+		if def.DynamicModels {
+			reportCatalogDiff(app, id, ids)
+		}
+		if changes := models.RecordCatalogChanges(id, available); len(changes) > 0 {
+			fmt.Fprintf(app.Stdout, "%s: %d pricing/context change(s); see `cline models changelog`\n", id, len(changes))
+		}
+		if current {
+			continue
+		}
+		migrations = append(migrations, models.Migration{
+			Provider:   id,
+			OldModelID: entry.ModelID,
+			NewModelID: models.SuggestReplacement(entry.ModelID, ids, def.DefaultModelID),
+		})
+	}
+	if len(migrations) == 0 {
+		fmt.Fprintln(app.Stdout, "All configured models are still available.")
+		return nil
+	}
+	fmt.Fprintf(app.Stdout, "%d configured model(s) are no longer offered:\n", len(migrations))
+	for _, m := range migrations {
+		fmt.Fprintf(app.Stdout, "  %-14s %s -> %s\n", m.Provider, m.OldModelID, m.NewModelID)
+	}
+	if !*yes {
+		fmt.Fprintf(app.Stdout, "Apply %d migration(s)? [y/N]: ", len(migrations))
+		line, _ := bufio.NewReader(app.Stdin).ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(line)); answer != "y" && answer != "yes" {
+			fmt.Fprintln(app.Stdout, "No changes made.")
+			return nil
+		}
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		for _, m := range migrations {
+			if entry, ok := cfg.Providers[m.Provider]; ok && entry.ModelID == m.OldModelID {
+				entry.ModelID = m.NewModelID
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	models.InvalidateSessionCache()
+	fmt.Fprintf(app.Stdout, "Migrated %d provider(s).\n", len(migrations))
+	return nil
+}
+
+// reportCatalogDiff prints what changed since the previous refresh instead of
+// re-listing the whole catalog, and records the new snapshot.
+func reportCatalogDiff(app *App, providerID string, ids []string) {
+	previous := models.LoadCatalogIDs(providerID)
+	defer models.SaveCatalogIDs(providerID, ids)
+	if previous == nil {
+		fmt.Fprintf(app.Stdout, "%s: %d model(s) in catalog (first refresh, no diff)\n", providerID, len(ids))
+		return
+	}
+	diff := models.DiffIDs(previous, ids)
+	if diff.Empty() {
+		fmt.Fprintf(app.Stdout, "%s: catalog unchanged (%d model(s))\n", providerID, len(ids))
+		return
+	}
+	fmt.Fprintf(app.Stdout, "%s: %d added, %d removed since last refresh\n", providerID, len(diff.Added), len(diff.Removed))
+	for _, id := range diff.Added {
+		fmt.Fprintf(app.Stdout, "  + %s\n", id)
+	}
+	for _, id := range diff.Removed {
+		fmt.Fprintf(app.Stdout, "  - %s\n", id)
+	}
+}
+
+// installRecorder routes catalog fetches through a transcript recorder when
+// --record is given. CLINE_REPLAY_DIR instead serves responses from an
+// existing transcript, which integration tests use to stay offline.
+func installRecorder(dir string) error {
+	if replay := os.Getenv("CLINE_REPLAY_DIR"); replay != "" {
+		rp, err := transcript.NewReplayer(replay)
+		if err != nil {
+			return err
+		}
+		models.SetHTTPTransport(rp)
+		return nil
+	}
+	if dir == "" {
+		return nil
+	}
+	rec, err := transcript.NewRecorder(dir, nil)
+	if err != nil {
+		return err
+	}
+	models.SetHTTPTransport(rec)
+	return nil
+}
+
+func runModelsList(app *App, args []string) error {
+	fs := flag.NewFlagSet("models list", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID")
+	limit := fs.Int("limit", 0, "maximum number of models to list (dynamic providers)")
+	offset := fs.Int("offset", 0, "number of models to skip (dynamic providers)")
+	recent := fs.Bool("recent", false, "order by favorites and recent use instead of catalog order")
+	record := fs.String("record", "", "directory to write sanitized HTTP transcripts to")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *provider == "" {
+		return fmt.Errorf("--provider is required")
+	}
+	if err := installRecorder(*record); err != nil {
+		return err
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	def, err := registry.Provider(*provider)
+	if err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	entry := cfg.Providers[*provider]
+	if entry == nil {
+		entry = &config.ProviderConfig{Provider: *provider}
+	}
+	state := recents.Load()
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	if def.DynamicModels {
+		fetched, err := models.FetchCached(context.Background(), *provider, entry, generated.ListOptions{Limit: *limit, Offset: *offset})
+		if err != nil {
+			return fmt.Errorf("fetching %s models: %w", def.Name, err)
+		}
+		if *recent {
+			fetched = orderModels(state, *provider, fetched)
+		}
+		for _, m := range fetched {
+			fmt.Fprintf(out, "%s %-44s ctx=%-8d %s/%s per Mtok%s\n",
+				favoriteMarker(state, *provider, m.ID, " "), m.ID, m.ContextWindow,
+				app.Costs().Format(m.InputPrice, 2), app.Costs().Format(m.OutputPrice, 2),
+				overrideMarker(entry, m.ID))
+		}
+		return nil
+	}
+	listed := append([]generated.ModelInfo(nil), def.Models...)
+	models.ApplyOverrides(entry, listed)
+	if *recent {
+		listed = orderModels(state, *provider, listed)
+	}
+	for _, m := range listed {
+		marker := " "
+		if m.ID == def.DefaultModelID {
+			marker = "*"
+		}
+		fmt.Fprintf(out, "%s %-44s ctx=%-8d %s/%s per Mtok%s\n",
+			favoriteMarker(state, *provider, m.ID, marker), m.ID, m.ContextWindow,
+			app.Costs().Format(m.InputPrice, 2), app.Costs().Format(m.OutputPrice, 2),
+			overrideMarker(entry, m.ID))
+	}
+	return nil
+}
+
+func runModelsUse(app *App, args []string) error {
+	fs := flag.NewFlagSet("models use", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	yes := fs.Bool("yes", false, "skip the cost confirmation for expensive models")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(fs.Args()) != 1 {
+		return fmt.Errorf("usage: cline models use [--provider <id>] <model-id>")
+	}
+	modelID := fs.Args()[0]
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	providerID := *provider
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	if providerID == "" {
+		return fmt.Errorf("no provider configured; run `cline auth add --provider <id>`")
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return err
+	}
+	entry := cfg.Provider(providerID)
+	if m := findModel(def, entry, modelID); m != nil {
+		if !*yes {
+			ok, err := confirmModelPrice(app, m, cfg.Settings)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				fmt.Fprintln(app.Stdout, "No changes made.")
+				return nil
+			}
+		}
+	} else if !def.DynamicModels {
+		return fmt.Errorf("provider %q has no model %q", providerID, modelID)
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		cfg.Provider(providerID).ModelID = modelID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "%s now uses %s.\n", providerID, modelID)
+	return nil
+}
+
+// findModel looks modelID up in the provider's static catalog with the
+// entry's overrides applied; nil for dynamic-only models.
+func findModel(def *generated.ProviderDefinition, entry *config.ProviderConfig, modelID string) *generated.ModelInfo {
+	for _, m := range def.Models {
+		if m.ID == modelID {
+			models.Override(entry, &m)
+			return &m
+		}
+	}
+	return nil
+}
+
+// confirmModelPrice warns when the model's blended price is over the
+// cost.warnThreshold setting and asks before proceeding.
+func confirmModelPrice(app *App, m *generated.ModelInfo, settings map[string]string) (bool, error) {
+	warning, err := guardrails.CheckPrice(m, settings)
+	if err != nil {
+		return false, err
+	}
+	if warning == nil {
+		return true, nil
+	}
+	fmt.Fprintf(app.Stdout, "%s costs ~%s per million tokens blended (%s in / %s out), over the %s threshold.\n",
+		m.ID, app.Costs().Format(warning.BlendedPrice, 2), app.Costs().Format(m.InputPrice, 2),
+		app.Costs().Format(m.OutputPrice, 2), app.Costs().Format(warning.Threshold, 2))
+	fmt.Fprintf(app.Stdout, "A typical task would run about %s.\n", app.Costs().Format(warning.TypicalTaskUSD, 2))
+	fmt.Fprint(app.Stdout, "Use it anyway? [y/N]: ")
+	line, _ := bufio.NewReader(app.Stdin).ReadString('\n')
+	answer := strings.ToLower(strings.TrimSpace(line))
+	return answer == "y" || answer == "yes", nil
+}
+
+func runModelsAlias(app *App, args []string) error {
+	fs := flag.NewFlagSet("models alias", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	remove := fs.String("remove", "", "alias name to delete")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	if *remove != "" {
+		err := manager.Update(func(cfg *config.ClineConfig) error {
+			if _, ok := cfg.ModelAliases[*remove]; !ok {
+				return fmt.Errorf("no alias named %q", *remove)
+			}
+			delete(cfg.ModelAliases, *remove)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Stdout, "Removed alias %q.\n", *remove)
+		return nil
+	}
+	switch len(fs.Args()) {
+	case 0:
+		cfg, err := manager.Config()
+		if err != nil {
+			return err
+		}
+		if len(cfg.ModelAliases) == 0 {
+			fmt.Fprintln(app.Stdout, "No model aliases defined.")
+			return nil
+		}
+		for _, name := range sortedKeys(cfg.ModelAliases) {
+			fmt.Fprintf(app.Stdout, "%-16s %s\n", name, cfg.ModelAliases[name])
+		}
+		return nil
+	case 2:
+		name, target := fs.Args()[0], fs.Args()[1]
+		if strings.Contains(name, "/") {
+			return fmt.Errorf("alias names cannot contain %q", "/")
+		}
+		err := manager.Update(func(cfg *config.ClineConfig) error {
+			if cfg.ModelAliases == nil {
+				cfg.ModelAliases = make(map[string]string)
+			}
+			cfg.ModelAliases[name] = target
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Stdout, "Aliased %q to %s.\n", name, target)
+		return nil
+	default:
+		return fmt.Errorf("usage: cline models alias [<name> <provider/model-id>] [--remove <name>]")
+	}
+}
+
+// overrideMarker flags models whose listed metadata differs from the catalog.
+func overrideMarker(entry *config.ProviderConfig, modelID string) string {
+	if entry.OverrideFor(modelID) != nil {
+		return " (overridden)"
+	}
+	return ""
+}
+
+func runModelsOverride(app *App, args []string) error {
+	fs := flag.NewFlagSet("models override", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID")
+	clear := fs.Bool("clear", false, "remove the model's override")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *provider == "" || len(fs.Args()) < 1 {
+		return fmt.Errorf("usage: cline models override --provider <id> <model-id> [field=value ...] [--clear]")
+	}
+	modelID := fs.Args()[0]
+	assignments := fs.Args()[1:]
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	if *clear {
+		err := manager.Update(func(cfg *config.ClineConfig) error {
+			delete(cfg.Provider(*provider).ModelOverrides, modelID)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		models.InvalidateSessionCache()
+		fmt.Fprintf(app.Stdout, "Cleared override for %s/%s.\n", *provider, modelID)
+		return nil
+	}
+	if len(assignments) == 0 {
+		cfg, err := manager.Config()
+		if err != nil {
+			return err
+		}
+		o := cfg.Provider(*provider).OverrideFor(modelID)
+		if o == nil || o.Empty() {
+			fmt.Fprintf(app.Stdout, "No override for %s/%s.\n", *provider, modelID)
+			return nil
+		}
+		if o.ContextWindow != nil {
+			fmt.Fprintf(app.Stdout, "contextWindow=%d\n", *o.ContextWindow)
+		}
+		if o.MaxTokens != nil {
+			fmt.Fprintf(app.Stdout, "maxTokens=%d\n", *o.MaxTokens)
+		}
+		if o.InputPrice != nil {
+			fmt.Fprintf(app.Stdout, "inputPrice=%g\n", *o.InputPrice)
+		}
+		if o.OutputPrice != nil {
+			fmt.Fprintf(app.Stdout, "outputPrice=%g\n", *o.OutputPrice)
+		}
+		return nil
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		entry := cfg.Provider(*provider)
+		if entry.ModelOverrides == nil {
+			entry.ModelOverrides = make(map[string]*config.ModelOverride)
+		}
+		o := entry.ModelOverrides[modelID]
+		if o == nil {
+			o = &config.ModelOverride{}
+			entry.ModelOverrides[modelID] = o
+		}
+		for _, assignment := range assignments {
+			name, value, ok := strings.Cut(assignment, "=")
+			if !ok {
+				return fmt.Errorf("expected field=value, got %q", assignment)
+			}
+			if err := setOverrideField(o, name, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	models.InvalidateSessionCache()
+	fmt.Fprintf(app.Stdout, "Override saved for %s/%s.\n", *provider, modelID)
+	return nil
+}
+
+// setOverrideField parses one field=value assignment into the override.
+func setOverrideField(o *config.ModelOverride, name, value string) error {
+	switch name {
+	case "contextWindow", "maxTokens":
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 0 {
+			return fmt.Errorf("%s must be a non-negative integer, got %q", name, value)
+		}
+		if name == "contextWindow" {
+			o.ContextWindow = &n
+		} else {
+			o.MaxTokens = &n
+		}
+	case "inputPrice", "outputPrice":
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || f < 0 {
+			return fmt.Errorf("%s must be a non-negative price per million tokens, got %q", name, value)
+		}
+		if name == "inputPrice" {
+			o.InputPrice = &f
+		} else {
+			o.OutputPrice = &f
+		}
+	default:
+		return fmt.Errorf("unknown override field %q (contextWindow, maxTokens, inputPrice, outputPrice)", name)
+	}
+	return nil
+}
+
+// orderModels applies favorites-then-recents ordering to a model list.
+func orderModels(state *recents.State, provider string, list []generated.ModelInfo) []generated.ModelInfo {
+	ids := make([]string, len(list))
+	byID := make(map[string]generated.ModelInfo, len(list))
+	for i, m := range list {
+		ids[i] = m.ID
+		byID[m.ID] = m
+	}
+	state.SortModelIDs(provider, ids)
+	ordered := make([]generated.ModelInfo, len(ids))
+	for i, id := range ids {
+		ordered[i] = byID[id]
+	}
+	return ordered
+}
+
+// favoriteMarker stars favorited models, keeping the caller's marker
+// otherwise.
+func favoriteMarker(state *recents.State, provider, modelID, fallback string) string {
+	if state.IsFavorite(provider, modelID) {
+		return "★"
+	}
+	return fallback
+}
+
+func runModelsFavorite(app *App, args []string) error {
+	fs := flag.NewFlagSet("models favorite", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *provider == "" || len(fs.Args()) != 1 {
+		return fmt.Errorf("usage: cline models favorite --provider <id> <model-id>")
+	}
+	modelID := fs.Args()[0]
+	state := recents.Load()
+	starred := state.ToggleFavorite(*provider, modelID)
+	state.Save()
+	if starred {
+		fmt.Fprintf(app.Stdout, "Starred %s/%s.\n", *provider, modelID)
+	} else {
+		fmt.Fprintf(app.Stdout, "Unstarred %s/%s.\n", *provider, modelID)
+	}
+	return nil
+}
+
+func runModelsProbe(app *App, args []string) error {
+	fs := flag.NewFlagSet("models probe", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if len(fs.Args()) != 1 {
+		return fmt.Errorf("usage: cline models probe [--provider <id>] <model-id>")
+	}
+	modelID := fs.Args()[0]
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	providerID := *provider
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	if providerID == "" {
+		return fmt.Errorf("no provider configured; run `cline auth add --provider <id>`")
+	}
+	entry, ok := cfg.Providers[providerID]
+	if !ok {
+		return fmt.Errorf("provider %q is not configured", providerID)
+	}
+	if entry.BaseURL == "" {
+		return fmt.Errorf("provider %q has no base URL; probing is for OpenAI-compatible servers", providerID)
+	}
+	fmt.Fprintf(app.Stderr, "Probing %s on %s...\n", modelID, entry.BaseURL)
+	caps, err := models.Probe(context.Background(), entry, entry.BaseURL, modelID)
+	if err != nil {
+		return fmt.Errorf("probing %s: %w", modelID, err)
+	}
+	if caps.ContextWindow > 0 {
+		fmt.Fprintf(app.Stdout, "context window: %d tokens\n", caps.ContextWindow)
+	} else {
+		fmt.Fprintln(app.Stdout, "context window: unknown (server did not report a limit)")
+	}
+	fmt.Fprintf(app.Stdout, "vision: %s\n", yesNo(caps.SupportsImages))
+	fmt.Fprintf(app.Stdout, "tools: %s\n", yesNo(caps.SupportsTools))
+	fmt.Fprintln(app.Stdout, "Cached; `cline models list` will use these results.")
+	return nil
+}
+
+// runModelsWarm is the cron/shell-hook entry point: it refreshes the catalog
+// caches for every configured dynamic provider, and watches the default
+// model's pricing, queueing a notice for the next interactive run when it
+// moved.
+func runModelsWarm(app *App, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: cline models warm")
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	warmed := 0
+	for _, id := range sortedKeys(cfg.Providers) {
+		def, err := registry.Provider(id)
+		if err != nil || !def.DynamicModels {
+			continue
+		}
+		fetched, err := models.Fetch(context.Background(), id, cfg.Providers[id], generated.ListOptions{})
+		if err != nil {
+			fmt.Fprintf(app.Stderr, "warning: could not refresh %s: %v\n", id, err)
+			continue
+		}
+		for _, change := range models.RecordCatalogChanges(id, fetched) {
+			queueNotice(change.String())
+		}
+		warmed++
+	}
+	if m := defaultModelInfo(app, cfg); m != nil {
+		if notice := models.WatchPrice(cfg.DefaultProvider, *m); notice != "" {
+			queueNotice(notice)
+			fmt.Fprintf(app.Stdout, "%s\n", notice)
+		}
+	}
+	fmt.Fprintf(app.Stdout, "Refreshed %d catalog(s).\n", warmed)
+	return nil
+}
+
+// defaultModelInfo resolves the catalog entry for the configured default
+// provider's model, or nil when there is no default or no catalog data.
+func defaultModelInfo(app *App, cfg *config.ClineConfig) *generated.ModelInfo {
+	if cfg.DefaultProvider == "" {
+		return nil
+	}
+	entry, ok := cfg.Providers[cfg.DefaultProvider]
+	if !ok {
+		return nil
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return nil
+	}
+	def, err := registry.Provider(cfg.DefaultProvider)
+	if err != nil {
+		return nil
+	}
+	modelID := entry.ModelID
+	if modelID == "" {
+		modelID = def.DefaultModelID
+	}
+	if modelID == "" {
+		return nil
+	}
+	available := def.Models
+	if def.DynamicModels {
+		fetched, err := models.Fetch(context.Background(), cfg.DefaultProvider, entry, generated.ListOptions{})
+		if err != nil {
+			return nil
+		}
+		available = fetched
+	}
+	for _, m := range available {
+		if m.ID == modelID {
+			return &m
+		}
+	}
+	return nil
+}
+
+func runModelsChangelog(app *App, args []string) error {
+	fs := flag.NewFlagSet("models changelog", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "only show changes for this provider")
+	model := fs.String("model", "", "only show changes for this model ID")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	entries, err := models.LoadChangelog()
+	if err != nil {
+		return err
+	}
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	shown := 0
+	for _, e := range entries {
+		if *provider != "" && e.Provider != *provider {
+			continue
+		}
+		if *model != "" && e.ModelID != *model {
+			continue
+		}
+		fmt.Fprintln(out, e.String())
+		shown++
+	}
+	if shown == 0 {
+		fmt.Fprintln(out, "No catalog changes recorded yet; they accumulate across `cline models refresh` and `cline models warm` runs.")
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// Notices are short messages queued by background work (the models warm
+// refresh, for now) and shown once at the next interactive invocation. They
+// live in a plain text file, one notice per line, deleted after display.
+
+func noticesPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "notices"), nil
+}
+
+// queueNotice appends a message for the next invocation; best effort like
+// the other state files.
+func queueNotice(text string) {
+	path, err := noticesPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	fmt.Fprintln(f, text)
+}
+
+// showPendingNotices prints and clears any queued notices. Called from
+// loadConfig so every provider-using command surfaces them exactly once.
+func (a *App) showPendingNotices() {
+	path, err := noticesPath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	os.Remove(path)
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line != "" {
+			fmt.Fprintf(a.Stderr, "notice: %s\n", line)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cline/cline/cli/pkg/bundle"
+)
+
+func offlineCommand() *Command {
+	return &Command{
+		Name:    "offline",
+		Summary: "Move definitions and catalogs to air-gapped machines.",
+		Sub: []*Command{
+			{
+				Name:    "bundle",
+				Summary: "Create and apply offline bundles.",
+				Sub: []*Command{
+					{
+						Name:    "create",
+						Summary: "Package definitions, cached catalogs and optionally the binary into one file.",
+						Usage:   "cline offline bundle create <file> [--binary]",
+						Run:     runOfflineBundleCreate,
+					},
+					{
+						Name:    "apply",
+						Summary: "Populate local caches from a bundle created on a connected machine.",
+						Usage:   "cline offline bundle apply <file>",
+						Run:     runOfflineBundleApply,
+					},
+				},
+			},
+		},
+	}
+}
+
+func runOfflineBundleCreate(app *App, args []string) error {
+	includeBinary := false
+	var rest []string
+	for _, arg := range args {
+		if arg == "--binary" {
+			includeBinary = true
+			continue
+		}
+		rest = append(rest, arg)
+	}
+	if len(rest) != 1 {
+		return fmt.Errorf("usage: cline offline bundle create <file> [--binary]")
+	}
+	f, err := os.OpenFile(rest[0], os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	entries, err := bundle.Create(f, includeBinary)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(rest[0])
+		return err
+	}
+	for _, entry := range entries {
+		fmt.Fprintln(app.Stdout, entry)
+	}
+	fmt.Fprintf(app.Stdout, "Wrote %s with %d entries.\n", rest[0], len(entries))
+	return nil
+}
+
+func runOfflineBundleApply(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline offline bundle apply <file>")
+	}
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if app.dryRun {
+		entries, err := bundle.List(f)
+		if err != nil {
+			return err
+		}
+		app.dryRunSkip(fmt.Sprintf("apply %d entries from %s:", len(entries), args[0]))
+		for _, entry := range entries {
+			fmt.Fprintf(app.Stdout, "  %s\n", entry)
+		}
+		return nil
+	}
+	applied, err := bundle.Apply(f)
+	for _, line := range applied {
+		fmt.Fprintln(app.Stdout, line)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Applied %d entries from %s.\n", len(applied), args[0])
+	return nil
+}
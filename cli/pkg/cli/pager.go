@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// pagedStdout returns the writer long listings should print to and a flush
+// callback to defer. When stdout is an interactive terminal and the output
+// would overflow the screen, the flush pipes it through the user's pager;
+// otherwise output passes straight through. The global --no-pager flag and
+// a non-terminal stdout both disable paging.
+func (a *App) pagedStdout() (io.Writer, func()) {
+	if a.noPager || !stdoutIsTerminal(a) {
+		return a.Stdout, func() {}
+	}
+	p := &pagerWriter{app: a}
+	return p, p.flush
+}
+
+// pagerWriter buffers output until flush decides whether to page it.
+type pagerWriter struct {
+	app *App
+	buf bytes.Buffer
+}
+
+func (p *pagerWriter) Write(b []byte) (int, error) {
+	return p.buf.Write(b)
+}
+
+func (p *pagerWriter) flush() {
+	content := p.buf.Bytes()
+	pager := pagerCommand()
+	if pager == nil || bytes.Count(content, []byte("\n")) < terminalRows() {
+		p.app.Stdout.Write(content)
+		return
+	}
+	if _, err := exec.LookPath(pager[0]); err != nil {
+		p.app.Stdout.Write(content)
+		return
+	}
+	cmd := exec.Command(pager[0], pager[1:]...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = p.app.Stdout
+	cmd.Stderr = p.app.Stderr
+	if err := cmd.Run(); err != nil {
+		p.app.Stdout.Write(content)
+	}
+}
+
+// stdoutIsTerminal reports whether the app writes to an interactive
+// terminal; swapped in tests.
+var stdoutIsTerminal = func(a *App) bool {
+	f, ok := a.Stdout.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// pagerCommand resolves $PAGER. An unset variable falls back to less with
+// git's flags (quit on one screen, pass colors, leave the screen intact);
+// "cat" is the conventional way to say "no pager".
+func pagerCommand() []string {
+	switch pager := os.Getenv("PAGER"); pager {
+	case "":
+		return []string{"less", "-FRX"}
+	case "cat":
+		return nil
+	default:
+		return strings.Fields(pager)
+	}
+}
+
+// terminalRows is the screen height used to decide whether output overflows;
+// $LINES when the shell exports it, a conventional 24 otherwise.
+func terminalRows() int {
+	if v, err := strconv.Atoi(os.Getenv("LINES")); err == nil && v > 0 {
+		return v
+	}
+	return 24
+}
@@ -0,0 +1,130 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/metrics"
+	"github.com/cline/cline/cli/pkg/pipeline"
+	"github.com/cline/cline/cli/pkg/stats"
+)
+
+func pipelineCommand() *Command {
+	return &Command{
+		Name:    "pipeline",
+		Summary: "Run named pipelines of shell and model steps.",
+		Sub: []*Command{
+			{
+				Name:    "run",
+				Summary: "Execute a pipeline file step by step.",
+				Usage:   "cline pipeline run <pipeline.yaml>",
+				Run:     runPipelineRun,
+			},
+		},
+	}
+}
+
+func runPipelineRun(app *App, args []string) error {
+	fs := flag.NewFlagSet("pipeline run", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline pipeline run <pipeline.yaml>")
+	}
+	data, err := os.ReadFile(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	p, err := pipeline.Parse(data)
+	if err != nil {
+		return fmt.Errorf("%s: %w", fs.Arg(0), err)
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	if app.dryRun {
+		for _, step := range p.Steps {
+			if step.Run != "" {
+				app.dryRunSkip(fmt.Sprintf("step %s: sh -c %q", step.Name, step.Run))
+			} else {
+				app.dryRunSkip(fmt.Sprintf("step %s: prompt the model (%d chars)", step.Name, len(step.Prompt)))
+			}
+		}
+		return nil
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	outputs := map[string]string{}
+	for i, step := range p.Steps {
+		fmt.Fprintf(app.Stderr, "[%d/%d] %s\n", i+1, len(p.Steps), step.Name)
+		var output string
+		if step.Run != "" {
+			command, err := pipeline.Expand(step.Run, outputs)
+			if err != nil {
+				return fmt.Errorf("step %s: %w", step.Name, err)
+			}
+			var stdout bytes.Buffer
+			cmd := exec.CommandContext(context.Background(), "sh", "-c", command)
+			cmd.Stdout = &stdout
+			cmd.Stderr = app.Stderr
+			if err := cmd.Run(); err != nil {
+				return fmt.Errorf("step %s: %w", step.Name, err)
+			}
+			output = stdout.String()
+		} else {
+			prompt, err := pipeline.Expand(step.Prompt, outputs)
+			if err != nil {
+				return fmt.Errorf("step %s: %w", step.Name, err)
+			}
+			target := compareTarget{providerID: cfg.DefaultProvider}
+			if step.Model != "" {
+				target, err = resolveCompareRef(cfg, step.Model)
+				if err != nil {
+					return fmt.Errorf("step %s: %w", step.Name, err)
+				}
+			} else if target.providerID == "" {
+				return fmt.Errorf("step %s: no model given and no default provider is configured", step.Name)
+			}
+			def, err := registry.Provider(target.providerID)
+			if err != nil {
+				return fmt.Errorf("step %s: %w", step.Name, err)
+			}
+			baseEntry, ok := cfg.Providers[target.providerID]
+			if !ok {
+				return fmt.Errorf("step %s: provider %q is not configured", step.Name, target.providerID)
+			}
+			entry := *baseEntry
+			if target.modelID != "" {
+				entry.ModelID = target.modelID
+			}
+			start := time.Now()
+			reply, usage, err := llm.CompleteWithRetry(context.Background(), def, &entry, llm.Prompt{Text: prompt})
+			seconds := time.Since(start).Seconds()
+			metrics.RecordProviderRequest(target.providerID, seconds, usage.InputTokens, usage.OutputTokens, usage.CostUSD, err != nil)
+			stats.Record(target.providerID, seconds, err != nil)
+			if err != nil {
+				return fmt.Errorf("step %s: %w", step.Name, err)
+			}
+			output = reply
+		}
+		outputs[step.Name] = strings.TrimRight(output, "\n")
+	}
+	final := p.Steps[len(p.Steps)-1]
+	fmt.Fprintln(app.Stdout, outputs[final.Name])
+	return nil
+}
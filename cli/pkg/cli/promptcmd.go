@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/cline/cline/cli/pkg/prompts"
+)
+
+func promptCommand() *Command {
+	return &Command{
+		Name:    "prompt",
+		Summary: "Run shared prompt templates.",
+		Sub: []*Command{
+			{
+				Name:    "list",
+				Summary: "List saved prompt templates.",
+				Usage:   "cline prompt list",
+				Run:     runPromptList,
+			},
+			{
+				Name:    "show",
+				Summary: "Print a template's pinned model and body.",
+				Usage:   "cline prompt show <template>",
+				Run:     runPromptShow,
+			},
+			{
+				Name:    "run",
+				Summary: "Render a template and send it to a provider.",
+				Usage:   "cline prompt run <template> [--var name=value]... [--provider <id>] [--model <id>]",
+				Run:     runPromptRun,
+			},
+		},
+	}
+}
+
+func runPromptList(app *App, args []string) error {
+	names, err := prompts.List()
+	if err != nil {
+		return err
+	}
+	if len(names) == 0 {
+		dir, _ := prompts.Dir()
+		fmt.Fprintf(app.Stdout, "No prompt templates; add *.tmpl files under %s.\n", dir)
+		return nil
+	}
+	for _, name := range names {
+		fmt.Fprintln(app.Stdout, name)
+	}
+	return nil
+}
+
+func runPromptShow(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline prompt show <template>")
+	}
+	tmpl, err := prompts.Load(args[0])
+	if err != nil {
+		return err
+	}
+	if tmpl.Provider != "" {
+		fmt.Fprintf(app.Stdout, "provider: %s\n", tmpl.Provider)
+	}
+	if tmpl.ModelID != "" {
+		fmt.Fprintf(app.Stdout, "model: %s\n", tmpl.ModelID)
+	}
+	fmt.Fprintln(app.Stdout, tmpl.Body)
+	return nil
+}
+
+func runPromptRun(app *App, args []string) error {
+	fs := flag.NewFlagSet("prompt run", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID (default: the template's pin, then the configured default)")
+	model := fs.String("model", "", "model ID (default: the template's pin)")
+	instructionsName := fs.String("instructions", "", "instruction profile to use (default: workspace default, if set)")
+	var vars stringList
+	fs.Var(&vars, "var", "template variable as name=value (repeatable)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline prompt run <template> [--var name=value]...")
+	}
+	tmpl, err := prompts.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	data, err := prompts.ParseVars(vars)
+	if err != nil {
+		return err
+	}
+	text, err := tmpl.Render(data)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		return fmt.Errorf("template %s rendered to an empty prompt", tmpl.Name)
+	}
+
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	system, err := resolveInstructions(cfg, *instructionsName)
+	if err != nil {
+		return err
+	}
+	providerID := *provider
+	if providerID == "" {
+		providerID = tmpl.Provider
+	}
+	modelID := *model
+	if modelID == "" {
+		modelID = tmpl.ModelID
+	}
+	_, err = app.sendPrompt(cfg, askRequest{
+		providerID: providerID,
+		modelID:    modelID,
+		system:     system,
+		text:       text,
+	})
+	return err
+}
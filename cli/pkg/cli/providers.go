@@ -0,0 +1,208 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/stats"
+	"github.com/cline/cline/cli/pkg/wizard"
+	// Imported for its side effect of registering dynamic model fetchers,
+	// which Verify checks for.
+	_ "github.com/cline/cline/cli/pkg/models"
+)
+
+func providersCommand() *Command {
+	return &Command{
+		Name:    "providers",
+		Summary: "Inspect and test API providers.",
+		Sub: []*Command{
+			{
+				Name:    "list",
+				Summary: "List all known providers.",
+				Usage:   "cline providers list",
+				Run:     runProvidersList,
+			},
+			{
+				Name:    "show",
+				Summary: "Show one provider's details, including its data-retention policy.",
+				Usage:   "cline providers show <id>",
+				Run:     runProvidersShow,
+			},
+			{
+				Name:    "test",
+				Summary: "Test connectivity of configured providers.",
+				Usage:   "cline providers test [--level config|auth|completion]",
+				Run:     runProvidersTest,
+			},
+			{
+				Name:    "stats",
+				Summary: "Show latency percentiles and error rates per provider.",
+				Usage:   "cline providers stats [--days <n>]",
+				Run:     runProvidersStats,
+			},
+			{
+				Name:    "set-header",
+				Summary: "Set a custom HTTP header sent with every request to a provider.",
+				Usage:   "cline providers set-header <id> <name> [<value>]",
+				Run:     runProvidersSetHeader,
+			},
+			providersExportCommand(),
+			{
+				Name:    "verify",
+				Summary: "Cross-check the generated provider definitions for drift.",
+				Usage:   "cline providers verify",
+				Run:     runProvidersVerify,
+			},
+		},
+	}
+}
+
+func runProvidersList(app *App, args []string) error {
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	for _, id := range registry.ProviderIDs() {
+		def, err := registry.Provider(id)
+		if err != nil {
+			return err
+		}
+		models := fmt.Sprintf("%d models", len(def.Models))
+		if def.DynamicModels {
+			models = "dynamic models"
+		}
+		fmt.Fprintf(out, "%-14s %-24s %s\n", id, def.Name, models)
+	}
+	return nil
+}
+
+func runProvidersShow(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline providers show <id>")
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	def, err := registry.Provider(args[0])
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Provider:       %s (%s)\n", def.Name, def.ID)
+	if def.BaseURL != "" {
+		fmt.Fprintf(app.Stdout, "Endpoint:       %s\n", def.BaseURL)
+	}
+	if def.DefaultModelID != "" {
+		fmt.Fprintf(app.Stdout, "Default model:  %s\n", def.DefaultModelID)
+	}
+	if def.DynamicModels {
+		fmt.Fprintf(app.Stdout, "Models:         fetched dynamically\n")
+	} else {
+		fmt.Fprintf(app.Stdout, "Models:         %d in the static catalog\n", len(def.Models))
+	}
+	if def.DataRetention != "" {
+		fmt.Fprintf(app.Stdout, "Data retention: %s\n", def.DataRetention)
+	}
+	if pol, err := app.Policy(); err == nil && pol != nil && pol.RequireRetentionAck {
+		cfg, err := app.loadConfig()
+		if err != nil {
+			return err
+		}
+		state := "not acknowledged"
+		if cfg.Settings[config.RetentionAckSetting(def.ID)] == "true" {
+			state = "acknowledged"
+		}
+		fmt.Fprintf(app.Stdout, "Retention ack:  %s (required by %s)\n", state, pol.Path)
+	}
+	return nil
+}
+
+// runProvidersSetHeader stores a custom header on a configured provider;
+// omitting the value removes the header.
+func runProvidersSetHeader(app *App, args []string) error {
+	if len(args) != 2 && len(args) != 3 {
+		return fmt.Errorf("usage: cline providers set-header <id> <name> [<value>]")
+	}
+	id, name := args[0], args[1]
+	value := ""
+	if len(args) == 3 {
+		value = args[2]
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	return manager.Update(func(cfg *config.ClineConfig) error {
+		entry, ok := cfg.Providers[id]
+		if !ok {
+			return fmt.Errorf("provider %q is not configured", id)
+		}
+		entry.SetHeader(name, value)
+		return nil
+	})
+}
+
+func runProvidersVerify(app *App, args []string) error {
+	issues, err := generated.Verify()
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		fmt.Fprintln(app.Stdout, "Definitions verified: no issues found.")
+		return nil
+	}
+	for _, issue := range issues {
+		fmt.Fprintln(app.Stdout, issue)
+	}
+	return fmt.Errorf("%d definition issue(s) found", len(issues))
+}
+
+func runProvidersStats(app *App, args []string) error {
+	fs := flag.NewFlagSet("providers stats", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	days := fs.Int("days", 7, "how many days of samples to include")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days < 1 {
+		return fmt.Errorf("--days must be at least 1")
+	}
+	samples, err := stats.Load(time.Now().Add(-time.Duration(*days) * 24 * time.Hour))
+	if err != nil {
+		return err
+	}
+	if len(samples) == 0 {
+		fmt.Fprintf(app.Stdout, "No samples recorded in the last %d day(s).\n", *days)
+		return nil
+	}
+	fmt.Fprintf(app.Stdout, "%-14s %8s %8s %8s %7s\n", "PROVIDER", "REQUESTS", "P50", "P95", "ERRORS")
+	for _, s := range stats.Summarize(samples) {
+		fmt.Fprintf(app.Stdout, "%-14s %8d %7.2fs %7.2fs %6.1f%%\n",
+			s.Provider, s.Count, s.P50, s.P95, s.ErrorRate*100)
+	}
+	return nil
+}
+
+func runProvidersTest(app *App, args []string) error {
+	fs := flag.NewFlagSet("providers test", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	levelName := fs.String("level", "auth", "test depth: config (fields only), auth (metadata call) or completion (1-token request)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	level, err := wizard.ParseTestLevel(*levelName)
+	if err != nil {
+		return err
+	}
+	w, err := app.Wizard()
+	if err != nil {
+		return err
+	}
+	return w.TestProviders(context.Background(), level)
+}
@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strconv"
+
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func providersExportCommand() *Command {
+	return &Command{
+		Name:    "export",
+		Summary: "Dump the provider/model catalog for docs and wikis.",
+		Usage:   "cline providers export --format json|csv|markdown [--configured-only]",
+		Run:     runProvidersExport,
+	}
+}
+
+func runProvidersExport(app *App, args []string) error {
+	fs := flag.NewFlagSet("providers export", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	format := fs.String("format", "json", "output format (json, csv, markdown)")
+	configuredOnly := fs.Bool("configured-only", false, "only include providers present in the config")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	var configured map[string]bool
+	if *configuredOnly {
+		manager, err := app.ConfigManager()
+		if err != nil {
+			return err
+		}
+		cfg, err := manager.Config()
+		if err != nil {
+			return err
+		}
+		configured = make(map[string]bool, len(cfg.Providers))
+		for id := range cfg.Providers {
+			configured[id] = true
+		}
+	}
+	var defs []*generated.ProviderDefinition
+	for _, id := range registry.ProviderIDs() {
+		if configured != nil && !configured[id] {
+			continue
+		}
+		def, err := registry.Provider(id)
+		if err != nil {
+			return err
+		}
+		defs = append(defs, def)
+	}
+	switch *format {
+	case "json":
+		data, err := json.MarshalIndent(map[string]any{"providers": defs}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(app.Stdout, string(data))
+	case "csv":
+		w := csv.NewWriter(app.Stdout)
+		w.Write([]string{"provider", "model", "context_window", "max_tokens", "input_price", "output_price", "supports_images", "supports_prompt_cache"})
+		for _, def := range defs {
+			if len(def.Models) == 0 {
+				w.Write([]string{def.ID, "", "", "", "", "", "", ""})
+				continue
+			}
+			for _, m := range def.Models {
+				w.Write([]string{
+					def.ID, m.ID,
+					strconv.Itoa(m.ContextWindow), strconv.Itoa(m.MaxTokens),
+					strconv.FormatFloat(m.InputPrice, 'g', -1, 64), strconv.FormatFloat(m.OutputPrice, 'g', -1, 64),
+					strconv.FormatBool(m.SupportsImages), strconv.FormatBool(m.SupportsPromptCache),
+				})
+			}
+		}
+		w.Flush()
+		return w.Error()
+	case "markdown":
+		for _, def := range defs {
+			fmt.Fprintf(app.Stdout, "## %s (`%s`)\n\n", def.Name, def.ID)
+			if def.DynamicModels {
+				fmt.Fprintf(app.Stdout, "Models are fetched at runtime.\n\n")
+				continue
+			}
+			fmt.Fprintln(app.Stdout, "| Model | Context | Max output | $/Mtok in | $/Mtok out | Images | Prompt cache |")
+			fmt.Fprintln(app.Stdout, "|---|---|---|---|---|---|---|")
+			for _, m := range def.Models {
+				fmt.Fprintf(app.Stdout, "| `%s` | %d | %d | %.2f | %.2f | %s | %s |\n",
+					m.ID, m.ContextWindow, m.MaxTokens, m.InputPrice, m.OutputPrice,
+					yesNo(m.SupportsImages), yesNo(m.SupportsPromptCache))
+			}
+			fmt.Fprintln(app.Stdout)
+		}
+	default:
+		return fmt.Errorf("unsupported format %q (json, csv, markdown)", *format)
+	}
+	return nil
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
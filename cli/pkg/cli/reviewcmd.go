@@ -0,0 +1,159 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/guardrails"
+	"github.com/cline/cline/cli/pkg/review"
+)
+
+// reviewChunkBudget caps one chunk's diff so prompt plus reply fit
+// comfortably in every supported model's context window.
+const reviewChunkBudget = 24000
+
+const reviewPromptTemplate = `Review the following diff. Report concrete problems:
+bugs, races, security issues, misleading names, missing error handling.
+Skip style opinions the diff's own surroundings contradict.
+
+Respond with ONLY a JSON array in this shape:
+%s
+
+Use an empty array if the chunk looks fine.
+
+%s`
+
+func reviewCommand() *Command {
+	return &Command{
+		Name:    "review",
+		Summary: "Review a diff and emit structured comments.",
+		Usage:   "cline review [--range <rev-range>] [--pr <url>] [--format markdown|github]",
+		Run:     runReview,
+	}
+}
+
+func runReview(app *App, args []string) error {
+	fs := flag.NewFlagSet("review", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	revRange := fs.String("range", "main..HEAD", "git revision range to review")
+	prURL := fs.String("pr", "", "pull request URL to review (requires the gh CLI)")
+	format := fs.String("format", "markdown", "output format (markdown, github)")
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	model := fs.String("model", "", "model ID override")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "markdown" && *format != "github" {
+		return fmt.Errorf("unknown format %q (want markdown or github)", *format)
+	}
+	diff, err := reviewDiff(*revRange, *prURL)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(diff) == "" {
+		fmt.Fprintln(app.Stdout, "Nothing to review.")
+		return nil
+	}
+
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+
+	chunks := review.SplitDiff(diff, reviewChunkBudget)
+	estimateReviewCost(app, cfg, *provider, *model, chunks)
+
+	var comments []review.Comment
+	for i, chunk := range chunks {
+		fmt.Fprintf(app.Stderr, "reviewing chunk %d/%d (%s)...\n", i+1, len(chunks), strings.Join(chunk.Files, ", "))
+		prompt := fmt.Sprintf(reviewPromptTemplate, review.Schema, chunk.Diff)
+		reply, err := app.sendPrompt(cfg, askRequest{
+			providerID: *provider,
+			modelID:    *model,
+			text:       prompt,
+			quiet:      true,
+		})
+		if err != nil {
+			return err
+		}
+		parsed, err := review.ParseComments(reply)
+		if err != nil {
+			fmt.Fprintf(app.Stderr, "warning: chunk %d: %v\n", i+1, err)
+			continue
+		}
+		comments = append(comments, parsed...)
+	}
+
+	if *format == "github" {
+		payload, err := review.RenderGitHub(comments)
+		if err != nil {
+			return err
+		}
+		app.Stdout.Write(append(payload, '\n'))
+		return nil
+	}
+	fmt.Fprint(app.Stdout, review.RenderMarkdown(comments))
+	return nil
+}
+
+// reviewDiff collects the diff from either a revision range or a PR URL.
+func reviewDiff(revRange, prURL string) (string, error) {
+	if prURL != "" {
+		out, err := exec.Command("gh", "pr", "diff", prURL).Output()
+		if err != nil {
+			return "", fmt.Errorf("gh pr diff %s: %w (is the gh CLI installed and authenticated?)", prURL, err)
+		}
+		return string(out), nil
+	}
+	out, err := exec.Command("git", "diff", revRange).Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok && len(ee.Stderr) > 0 {
+			return "", fmt.Errorf("git diff %s: %s", revRange, strings.TrimSpace(string(ee.Stderr)))
+		}
+		return "", fmt.Errorf("git diff %s: %w", revRange, err)
+	}
+	return string(out), nil
+}
+
+// estimateReviewCost prints the per-chunk token and cost estimate before any
+// request is sent, so expensive reviews can be aborted early.
+func estimateReviewCost(app *App, cfg *config.ClineConfig, providerID, modelID string, chunks []review.Chunk) {
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	entry := cfg.Providers[providerID]
+	registry, err := app.Registry()
+	if err != nil || entry == nil {
+		return
+	}
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return
+	}
+	if modelID == "" {
+		modelID = entry.ModelID
+	}
+	var inPrice, outPrice float64
+	for _, m := range def.Models {
+		if m.ID == modelID {
+			inPrice, outPrice = m.InputPrice, m.OutputPrice
+			break
+		}
+	}
+	// Assume a few hundred tokens of comments per chunk coming back.
+	const estimatedReplyTokens = 400
+	total := 0.0
+	for i, chunk := range chunks {
+		tokens := chunk.Tokens() + guardrails.EstimateTokens(reviewPromptTemplate)
+		cost := float64(tokens)*inPrice/1_000_000 + estimatedReplyTokens*outPrice/1_000_000
+		total += cost
+		fmt.Fprintf(app.Stderr, "chunk %d/%d: ~%d tokens, ~%s\n", i+1, len(chunks), tokens, app.Costs().Format(cost, 4))
+	}
+	fmt.Fprintf(app.Stderr, "estimated total: ~%s across %d chunk(s)\n", app.Costs().Format(total, 4), len(chunks))
+}
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/cloudsync"
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// sessionCheckInterval bounds how often the revocation probe hits the
+// network; between probes commands run at full speed.
+const sessionCheckInterval = time.Hour
+
+// sessionCheckNow is swappable so the interval logic is testable.
+var sessionCheckNow = time.Now
+
+// checkSessionRevocation enforces offboarding in managed mode: when the
+// policy asks for it and sync is configured, the account session is probed at
+// most once per hour; a revoked session wipes locally stored provider keys.
+// Offline machines and service hiccups gate nothing.
+func (a *App) checkSessionRevocation(cfg *config.ClineConfig) error {
+	pol, err := a.Policy()
+	if err != nil || pol == nil || !pol.WipeKeysOnRevoke {
+		return nil
+	}
+	endpoint := cfg.Settings[cloudsync.SettingEndpoint]
+	token := cfg.Settings[cloudsync.SettingToken]
+	if endpoint == "" || token == "" {
+		return nil
+	}
+	if !sessionCheckDue() {
+		return nil
+	}
+	valid, err := cloudsync.SessionValid(context.Background(), endpoint, token)
+	if err != nil {
+		return nil
+	}
+	recordSessionCheck()
+	if valid {
+		return nil
+	}
+	if err := a.wipeProviderKeys(); err != nil {
+		return fmt.Errorf("account session was revoked but wiping credentials failed: %w", err)
+	}
+	return fmt.Errorf("the account session was revoked; locally stored provider keys have been removed per %s", pol.Path)
+}
+
+// sessionCheckPath is where the last successful probe time lives.
+func sessionCheckPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "session-check"), nil
+}
+
+func sessionCheckDue() bool {
+	path, err := sessionCheckPath()
+	if err != nil {
+		return false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return true
+	}
+	last, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return true
+	}
+	return sessionCheckNow().Sub(time.Unix(last, 0)) >= sessionCheckInterval
+}
+
+// recordSessionCheck is best-effort; a failed write just means the next
+// command probes again.
+func recordSessionCheck() {
+	path, err := sessionCheckPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	os.WriteFile(path, []byte(strconv.FormatInt(sessionCheckNow().Unix(), 10)), 0o600)
+}
+
+// wipeProviderKeys removes every credential-valued field from the config:
+// API keys, secret-valued headers, the sync token, and credential-looking
+// ExtraConfig values.
+func (a *App) wipeProviderKeys() error {
+	manager, err := a.ConfigManager()
+	if err != nil {
+		return err
+	}
+	return manager.Update(func(cfg *config.ClineConfig) error {
+		for _, entry := range cfg.Providers {
+			entry.APIKey = ""
+			for name := range entry.Headers {
+				if config.LooksLikeSecretName(name) || strings.EqualFold(name, "authorization") {
+					entry.SetHeader(name, "")
+				}
+			}
+			for name := range entry.ExtraConfig {
+				if config.LooksLikeSecretName(name) {
+					delete(entry.ExtraConfig, name)
+				}
+			}
+		}
+		delete(cfg.Settings, cloudsync.SettingToken)
+		return nil
+	})
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/cline/cline/cli/pkg/policy"
+)
+
+// restrictedDenials maps command paths the restricted role may not run to the
+// reason shown in the denial. Admin and developer roles are unrestricted; the
+// restricted role is for shared or locked-down machines where only using the
+// already-configured providers is allowed.
+var restrictedDenials = map[string]string{
+	"setup":                "configures new providers",
+	"auth add":             "adds provider credentials",
+	"auth remove":          "removes provider credentials",
+	"config import":        "imports provider configuration",
+	"config share":         "exports provider credentials",
+	"config receive":       "imports provider configuration",
+	"config set":           "changes settings, including redaction rules",
+	"settings set":         "changes settings, including redaction rules",
+	"providers set-header": "changes what is sent to providers",
+	"trust add":            "changes workspace trust",
+	"trust remove":         "changes workspace trust",
+	"alias set":            "redefines what commands run",
+	"offline bundle apply": "replaces provider definitions and catalogs",
+}
+
+// checkCommandRole enforces the policy's role on a resolved command path
+// ("auth add"). A broken or absent policy gates nothing, matching how the
+// other policy checks degrade.
+func (a *App) checkCommandRole(path string) error {
+	pol, err := a.Policy()
+	if err != nil || pol == nil || !pol.Restricted() {
+		return nil
+	}
+	if reason, denied := restrictedDenials[path]; denied {
+		return fmt.Errorf("%s assigns the %s role, which cannot run `cline %s` (%s); ask an admin to make this change",
+			pol.Path, policy.RoleRestricted, path, reason)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cli
+
+func rootCommand() *Command {
+	return &Command{
+		Name:    "cline",
+		Usage:   "cline <command> [arguments]",
+		Summary: "Configure and use AI providers from the terminal.",
+		Sub: []*Command{
+			askCommand(),
+			compareCommand(),
+			evalCommand(),
+			batchCommand(),
+			pipelineCommand(),
+			promptCommand(),
+			gitCommand(),
+			reviewCommand(),
+			embedCommand(),
+			taskCommand(),
+			contextCommand(),
+			trustCommand(),
+			setupCommand(),
+			authCommand(),
+			configCommand(),
+			providersCommand(),
+			modelsCommand(),
+			instructionsCommand(),
+			settingsCommand(),
+			aliasCommand(),
+			historyCommand(),
+			usageCommand(),
+			offlineCommand(),
+			syncCommand(),
+			adminCommand(),
+			doctorCommand(),
+			supportCommand(),
+			hooksCommand(),
+			serveCommand(),
+			internalCommand(),
+		},
+	}
+}
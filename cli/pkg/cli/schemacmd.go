@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/approvals"
+	"github.com/cline/cline/cli/pkg/clierr"
+	"github.com/cline/cline/cli/pkg/cloudsync"
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/currency"
+	"github.com/cline/cline/cli/pkg/gitsync"
+	"github.com/cline/cline/cli/pkg/guardrails"
+	"github.com/cline/cline/cli/pkg/history"
+	"github.com/cline/cline/cli/pkg/notify"
+	"github.com/cline/cline/cli/pkg/policy"
+	"github.com/cline/cline/cli/pkg/respcache"
+	"github.com/cline/cline/cli/pkg/theme"
+)
+
+// schemaDocument is the shape `cline internal schema` emits. It describes the
+// CLI itself — commands, global flags, the config file, settings keys, and
+// exit codes — so generated wrappers and docs can stay in sync with the code
+// instead of hand-tracking releases.
+type schemaDocument struct {
+	Commands    *schemaCommand   `json:"commands"`
+	GlobalFlags []schemaFlag     `json:"globalFlags"`
+	Config      *schemaType      `json:"config"`
+	Settings    []schemaSetting  `json:"settings"`
+	ExitCodes   []schemaExitCode `json:"exitCodes"`
+}
+
+type schemaCommand struct {
+	Name    string           `json:"name"`
+	Summary string           `json:"summary,omitempty"`
+	Usage   string           `json:"usage,omitempty"`
+	Hidden  bool             `json:"hidden,omitempty"`
+	Sub     []*schemaCommand `json:"sub,omitempty"`
+}
+
+type schemaFlag struct {
+	Name    string `json:"name"`
+	Summary string `json:"summary"`
+}
+
+// schemaType describes one JSON value in the config file: a scalar, an
+// object with named fields, or a map with uniform values.
+type schemaType struct {
+	Type   string        `json:"type"`
+	Fields []schemaField `json:"fields,omitempty"`
+	Values *schemaType   `json:"values,omitempty"`
+	Items  *schemaType   `json:"items,omitempty"`
+}
+
+type schemaField struct {
+	Name     string      `json:"name"`
+	Optional bool        `json:"optional,omitempty"`
+	Schema   *schemaType `json:"schema"`
+}
+
+type schemaSetting struct {
+	Key     string `json:"key"`
+	Summary string `json:"summary"`
+}
+
+type schemaExitCode struct {
+	Code    int    `json:"code"`
+	Meaning string `json:"meaning"`
+}
+
+func runInternalSchema(app *App, args []string) error {
+	doc := schemaDocument{
+		Commands: commandSchema(rootCommand()),
+		GlobalFlags: []schemaFlag{
+			{Name: "--no-pager", Summary: "Write long output directly to stdout instead of a pager."},
+			{Name: "--dry-run", Summary: "Show what a command would change without writing config or calling external services."},
+			{Name: "--ephemeral", Summary: "Run with an in-memory config and throwaway state, writing nothing persistent."},
+		},
+		Config:   typeSchema(reflect.TypeOf(config.ClineConfig{})),
+		Settings: settingsSchema(),
+		ExitCodes: []schemaExitCode{
+			{Code: clierr.ExitOK, Meaning: "success"},
+			{Code: clierr.ExitGeneric, Meaning: "unclassified failure"},
+			{Code: clierr.ExitConfig, Meaning: "broken or unusable configuration"},
+			{Code: clierr.ExitAuth, Meaning: "rejected credential"},
+			{Code: clierr.ExitNetwork, Meaning: "transport failure"},
+			{Code: clierr.ExitProvider, Meaning: "provider API error"},
+			{Code: clierr.ExitAborted, Meaning: "user abort"},
+		},
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "%s\n", data)
+	return nil
+}
+
+func commandSchema(c *Command) *schemaCommand {
+	out := &schemaCommand{
+		Name:    c.Name,
+		Summary: c.Summary,
+		Usage:   c.Usage,
+		Hidden:  c.Hidden,
+	}
+	for _, sub := range c.Sub {
+		out.Sub = append(out.Sub, commandSchema(sub))
+	}
+	return out
+}
+
+// typeSchema maps a Go type onto the JSON it marshals to, following the same
+// struct tags encoding/json uses.
+func typeSchema(t reflect.Type) *schemaType {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return typeSchema(t.Elem())
+	case reflect.Bool:
+		return &schemaType{Type: "boolean"}
+	case reflect.String:
+		return &schemaType{Type: "string"}
+	case reflect.Int, reflect.Int64, reflect.Float64:
+		return &schemaType{Type: "number"}
+	case reflect.Slice:
+		return &schemaType{Type: "array", Items: typeSchema(t.Elem())}
+	case reflect.Map:
+		return &schemaType{Type: "map", Values: typeSchema(t.Elem())}
+	case reflect.Struct:
+		out := &schemaType{Type: "object"}
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if !f.IsExported() {
+				continue
+			}
+			tag := f.Tag.Get("json")
+			name, opts, _ := strings.Cut(tag, ",")
+			if name == "-" {
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			out.Fields = append(out.Fields, schemaField{
+				Name:     name,
+				Optional: strings.Contains(opts, "omitempty"),
+				Schema:   typeSchema(f.Type),
+			})
+		}
+		return out
+	default:
+		return &schemaType{Type: t.Kind().String()}
+	}
+}
+
+// settingsSchema lists the settings keys the CLI understands. Each key is a
+// constant next to the feature it configures; keep this list in step when
+// adding one.
+func settingsSchema() []schemaSetting {
+	return []schemaSetting{
+		{Key: theme.SettingTheme, Summary: "Output color theme."},
+		{Key: notify.SettingMode, Summary: "Desktop notification mode."},
+		{Key: notify.SettingWebhook, Summary: "Webhook URL for task notifications."},
+		{Key: notify.SettingFormat, Summary: "Webhook payload format."},
+		{Key: approvals.SettingAutoEdits, Summary: "Auto-approve edits inside the workspace."},
+		{Key: approvals.SettingAutoReadOnly, Summary: "Auto-approve read-only commands."},
+		{Key: approvals.SettingAllowlist, Summary: "Command patterns approved without asking."},
+		{Key: approvals.SettingDenylist, Summary: "Command patterns always denied."},
+		{Key: guardrails.SettingPriceWarnThreshold, Summary: "Estimated cost above which a warning is shown."},
+		{Key: currency.SettingCurrency, Summary: "Currency costs are displayed in (ISO 4217 code)."},
+		{Key: currency.SettingLocale, Summary: "Locale for cost number formatting."},
+		{Key: respcache.SettingEnabled, Summary: "Cache model replies for repeated identical prompts."},
+		{Key: respcache.SettingTTL, Summary: "How long cached replies are served."},
+		{Key: history.SettingRetention, Summary: "History retention: \"never\" or a maximum age like \"30d\"."},
+		{Key: policy.SettingPublicKey, Summary: "Public key policy files must be signed with."},
+		{Key: gitsync.SettingRemote, Summary: "Git remote for shared team config."},
+		{Key: cloudsync.SettingEndpoint, Summary: "Encrypted settings sync endpoint."},
+		{Key: cloudsync.SettingToken, Summary: "Bearer token for the sync endpoint."},
+	}
+}
@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/checks"
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// skipTLSExtraKeys are the spellings of "don't verify certificates" seen in
+// imported configs.
+var skipTLSExtraKeys = []string{"insecureSkipVerify", "skipTlsVerify", "skipTLSVerify", "insecure"}
+
+// securityLint checks the loaded config for insecure setups: world-readable
+// files, credentials parked in ExtraConfig, plain-http endpoints that leave
+// the machine, and disabled TLS verification. Each failure carries the
+// command that fixes it.
+func securityLint(cfg *config.ClineConfig, path string) []checks.Result {
+	var results []checks.Result
+	if runtime.GOOS != "windows" {
+		if info, err := os.Stat(path); err == nil && info.Mode().Perm()&0o077 != 0 {
+			results = append(results, checks.Result{
+				ID:          "security-file-permissions",
+				Description: "Config and key files are owner-only",
+				Detail:      fmt.Sprintf("%s is readable by other users; run `chmod 600 %s`", path, path),
+				Path:        path,
+			})
+		}
+		dir := filepath.Dir(path)
+		if info, err := os.Stat(dir); err == nil && info.Mode().Perm()&0o077 != 0 {
+			results = append(results, checks.Result{
+				ID:          "security-file-permissions",
+				Description: "Config and key files are owner-only",
+				Detail:      fmt.Sprintf("%s is accessible by other users; run `chmod 700 %s`", dir, dir),
+				Path:        dir,
+			})
+		}
+	}
+	for _, id := range sortedKeys(cfg.Providers) {
+		entry := cfg.Providers[id]
+		for name := range entry.ExtraConfig {
+			if looksLikeSecret(name) {
+				results = append(results, checks.Result{
+					ID:          "security-plaintext-extra-secret",
+					Description: "No credentials in ExtraConfig",
+					Detail: fmt.Sprintf("%s.%s looks like a credential stored outside the secret fields; run `cline auth add --provider %s` to store it properly",
+						id, name, id),
+					Path: path,
+				})
+			}
+			if isSkipTLSKey(name) && entry.ExtraConfig[name] == "true" {
+				results = append(results, checks.Result{
+					ID:          "security-tls-verification",
+					Description: "TLS verification is enabled",
+					Detail: fmt.Sprintf("%s.%s disables certificate checks; remove it with `cline config set %s %s \"\"`",
+						id, name, id, name),
+					Path: path,
+				})
+			}
+		}
+		if insecureEndpoint(entry.BaseURL) {
+			results = append(results, checks.Result{
+				ID:          "security-plaintext-endpoint",
+				Description: "Remote endpoints use https",
+				Detail: fmt.Sprintf("%s uses plain http to a non-local host (%s); run `cline config set %s baseUrl https://...`",
+					id, entry.BaseURL, id),
+				Path: path,
+			})
+		}
+	}
+	if len(results) == 0 {
+		results = append(results, checks.Result{
+			ID:          "security-lint",
+			Description: "No insecure configuration found",
+			OK:          true,
+		})
+	}
+	return results
+}
+
+func looksLikeSecret(name string) bool {
+	return config.LooksLikeSecretName(name)
+}
+
+func isSkipTLSKey(name string) bool {
+	for _, key := range skipTLSExtraKeys {
+		if name == key {
+			return true
+		}
+	}
+	return false
+}
+
+// insecureEndpoint reports whether a base URL talks plain http to a host that
+// is not this machine.
+func insecureEndpoint(baseURL string) bool {
+	if !strings.HasPrefix(baseURL, "http://") {
+		return false
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return true
+	}
+	host := u.Hostname()
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+	if ip := net.ParseIP(host); ip != nil && ip.IsLoopback() {
+		return false
+	}
+	return true
+}
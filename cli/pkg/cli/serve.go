@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/metrics"
+)
+
+func serveCommand() *Command {
+	return &Command{
+		Name:    "serve",
+		Summary: "Run the long-lived daemon mode.",
+		Usage:   "cline serve [--addr 127.0.0.1:7633] [--metrics]",
+		Run:     runServe,
+	}
+}
+
+func runServe(app *App, args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	addr := fs.String("addr", "127.0.0.1:7633", "listen address (localhost only by default)")
+	enableMetrics := fs.Bool("metrics", false, "expose Prometheus metrics on /metrics")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	service, err := config.NewService(manager)
+	if err != nil {
+		return err
+	}
+	defer service.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	if *enableMetrics {
+		mux.Handle("/metrics", metrics.Default.Handler())
+	}
+
+	ln, err := net.Listen("tcp", *addr)
+	if err != nil {
+		return err
+	}
+	srv := &http.Server{Handler: mux}
+	errCh := make(chan error, 1)
+	go func() { errCh <- srv.Serve(ln) }()
+	fmt.Fprintf(app.Stdout, "cline serve listening on %s", ln.Addr())
+	if *enableMetrics {
+		fmt.Fprintf(app.Stdout, " (metrics on /metrics)")
+	}
+	fmt.Fprintln(app.Stdout)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	select {
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	case err := <-errCh:
+		return err
+	}
+}
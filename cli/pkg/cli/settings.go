@@ -0,0 +1,177 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cline/cline/cli/pkg/clierr"
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/notify"
+	"github.com/cline/cline/cli/pkg/redact"
+)
+
+func settingsCommand() *Command {
+	return &Command{
+		Name:    "settings",
+		Summary: "Manage CLI settings.",
+		Sub: []*Command{
+			{
+				Name:    "set",
+				Summary: "Set a settings key.",
+				Usage:   "cline settings set <key> <value>",
+				Run:     runSettingsSet,
+			},
+			{
+				Name:    "get",
+				Summary: "Print a settings key.",
+				Usage:   "cline settings get <key>",
+				Run:     runSettingsGet,
+			},
+			{
+				Name:    "list",
+				Summary: "List all settings.",
+				Usage:   "cline settings list",
+				Run:     runSettingsList,
+			},
+			{
+				Name:    "test-webhook",
+				Summary: "Send a test notification to the configured webhook.",
+				Usage:   "cline settings test-webhook",
+				Run:     runSettingsTestWebhook,
+			},
+			approvalsCommand(),
+			{
+				Name:    "redaction",
+				Summary: "Manage content redaction rules.",
+				Sub: []*Command{
+					{
+						Name:    "test",
+						Summary: "Preview which parts of a file the redaction rules would strip.",
+						Usage:   "cline settings redaction test <file>",
+						Run:     runRedactionTest,
+					},
+				},
+			},
+		},
+	}
+}
+
+func runRedactionTest(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline settings redaction test <file>")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	redactor, err := redact.FromSettings(cfg.Settings)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	_, matches := redactor.Apply(string(data))
+	if len(matches) == 0 {
+		fmt.Fprintln(app.Stdout, "No redaction rules matched.")
+		return nil
+	}
+	for _, m := range matches {
+		fmt.Fprintf(app.Stdout, "%s:%d %-20s %s\n", args[0], m.Line, m.Rule, m.Text)
+	}
+	fmt.Fprintf(app.Stdout, "%d span(s) would be redacted before sending.\n", len(matches))
+	return nil
+}
+
+func runSettingsSet(app *App, args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: cline settings set <key> <value>")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	return manager.Update(func(cfg *config.ClineConfig) error {
+		if cfg.Settings == nil {
+			cfg.Settings = make(map[string]string)
+		}
+		cfg.Settings[args[0]] = args[1]
+		return nil
+	})
+}
+
+func runSettingsGet(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline settings get <key>")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	value, ok := cfg.Settings[args[0]]
+	if !ok {
+		return fmt.Errorf("setting %q is not set", args[0])
+	}
+	fmt.Fprintln(app.Stdout, value)
+	return nil
+}
+
+func runSettingsList(app *App, args []string) error {
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	for _, key := range sortedKeys(cfg.Settings) {
+		fmt.Fprintf(app.Stdout, "%s=%s\n", key, cfg.Settings[key])
+	}
+	return nil
+}
+
+func runSettingsTestWebhook(app *App, args []string) error {
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	notifier := notify.FromSettings(cfg.Settings)
+	if notifier == nil {
+		return fmt.Errorf("no webhook configured; run `cline settings set %s <url>`", notify.SettingWebhook)
+	}
+	err = notifier.Send(context.Background(), notify.Event{
+		Type:    notify.TaskCompleted,
+		Message: "test notification from cline settings test-webhook",
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Delivered test notification to %s.\n", notifier.URL)
+	return nil
+}
+
+// loadConfig is a shorthand for manager construction plus load. Repository
+// policy defaults (committed .cline/policy.yaml) override the user's default
+// provider when running inside a repo that pins one.
+func (a *App) loadConfig() (*config.ClineConfig, error) {
+	manager, err := a.ConfigManager()
+	if err != nil {
+		return nil, err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return nil, clierr.Config(err)
+	}
+	if pol, err := a.Policy(); err == nil && pol != nil {
+		if pol.DefaultProvider != "" {
+			cfg.DefaultProvider = pol.DefaultProvider
+		}
+		if pol.DefaultModel != "" && cfg.DefaultProvider != "" {
+			cfg.Provider(cfg.DefaultProvider).ModelID = pol.DefaultModel
+		}
+	}
+	if err := a.checkSessionRevocation(cfg); err != nil {
+		return nil, err
+	}
+	a.showPendingNotices()
+	return cfg, nil
+}
@@ -0,0 +1,42 @@
+package cli
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/cline/cline/cli/pkg/wizard"
+)
+
+func setupCommand() *Command {
+	return &Command{
+		Name:    "setup",
+		Summary: "Run the provider setup wizard.",
+		Usage:   "cline setup [--provider <id>]",
+		Run:     runSetup,
+	}
+}
+
+func runSetup(app *App, args []string) error {
+	fs := flag.NewFlagSet("setup", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "configure a single provider and exit")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	w, err := app.Wizard()
+	if err != nil {
+		return err
+	}
+	if *provider != "" {
+		return w.FastSetup(*provider)
+	}
+	// Without --provider, look for local model servers first: if one is
+	// running, setup is a single keystroke.
+	fmt.Fprintln(app.Stderr, "scanning for local model servers...")
+	found := wizard.DiscoverLocalServers(context.Background())
+	if len(found) == 0 {
+		return fmt.Errorf("no local model servers found; run `cline setup --provider <id>` (see `cline providers list` for IDs)")
+	}
+	return w.OfferDiscovered(found)
+}
@@ -0,0 +1,212 @@
+package cli
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/sharebox"
+)
+
+// shareDocument is the payload `cline config share` seals: the selected
+// provider entries, keys and all unless --no-keys stripped them.
+type shareDocument struct {
+	Providers map[string]*config.ProviderConfig `json:"providers"`
+}
+
+// shareIdentityPath is where the receive identity's private key lives,
+// owner-only like API keys.
+func shareIdentityPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "share.key"), nil
+}
+
+// loadOrCreateShareIdentity returns the public key, generating and storing a
+// key pair on first use.
+func loadOrCreateShareIdentity() (publicKey string, err error) {
+	path, err := shareIdentityPath()
+	if err != nil {
+		return "", err
+	}
+	if data, err := os.ReadFile(path); err == nil {
+		priv := strings.TrimSpace(string(data))
+		return sharePublicKey(priv)
+	}
+	pub, priv, err := sharebox.GenerateIdentity()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(priv+"\n"), 0o600); err != nil {
+		return "", err
+	}
+	return pub, nil
+}
+
+// sharePublicKey re-derives the public key from a stored private key.
+func sharePublicKey(privateKey string) (string, error) {
+	pub, err := sharebox.PublicKeyOf(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("stored share key is corrupted; remove it to generate a new one: %w", err)
+	}
+	return pub, nil
+}
+
+func runConfigShareKey(app *App, args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: cline config share-key")
+	}
+	pub, err := loadOrCreateShareIdentity()
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "%s\n", pub)
+	fmt.Fprintln(app.Stderr, "Give this public key to teammates; they share a setup with `cline config share --to <key>`.")
+	return nil
+}
+
+func runConfigShare(app *App, args []string) error {
+	fs := flag.NewFlagSet("config share", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	to := fs.String("to", "", "recipient's public key (from their `cline config share-key`)")
+	providers := fs.String("providers", "", "comma-separated provider IDs to share (default: all configured)")
+	noKeys := fs.Bool("no-keys", false, "strip API keys and secret headers from the shared entries")
+	out := fs.String("out", "", "write the blob to a file instead of stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *to == "" {
+		return fmt.Errorf("--to <public-key> is required")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	doc := shareDocument{Providers: map[string]*config.ProviderConfig{}}
+	wanted := map[string]bool{}
+	for _, id := range strings.Split(*providers, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			wanted[id] = true
+		}
+	}
+	for id, entry := range cfg.Providers {
+		if len(wanted) > 0 && !wanted[id] {
+			continue
+		}
+		shared := *entry
+		if *noKeys {
+			shared.APIKey = ""
+			shared.Headers = nil
+			for name, value := range entry.Headers {
+				if !config.LooksLikeSecretName(name) && !strings.EqualFold(name, "authorization") {
+					shared.SetHeader(name, value)
+				}
+			}
+		}
+		doc.Providers[id] = &shared
+	}
+	for id := range wanted {
+		if _, ok := doc.Providers[id]; !ok {
+			return fmt.Errorf("provider %q is not configured", id)
+		}
+	}
+	if len(doc.Providers) == 0 {
+		return fmt.Errorf("nothing to share; no providers configured")
+	}
+	plaintext, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	blob, err := sharebox.Seal(*to, plaintext)
+	if err != nil {
+		return err
+	}
+	encoded := base64.StdEncoding.EncodeToString(blob)
+	if *out != "" {
+		if err := os.WriteFile(*out, []byte(encoded+"\n"), 0o600); err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Stdout, "Wrote %d provider(s) sealed for the recipient to %s.\n", len(doc.Providers), *out)
+		return nil
+	}
+	fmt.Fprintf(app.Stdout, "%s\n", encoded)
+	return nil
+}
+
+func runConfigReceive(app *App, args []string) error {
+	fs := flag.NewFlagSet("config receive", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	var encoded []byte
+	var err error
+	switch len(fs.Args()) {
+	case 0:
+		encoded, err = io.ReadAll(app.Stdin)
+	case 1:
+		encoded, err = os.ReadFile(fs.Args()[0])
+	default:
+		return fmt.Errorf("usage: cline config receive [<file>] (or pipe the blob on stdin)")
+	}
+	if err != nil {
+		return err
+	}
+	blob, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(encoded)))
+	if err != nil {
+		return fmt.Errorf("blob is not valid base64: %w", err)
+	}
+	path, err := shareIdentityPath()
+	if err != nil {
+		return err
+	}
+	keyData, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("no share key found; run `cline config share-key` first and give the sender your public key")
+	}
+	plaintext, err := sharebox.Open(strings.TrimSpace(string(keyData)), blob)
+	if err != nil {
+		return err
+	}
+	var doc shareDocument
+	if err := json.Unmarshal(plaintext, &doc); err != nil {
+		return fmt.Errorf("decoding shared config: %w", err)
+	}
+	if len(doc.Providers) == 0 {
+		return fmt.Errorf("blob contains no providers")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		for id, entry := range doc.Providers {
+			// An entry shared without a key must not clobber a key we
+			// already have for that provider.
+			if existing, ok := cfg.Providers[id]; ok && entry.APIKey == "" {
+				entry.APIKey = existing.APIKey
+			}
+			cfg.Provider(id)
+			cfg.Providers[id] = entry
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	for _, id := range sortedKeys(doc.Providers) {
+		fmt.Fprintf(app.Stdout, "Imported provider %s.\n", id)
+	}
+	return nil
+}
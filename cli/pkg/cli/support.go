@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/task"
+)
+
+func supportCommand() *Command {
+	return &Command{
+		Name:    "support",
+		Summary: "Gather diagnostics for bug reports.",
+		Sub: []*Command{
+			{
+				Name:    "snapshot",
+				Summary: "Write a redacted diagnostics tarball for a bug report.",
+				Usage:   "cline support snapshot [--output <file.tar.gz>]",
+				Run:     runSupportSnapshot,
+			},
+		},
+	}
+}
+
+// runSupportSnapshot bundles doctor output, the config with secrets hashed,
+// provider stats and recent task metadata into one tarball, so maintainers
+// can reproduce an issue without asking for each piece separately. Nothing in
+// the archive contains prompt content or usable credentials.
+func runSupportSnapshot(app *App, args []string) error {
+	fs := flag.NewFlagSet("support snapshot", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	output := fs.String("output", "", "tarball path (default: cline-support-<timestamp>.tar.gz)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	path := *output
+	if path == "" {
+		path = fmt.Sprintf("cline-support-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+	}
+
+	var files []snapshotFile
+	files = append(files, snapshotFile{"instance.txt", instanceInfo(app)})
+	files = append(files, snapshotFile{"doctor.txt", doctorReport(app)})
+	if data := sanitizedConfig(app); data != nil {
+		files = append(files, snapshotFile{"config.json", data})
+	}
+	if data := statsFile(); data != nil {
+		files = append(files, snapshotFile{"stats.jsonl", data})
+	}
+	files = append(files, snapshotFile{"tasks.txt", recentTaskLog()})
+
+	if err := writeTarball(path, files); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Wrote %s (%d file(s)). Secrets are hashed; prompt content is not included.\n", path, len(files))
+	return nil
+}
+
+type snapshotFile struct {
+	name string
+	data []byte
+}
+
+func writeTarball(path string, files []snapshotFile) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	now := time.Now()
+	for _, file := range files {
+		err := tw.WriteHeader(&tar.Header{
+			Name:    "cline-support/" + file.name,
+			Mode:    0o600,
+			Size:    int64(len(file.data)),
+			ModTime: now,
+		})
+		if err != nil {
+			return err
+		}
+		if _, err := tw.Write(file.data); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// instanceInfo records the environment a report came from.
+func instanceInfo(app *App) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "time: %s\n", time.Now().UTC().Format(time.RFC3339))
+	fmt.Fprintf(&b, "os: %s\narch: %s\ngo: %s\n", runtime.GOOS, runtime.GOARCH, runtime.Version())
+	if dir, err := config.GetConfigDir(); err == nil {
+		fmt.Fprintf(&b, "configDir: %s\n", dir)
+	}
+	if dir, err := config.GetDataDir(); err == nil {
+		fmt.Fprintf(&b, "dataDir: %s\n", dir)
+	}
+	return []byte(b.String())
+}
+
+// doctorReport renders the doctor checks in their text format; a snapshot is
+// useful even when some checks fail, so errors become part of the report.
+func doctorReport(app *App) []byte {
+	results, err := app.doctorChecks()
+	if err != nil {
+		return []byte(fmt.Sprintf("doctor checks failed to run: %v\n", err))
+	}
+	var b strings.Builder
+	for _, r := range results {
+		status := "ok"
+		if !r.OK {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&b, "%-30s %-4s %s\n", r.ID, status, r.Detail)
+	}
+	return []byte(b.String())
+}
+
+// sanitizedConfig marshals the config with every credential replaced by a
+// short hash, so two reports from the same machine correlate without
+// revealing the key.
+func sanitizedConfig(app *App) []byte {
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return nil
+	}
+	view := struct {
+		Version         int                            `json:"version"`
+		DefaultProvider string                         `json:"defaultProvider,omitempty"`
+		Providers       map[string]*configProviderView `json:"providers,omitempty"`
+		Settings        map[string]string              `json:"settings,omitempty"`
+	}{Version: cfg.Version, DefaultProvider: cfg.DefaultProvider, Settings: cfg.Settings}
+	view.Providers = make(map[string]*configProviderView, len(cfg.Providers))
+	for id, entry := range cfg.Providers {
+		extra := make(map[string]string, len(entry.ExtraConfig))
+		for name, value := range entry.ExtraConfig {
+			if looksSecret(name) {
+				value = hashSecret(value)
+			}
+			extra[name] = value
+		}
+		view.Providers[id] = &configProviderView{
+			ModelID:     entry.ModelID,
+			APIKey:      hashSecret(entry.APIKey),
+			BaseURL:     entry.BaseURL,
+			ExtraConfig: extra,
+		}
+	}
+	data, err := json.MarshalIndent(view, "", "  ")
+	if err != nil {
+		return nil
+	}
+	return append(data, '\n')
+}
+
+// looksSecret mirrors the security lint's credential heuristic.
+func looksSecret(name string) bool {
+	return config.LooksLikeSecretName(name)
+}
+
+// hashSecret replaces a credential with a stable fingerprint.
+func hashSecret(s string) string {
+	if s == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(s))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
+
+// statsFile returns the raw provider stats samples; they hold only provider
+// names, latencies and error flags.
+func statsFile() []byte {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(dir + "/stats.jsonl")
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// recentTaskLog lists the newest task records' metadata — never their
+// content, which may hold proprietary code.
+func recentTaskLog() []byte {
+	ids, err := task.List()
+	if err != nil || len(ids) == 0 {
+		return []byte("no recorded tasks\n")
+	}
+	const maxTasks = 20
+	var records []*task.Task
+	for _, id := range ids {
+		if t, err := task.Load(id); err == nil {
+			records = append(records, t)
+		}
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].StartedAt.After(records[j].StartedAt) })
+	if len(records) > maxTasks {
+		records = records[:maxTasks]
+	}
+	var b strings.Builder
+	for _, t := range records {
+		fmt.Fprintf(&b, "%s %s provider=%s model=%s events=%d cost=$%.4f\n",
+			t.StartedAt.UTC().Format(time.RFC3339), t.ID, t.Provider, t.ModelID, len(t.Events), t.CostUSD())
+	}
+	return []byte(b.String())
+}
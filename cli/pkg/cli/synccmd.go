@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cline/cline/cli/pkg/cloudsync"
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func syncCommand() *Command {
+	return &Command{
+		Name:    "sync",
+		Summary: "Sync settings through the account service, end-to-end encrypted.",
+		Sub: []*Command{
+			{
+				Name:    "push",
+				Summary: "Seal the local settings with the passphrase and upload them.",
+				Usage:   "cline sync push",
+				Run:     runSyncPush,
+			},
+			{
+				Name:    "pull",
+				Summary: "Download and apply the synced settings on this machine.",
+				Usage:   "cline sync pull",
+				Run:     runSyncPull,
+			},
+		},
+	}
+}
+
+// syncParams reads the opt-in sync configuration; each missing piece names
+// the command or variable that provides it.
+func syncParams(cfg *config.ClineConfig) (endpoint, token, passphrase string, err error) {
+	endpoint = cfg.Settings[cloudsync.SettingEndpoint]
+	if endpoint == "" {
+		return "", "", "", fmt.Errorf("settings sync is off; opt in with `cline config set %s <url>`", cloudsync.SettingEndpoint)
+	}
+	token = cfg.Settings[cloudsync.SettingToken]
+	if token == "" {
+		return "", "", "", fmt.Errorf("no account token; run `cline config set %s <token>`", cloudsync.SettingToken)
+	}
+	passphrase = os.Getenv(cloudsync.PassphraseEnv)
+	if passphrase == "" {
+		return "", "", "", fmt.Errorf("set %s to your sync passphrase; it is never sent to the service", cloudsync.PassphraseEnv)
+	}
+	return endpoint, token, passphrase, nil
+}
+
+func runSyncPush(app *App, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: cline sync push")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	endpoint, token, passphrase, err := syncParams(cfg)
+	if err != nil {
+		return err
+	}
+	doc, err := cloudsync.Collect(cfg)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		app.dryRunSkip(fmt.Sprintf("seal %d provider(s) and %d instruction profile(s) and PUT them to %s/v1/settings",
+			len(doc.Providers), len(doc.Instructions), endpoint))
+		return nil
+	}
+	if err := cloudsync.Push(context.Background(), endpoint, token, passphrase, doc); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Pushed %d provider(s) and %d instruction profile(s), sealed with your passphrase.\n",
+		len(doc.Providers), len(doc.Instructions))
+	return nil
+}
+
+func runSyncPull(app *App, args []string) error {
+	if len(args) != 0 {
+		return fmt.Errorf("usage: cline sync pull")
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		return err
+	}
+	endpoint, token, passphrase, err := syncParams(cfg)
+	if err != nil {
+		return err
+	}
+	doc, err := cloudsync.Pull(context.Background(), endpoint, token, passphrase)
+	if err != nil {
+		return err
+	}
+	if app.dryRun {
+		app.dryRunSkip(fmt.Sprintf("apply %d provider(s) and %d instruction profile(s) from sync",
+			len(doc.Providers), len(doc.Instructions)))
+		return nil
+	}
+	if err := manager.Update(func(cfg *config.ClineConfig) error {
+		return cloudsync.Apply(cfg, doc)
+	}); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Applied %d provider(s) and %d instruction profile(s) from sync.\n",
+		len(doc.Providers), len(doc.Instructions))
+	return nil
+}
@@ -0,0 +1,247 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/task"
+)
+
+func taskCommand() *Command {
+	return &Command{
+		Name:    "task",
+		Summary: "Inspect and export recorded tasks.",
+		Sub: []*Command{
+			{
+				Name:    "list",
+				Summary: "List recorded tasks, newest first.",
+				Usage:   "cline task list",
+				Run:     runTaskList,
+			},
+			{
+				Name:    "export",
+				Summary: "Export a task transcript for sharing or review.",
+				Usage:   "cline task export <id> --format markdown|json|html",
+				Run:     runTaskExport,
+			},
+			{
+				Name:    "submit",
+				Summary: "Queue a prompt for later processing.",
+				Usage:   "cline task submit [--provider <id>] <prompt>",
+				Run:     runTaskSubmit,
+			},
+			{
+				Name:    "queue",
+				Summary: "Show pending and running tasks with interactive cancel.",
+				Usage:   "cline task queue",
+				Run:     runTaskQueue,
+			},
+			{
+				Name:    "run",
+				Summary: "Process queued tasks in submission order.",
+				Usage:   "cline task run",
+				Run:     runTaskRun,
+			},
+		},
+	}
+}
+
+func runTaskSubmit(app *App, args []string) error {
+	fs := flag.NewFlagSet("task submit", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	provider := fs.String("provider", "", "provider ID (default: configured default provider)")
+	instructionsName := fs.String("instructions", "", "instruction profile to use (default: workspace default, if set)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	prompt := strings.TrimSpace(strings.Join(fs.Args(), " "))
+	if prompt == "" {
+		return fmt.Errorf("usage: cline task submit <prompt>")
+	}
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if err := checkWorkingDirTrust(cfg); err != nil {
+		return err
+	}
+	providerID := *provider
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	if providerID == "" {
+		return fmt.Errorf("no provider configured; run `cline auth add --provider <id>`")
+	}
+	entry, ok := cfg.Providers[providerID]
+	if !ok {
+		return fmt.Errorf("provider %q is not configured", providerID)
+	}
+	// Resolve the instruction profile at submit time so later edits to the
+	// profile don't change already-queued work.
+	system, err := resolveInstructions(cfg, *instructionsName)
+	if err != nil {
+		return err
+	}
+	t := &task.Task{
+		ID:           task.NewID(),
+		StartedAt:    time.Now(),
+		Provider:     providerID,
+		ModelID:      entry.ModelID,
+		Status:       task.StatusPending,
+		Instructions: system,
+		Events:       []task.Event{{Time: time.Now(), Role: "user", Content: prompt}},
+	}
+	if err := task.Save(t); err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Queued task %s.\n", t.ID)
+	return nil
+}
+
+func runTaskQueue(app *App, args []string) error {
+	queued, err := task.Queue()
+	if err != nil {
+		return err
+	}
+	if len(queued) == 0 {
+		fmt.Fprintln(app.Stdout, "Queue is empty.")
+		return nil
+	}
+	for _, t := range queued {
+		fmt.Fprintf(app.Stdout, "%-28s %-9s %-14s %-32s %s\n", t.ID, t.Status, t.Provider, t.ModelID, app.Costs().Format(t.CostUSD(), 4))
+	}
+	reader := bufio.NewReader(app.Stdin)
+	for {
+		fmt.Fprint(app.Stdout, "Task ID to cancel (blank to exit): ")
+		line, err := reader.ReadString('\n')
+		id := strings.TrimSpace(line)
+		if id == "" {
+			return nil
+		}
+		t, loadErr := task.Load(id)
+		if loadErr != nil {
+			fmt.Fprintf(app.Stderr, "%v\n", loadErr)
+		} else if !t.Active() {
+			fmt.Fprintf(app.Stderr, "task %s is already %s\n", id, t.Status)
+		} else {
+			t.Status = task.StatusCancelled
+			if err := task.Save(t); err != nil {
+				return err
+			}
+			fmt.Fprintf(app.Stdout, "Cancelled task %s.\n", id)
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
+
+func runTaskRun(app *App, args []string) error {
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	registry, err := app.Registry()
+	if err != nil {
+		return err
+	}
+	queued, err := task.Queue()
+	if err != nil {
+		return err
+	}
+	processed := 0
+	for _, t := range queued {
+		if t.Status != task.StatusPending || len(t.Events) == 0 {
+			continue
+		}
+		entry, ok := cfg.Providers[t.Provider]
+		if !ok {
+			fmt.Fprintf(app.Stderr, "warning: skipping %s, provider %q no longer configured\n", t.ID, t.Provider)
+			continue
+		}
+		if _, err := registry.Provider(t.Provider); err != nil {
+			fmt.Fprintf(app.Stderr, "warning: skipping %s: %v\n", t.ID, err)
+			continue
+		}
+		t.Status = task.StatusRunning
+		if err := task.Save(t); err != nil {
+			return err
+		}
+		usedProvider, reply, usage, err := app.complete(context.Background(), registry, cfg, t.Provider, entry,
+			llm.Prompt{System: t.Instructions, Text: t.Events[0].Content})
+		if usedProvider != t.Provider {
+			t.Provider = usedProvider
+		}
+		if err != nil {
+			t.Status = task.StatusFailed
+			t.Events = append(t.Events, task.Event{Time: time.Now(), Role: "assistant", Content: err.Error()})
+		} else {
+			t.Status = task.StatusCompleted
+			t.Events = append(t.Events, task.Event{Time: time.Now(), Role: "assistant", Content: reply, CostUSD: usage.CostUSD})
+		}
+		if err := task.Save(t); err != nil {
+			return err
+		}
+		fmt.Fprintf(app.Stdout, "%-28s %s\n", t.ID, t.Status)
+		processed++
+	}
+	if processed == 0 {
+		fmt.Fprintln(app.Stdout, "No pending tasks.")
+	}
+	return nil
+}
+
+func runTaskList(app *App, args []string) error {
+	ids, err := task.List()
+	if err != nil {
+		return err
+	}
+	if len(ids) == 0 {
+		fmt.Fprintln(app.Stdout, "No recorded tasks.")
+		return nil
+	}
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	for _, id := range ids {
+		t, err := task.Load(id)
+		if err != nil {
+			fmt.Fprintf(app.Stderr, "warning: %v\n", err)
+			continue
+		}
+		status := t.Status
+		if status == "" {
+			status = task.StatusCompleted
+		}
+		fmt.Fprintf(out, "%-28s %-9s %-14s %-32s %s\n", t.ID, status, t.Provider, t.ModelID, app.Costs().Format(t.CostUSD(), 4))
+	}
+	return nil
+}
+
+func runTaskExport(app *App, args []string) error {
+	fs := flag.NewFlagSet("task export", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	format := fs.String("format", "markdown", "export format (markdown, json, html)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline task export <id> --format markdown|json|html")
+	}
+	t, err := task.Load(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	exported, err := task.Export(t, *format)
+	if err != nil {
+		return err
+	}
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	out.Write(exported)
+	return nil
+}
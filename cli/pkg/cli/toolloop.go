@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/approvals"
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/history"
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/metrics"
+	"github.com/cline/cline/cli/pkg/notify"
+	"github.com/cline/cline/cli/pkg/stats"
+	"github.com/cline/cline/cli/pkg/tools"
+)
+
+// maxToolRounds bounds the agent loop so a confused model cannot call tools
+// forever.
+const maxToolRounds = 8
+
+// runToolLoop drives a light agent loop: send the prompt with the user's tool
+// definitions, execute approved tool calls locally, feed the results back, and
+// repeat until the model answers in text. It is deliberately smaller than the
+// core task machinery — no retries, no fallback, one conversation.
+func (a *App) runToolLoop(cfg *config.ClineConfig, req askRequest, defs []tools.Definition) (string, error) {
+	req = applyModelAlias(cfg, req)
+	providerID := req.providerID
+	if providerID == "" {
+		providerID = cfg.DefaultProvider
+	}
+	if providerID == "" {
+		return "", fmt.Errorf("no provider configured; run `cline auth add --provider <id>`")
+	}
+	entry, ok := cfg.Providers[providerID]
+	if !ok {
+		return "", fmt.Errorf("provider %q is not configured", providerID)
+	}
+	if req.modelID != "" {
+		entry.ModelID = req.modelID
+	}
+	if pol, err := a.Policy(); err == nil && pol != nil {
+		if !pol.AllowsProvider(providerID) {
+			return "", fmt.Errorf("provider %q is not allowed by %s", providerID, pol.Path)
+		}
+		if entry.ModelID != "" && !pol.AllowsModel(entry.ModelID) {
+			return "", fmt.Errorf("model %q is not allowed by %s", entry.ModelID, pol.Path)
+		}
+	}
+	registry, err := a.Registry()
+	if err != nil {
+		return "", err
+	}
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return "", err
+	}
+
+	byName := make(map[string]tools.Definition, len(defs))
+	specs := make([]llm.ToolSpec, 0, len(defs))
+	for _, d := range defs {
+		byName[d.Name] = d
+		specs = append(specs, llm.ToolSpec{Name: d.Name, Description: d.Description, Parameters: d.Parameters})
+	}
+	approvalPolicy := approvals.FromSettings(cfg.Settings)
+	approve := bufio.NewReader(a.Stdin)
+
+	messages := []llm.Message{{Role: "user", Content: req.text}}
+	ctx := context.Background()
+	var costTotal float64
+	for round := 0; round < maxToolRounds; round++ {
+		start := time.Now()
+		reply, usage, err := llm.ChatWithTools(ctx, def, entry, req.system, messages, specs)
+		metrics.RecordProviderRequest(providerID, time.Since(start).Seconds(), usage.InputTokens, usage.OutputTokens, usage.CostUSD, err != nil)
+		stats.Record(providerID, time.Since(start).Seconds(), err != nil)
+		if err != nil {
+			return "", err
+		}
+		costTotal += usage.CostUSD
+		messages = append(messages, reply)
+		if len(reply.ToolCalls) == 0 {
+			if !req.quiet {
+				fmt.Fprintln(a.Stdout, reply.Content)
+			}
+			history.Record(cfg.Settings, history.Entry{
+				Command:  "task",
+				Provider: providerID,
+				ModelID:  entry.ModelID,
+				Prompt:   req.text,
+				CostUSD:  costTotal,
+			})
+			if round > 0 {
+				// Only multi-round tasks are worth interrupting for; a
+				// one-shot answer means the user is likely still watching.
+				notify.Notify(a.Stderr, cfg.Settings, "cline", "Task finished")
+			}
+			return reply.Content, nil
+		}
+		for _, call := range reply.ToolCalls {
+			result := a.executeToolCall(ctx, byName, approvalPolicy, approve, cfg.Settings, call)
+			messages = append(messages, llm.Message{Role: "tool", ToolCallID: call.ID, Content: result})
+		}
+	}
+	return "", fmt.Errorf("model did not finish within %d tool round(s)", maxToolRounds)
+}
+
+// executeToolCall runs one requested tool under the approval rules and
+// returns what the model should see. Unknown tools and denials come back as
+// text so the model can carry on without them.
+func (a *App) executeToolCall(ctx context.Context, byName map[string]tools.Definition, policy *approvals.Policy, approve *bufio.Reader, settings map[string]string, call llm.ToolCall) string {
+	def, ok := byName[call.Name]
+	if !ok {
+		return fmt.Sprintf("no such tool %q", call.Name)
+	}
+	decision := policy.EvaluateCommand(def.Command)
+	allowed := def.AutoApprove || decision.Auto
+	switch {
+	case decision.Denied:
+		// The denylist wins even over autoApprove in the definitions file.
+		fmt.Fprintf(a.Stderr, "tool %s denied: %s\n", call.Name, decision.Reason)
+		return fmt.Sprintf("tool %s was denied by the approval policy", call.Name)
+	case allowed:
+		fmt.Fprintf(a.Stderr, "running tool %s %s\n", call.Name, call.Arguments)
+	default:
+		notify.Notify(a.Stderr, settings, "cline", fmt.Sprintf("Approval needed for tool %s", call.Name))
+		fmt.Fprintf(a.Stderr, "model wants to run tool %s with arguments %s\nallow? [y/N] ", call.Name, call.Arguments)
+		answer, _ := approve.ReadString('\n')
+		if answer := strings.ToLower(strings.TrimSpace(answer)); answer != "y" && answer != "yes" {
+			return fmt.Sprintf("tool %s was denied by the user", call.Name)
+		}
+	}
+	return tools.Run(ctx, def, call.Arguments)
+}
@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/approvals"
+	"github.com/cline/cline/cli/pkg/llm"
+	"github.com/cline/cline/cli/pkg/tools"
+)
+
+func TestExecuteToolCallDenyBeatsAutoApprove(t *testing.T) {
+	marker := filepath.Join(t.TempDir(), "ran")
+	def := tools.Definition{
+		Name:        "deploy",
+		Command:     "touch " + marker,
+		AutoApprove: true,
+	}
+	policy := approvals.FromSettings(map[string]string{
+		approvals.SettingDenylist: "touch",
+	})
+	var stderr bytes.Buffer
+	app := &App{Stdin: strings.NewReader(""), Stdout: &bytes.Buffer{}, Stderr: &stderr}
+
+	result := app.executeToolCall(context.Background(),
+		map[string]tools.Definition{def.Name: def}, policy,
+		bufio.NewReader(app.Stdin), nil, llm.ToolCall{ID: "1", Name: "deploy"})
+
+	if !strings.Contains(result, "denied by the approval policy") {
+		t.Errorf("result = %q, want policy denial", result)
+	}
+	if !strings.Contains(stderr.String(), "matches deny rule") {
+		t.Errorf("stderr = %q, want deny reason", stderr.String())
+	}
+	if _, err := os.Stat(marker); err == nil {
+		t.Error("denied tool ran anyway")
+	}
+}
@@ -0,0 +1,124 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/trust"
+)
+
+func trustCommand() *Command {
+	return &Command{
+		Name:    "trust",
+		Summary: "Manage per-directory workspace trust levels.",
+		Sub: []*Command{
+			{
+				Name:    "add",
+				Summary: "Set the trust level for a directory.",
+				Usage:   "cline trust add <path> [--level trusted|restricted|blocked]",
+				Run:     runTrustAdd,
+			},
+			{
+				Name:    "list",
+				Summary: "List configured trust levels.",
+				Usage:   "cline trust list",
+				Run:     runTrustList,
+			},
+			{
+				Name:    "remove",
+				Summary: "Remove a directory's trust entry.",
+				Usage:   "cline trust remove <path>",
+				Run:     runTrustRemove,
+			},
+		},
+	}
+}
+
+func runTrustAdd(app *App, args []string) error {
+	fs := flag.NewFlagSet("trust add", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	levelName := fs.String("level", string(trust.Trusted), "trust level (trusted, restricted, blocked)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: cline trust add <path> [--level trusted|restricted|blocked]")
+	}
+	level, err := trust.ParseLevel(*levelName)
+	if err != nil {
+		return err
+	}
+	path, err := filepath.Abs(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		if cfg.Trust == nil {
+			cfg.Trust = make(map[string]string)
+		}
+		cfg.Trust[path] = string(level)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Marked %s as %s.\n", path, level)
+	return nil
+}
+
+func runTrustList(app *App, args []string) error {
+	cfg, err := app.loadConfig()
+	if err != nil {
+		return err
+	}
+	if len(cfg.Trust) == 0 {
+		fmt.Fprintln(app.Stdout, "No trust entries. Unlisted directories are restricted.")
+		return nil
+	}
+	for _, path := range sortedKeys(cfg.Trust) {
+		fmt.Fprintf(app.Stdout, "%-10s %s\n", cfg.Trust[path], path)
+	}
+	return nil
+}
+
+func runTrustRemove(app *App, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: cline trust remove <path>")
+	}
+	path, err := filepath.Abs(args[0])
+	if err != nil {
+		return err
+	}
+	manager, err := app.ConfigManager()
+	if err != nil {
+		return err
+	}
+	err = manager.Update(func(cfg *config.ClineConfig) error {
+		if _, ok := cfg.Trust[path]; !ok {
+			return fmt.Errorf("no trust entry for %s", path)
+		}
+		delete(cfg.Trust, path)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(app.Stdout, "Removed trust entry for %s.\n", path)
+	return nil
+}
+
+// checkWorkingDirTrust refuses to proceed when the working directory is
+// blocked. Chat and task entry points call this before doing any work.
+func checkWorkingDirTrust(cfg *config.ClineConfig) error {
+	if trust.Lookup(cfg.Trust, ".") == trust.Blocked {
+		dir, _ := filepath.Abs(".")
+		return fmt.Errorf("directory %s is blocked by workspace trust; see `cline trust list`", dir)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/usage"
+)
+
+func usageCommand() *Command {
+	return &Command{
+		Name:    "usage",
+		Summary: "Report what invocations cost, grouped for chargeback.",
+		Sub: []*Command{
+			{
+				Name:    "show",
+				Summary: "Aggregate the usage ledger by provider, model, command, day, or tag.",
+				Usage:   "cline usage show [--by <provider|model|command|day|tag>] [--days <n>]",
+				Run:     runUsageShow,
+			},
+			{
+				Name:    "export",
+				Summary: "Dump the usage ledger as CSV or Parquet for BI tools.",
+				Usage:   "cline usage export [--format <csv|parquet>] [--since <YYYY-MM-DD>] [--out <file>]",
+				Run:     runUsageExport,
+			},
+		},
+	}
+}
+
+func runUsageExport(app *App, args []string) error {
+	fs := flag.NewFlagSet("usage export", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	format := fs.String("format", "csv", "output format: csv or parquet")
+	sinceStr := fs.String("since", "", "only export entries on or after this date (YYYY-MM-DD)")
+	out := fs.String("out", "", "output file (default: stdout; required for parquet)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: cline usage export [--format <csv|parquet>] [--since <YYYY-MM-DD>] [--out <file>]")
+	}
+	if *format != "csv" && *format != "parquet" {
+		return fmt.Errorf("unknown format %q (csv or parquet)", *format)
+	}
+	since := time.Time{}
+	if *sinceStr != "" {
+		var err error
+		if since, err = time.ParseInLocation("2006-01-02", *sinceStr, time.Local); err != nil {
+			return fmt.Errorf("--since must be YYYY-MM-DD: %w", err)
+		}
+	}
+	records, err := usage.Load(since)
+	if err != nil {
+		return err
+	}
+	var w io.Writer = app.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	} else if *format == "parquet" {
+		return fmt.Errorf("parquet is binary; give it a destination with --out <file>")
+	}
+	if *format == "parquet" {
+		err = usage.WriteParquet(w, records)
+	} else {
+		err = usage.WriteCSV(w, records)
+	}
+	if err != nil {
+		return err
+	}
+	if *out != "" {
+		fmt.Fprintf(app.Stderr, "exported %d record(s) to %s\n", len(records), *out)
+	}
+	return nil
+}
+
+func runUsageShow(app *App, args []string) error {
+	fs := flag.NewFlagSet("usage show", flag.ContinueOnError)
+	fs.SetOutput(app.Stderr)
+	by := fs.String("by", "provider", "grouping: provider, model, command, day, or tag")
+	days := fs.Int("days", 30, "how many days back to report (0 for all)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 0 {
+		return fmt.Errorf("usage: cline usage show [--by <provider|model|command|day|tag>] [--days <n>]")
+	}
+	since := time.Time{}
+	if *days > 0 {
+		since = time.Now().Add(-time.Duration(*days) * 24 * time.Hour)
+	}
+	records, err := usage.Load(since)
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		fmt.Fprintln(app.Stdout, "No usage recorded.")
+		return nil
+	}
+	totals, err := usage.GroupBy(records, *by)
+	if err != nil {
+		return err
+	}
+	out, closePager := app.pagedStdout()
+	defer closePager()
+	fmt.Fprintf(out, "%-32s %8s %12s %12s %12s\n", strings.ToUpper(*by), "REQS", "TOK IN", "TOK OUT", "COST")
+	for _, t := range totals {
+		fmt.Fprintf(out, "%-32s %8d %12d %12d %12s\n",
+			t.Key, t.Requests, t.InputTokens, t.OutputTokens, app.Costs().Format(t.CostUSD, 4))
+	}
+	return nil
+}
+
+// parseTags turns repeated --tag key=value flags into a map.
+func parseTags(pairs []string) (map[string]string, error) {
+	if len(pairs) == 0 {
+		return nil, nil
+	}
+	tags := map[string]string{}
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("tag %q is not key=value", pair)
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
@@ -0,0 +1,101 @@
+// Package clierr defines the CLI's exit code taxonomy, so wrapper scripts
+// can branch on the failure class instead of grepping stderr. Errors are
+// classified either explicitly, by wrapping them in a class, or implicitly
+// from their type (provider status errors, transport errors, cancellation).
+package clierr
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+
+	"github.com/cline/cline/cli/pkg/llm"
+)
+
+// The exit codes commands terminate with. They are part of the CLI's
+// contract: changing a value is a breaking change.
+const (
+	// ExitOK is success.
+	ExitOK = 0
+	// ExitGeneric is any failure without a more specific class.
+	ExitGeneric = 1
+	// ExitConfig is a broken or unusable configuration.
+	ExitConfig = 2
+	// ExitAuth is a rejected credential (401/403 from a provider or service).
+	ExitAuth = 3
+	// ExitNetwork is a transport failure: the request never completed.
+	ExitNetwork = 4
+	// ExitProvider is a provider API error other than bad credentials.
+	ExitProvider = 5
+	// ExitAborted is a user abort (interrupt or declined confirmation),
+	// matching the shell convention for SIGINT.
+	ExitAborted = 130
+)
+
+// Error carries an exit code alongside the underlying error.
+type Error struct {
+	Code int
+	Err  error
+}
+
+func (e *Error) Error() string { return e.Err.Error() }
+
+func (e *Error) Unwrap() error { return e.Err }
+
+// wrap attaches a class to err; nil stays nil so call sites can wrap
+// unconditionally.
+func wrap(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Code: code, Err: err}
+}
+
+// Config classes err as a configuration failure.
+func Config(err error) error { return wrap(ExitConfig, err) }
+
+// Auth classes err as a credential failure.
+func Auth(err error) error { return wrap(ExitAuth, err) }
+
+// Network classes err as a transport failure.
+func Network(err error) error { return wrap(ExitNetwork, err) }
+
+// Provider classes err as a provider API failure.
+func Provider(err error) error { return wrap(ExitProvider, err) }
+
+// Aborted classes err as a user abort.
+func Aborted(err error) error { return wrap(ExitAborted, err) }
+
+// Code resolves the exit code for an error: an explicit class wins, then the
+// error's type decides — provider status errors split into auth (401/403)
+// and provider failures, transport errors are network, cancellation is a
+// user abort.
+func Code(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	var ce *Error
+	if errors.As(err, &ce) {
+		return ce.Code
+	}
+	if errors.Is(err, context.Canceled) {
+		return ExitAborted
+	}
+	var se *llm.StatusError
+	if errors.As(err, &se) {
+		if se.Code == 401 || se.Code == 403 {
+			return ExitAuth
+		}
+		return ExitProvider
+	}
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return ExitNetwork
+	}
+	var ne net.Error
+	if errors.As(err, &ne) {
+		return ExitNetwork
+	}
+	return ExitGeneric
+}
@@ -0,0 +1,38 @@
+package clierr
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/llm"
+)
+
+func TestCodeClassification(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"nil", nil, ExitOK},
+		{"plain", fmt.Errorf("boom"), ExitGeneric},
+		{"explicit config", Config(fmt.Errorf("bad json")), ExitConfig},
+		{"wrapped class survives fmt.Errorf", fmt.Errorf("loading: %w", Auth(fmt.Errorf("nope"))), ExitAuth},
+		{"canceled", context.Canceled, ExitAborted},
+		{"provider 401", &llm.StatusError{Provider: "openai", Code: 401}, ExitAuth},
+		{"provider 500", &llm.StatusError{Provider: "openai", Code: 500}, ExitProvider},
+		{"transport", &url.Error{Op: "Post", URL: "https://x", Err: fmt.Errorf("refused")}, ExitNetwork},
+	}
+	for _, tc := range cases {
+		if got := Code(tc.err); got != tc.want {
+			t.Errorf("%s: Code = %d, want %d", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestWrapNilStaysNil(t *testing.T) {
+	if Config(nil) != nil || Network(nil) != nil {
+		t.Error("wrapping nil produced an error")
+	}
+}
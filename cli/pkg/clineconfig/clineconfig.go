@@ -0,0 +1,177 @@
+// Package clineconfig is the stable Go API for other tools that need to read
+// (or minimally update) a user's Cline provider setup without shelling out to
+// the CLI or parsing its config file themselves.
+//
+// Compatibility: within a major version of the module, the types and
+// functions here only ever gain fields and functions; nothing is renamed,
+// removed, or repurposed. Internal packages (config, generated, models) make
+// no such promise — depend on this package instead.
+package clineconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/models"
+)
+
+// Provider is one configured provider entry.
+type Provider struct {
+	// ID is the provider identifier ("openai", "anthropic", ...).
+	ID string
+	// ModelID is the configured model; empty means the catalog default.
+	ModelID string
+	// APIKey is the stored credential; empty when the provider needs none.
+	APIKey string
+	// BaseURL overrides the provider's default endpoint when set.
+	BaseURL string
+	// Default reports whether this entry is the default provider.
+	Default bool
+}
+
+// Model is one catalog entry. Prices are USD per million tokens.
+type Model struct {
+	ID            string
+	Name          string
+	ContextWindow int
+	MaxTokens     int
+	InputPrice    float64
+	OutputPrice   float64
+}
+
+// Setup is a loaded configuration.
+type Setup struct {
+	// Path is the config file the setup was read from.
+	Path string
+
+	manager *config.ConfigManager
+	cfg     *config.ClineConfig
+}
+
+// Load reads the user's configuration from its default location.
+func Load() (*Setup, error) {
+	manager, err := config.NewConfigManager()
+	if err != nil {
+		return nil, err
+	}
+	return load(manager)
+}
+
+// LoadFrom reads a configuration from an explicit path.
+func LoadFrom(path string) (*Setup, error) {
+	return load(config.NewConfigManagerAt(path))
+}
+
+func load(manager *config.ConfigManager) (*Setup, error) {
+	cfg, err := manager.Load()
+	if err != nil {
+		return nil, err
+	}
+	return &Setup{Path: manager.Path(), manager: manager, cfg: cfg}, nil
+}
+
+// DefaultProvider returns the default provider's ID; empty when none is set.
+func (s *Setup) DefaultProvider() string {
+	return s.cfg.DefaultProvider
+}
+
+// Providers lists the configured providers in ID order.
+func (s *Setup) Providers() []Provider {
+	ids := make([]string, 0, len(s.cfg.Providers))
+	for id := range s.cfg.Providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	providers := make([]Provider, 0, len(ids))
+	for _, id := range ids {
+		p, _ := s.Provider(id)
+		providers = append(providers, p)
+	}
+	return providers
+}
+
+// Provider returns one configured provider entry.
+func (s *Setup) Provider(id string) (Provider, bool) {
+	entry, ok := s.cfg.Providers[id]
+	if !ok {
+		return Provider{}, false
+	}
+	return Provider{
+		ID:      id,
+		ModelID: entry.ModelID,
+		APIKey:  entry.APIKey,
+		BaseURL: entry.BaseURL,
+		Default: id == s.cfg.DefaultProvider,
+	}, true
+}
+
+// Credentials returns the API key for a configured provider.
+func (s *Setup) Credentials(id string) (string, bool) {
+	entry, ok := s.cfg.Providers[id]
+	if !ok {
+		return "", false
+	}
+	return entry.APIKey, true
+}
+
+// SetModel selects the model a configured provider uses and saves the
+// change. Other concurrent edits to the config file are preserved.
+func (s *Setup) SetModel(providerID, modelID string) error {
+	err := s.manager.Update(func(cfg *config.ClineConfig) error {
+		entry, ok := cfg.Providers[providerID]
+		if !ok {
+			return fmt.Errorf("provider %q is not configured", providerID)
+		}
+		entry.ModelID = modelID
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if entry, ok := s.cfg.Providers[providerID]; ok {
+		entry.ModelID = modelID
+	}
+	return nil
+}
+
+// Models lists a provider's models: the static catalog for most providers,
+// or a live fetch (honoring the user's overrides) for dynamic ones.
+func (s *Setup) Models(ctx context.Context, providerID string) ([]Model, error) {
+	registry, err := generated.NewProviderRegistry()
+	if err != nil {
+		return nil, err
+	}
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return nil, err
+	}
+	entry := s.cfg.Providers[providerID]
+	if entry == nil {
+		entry = &config.ProviderConfig{Provider: providerID}
+	}
+	list := def.Models
+	if def.DynamicModels {
+		list, err = models.FetchCached(ctx, providerID, entry, generated.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		list = append([]generated.ModelInfo(nil), list...)
+		models.ApplyOverrides(entry, list)
+	}
+	out := make([]Model, len(list))
+	for i, m := range list {
+		out[i] = Model{
+			ID:            m.ID,
+			Name:          m.Name,
+			ContextWindow: m.ContextWindow,
+			MaxTokens:     m.MaxTokens,
+			InputPrice:    m.InputPrice,
+			OutputPrice:   m.OutputPrice,
+		}
+	}
+	return out, nil
+}
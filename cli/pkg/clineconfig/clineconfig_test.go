@@ -0,0 +1,73 @@
+package clineconfig
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func writeTestConfig(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+	manager, err := config.NewConfigManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewClineConfig()
+	cfg.DefaultProvider = "openai"
+	cfg.Providers["openai"] = &config.ProviderConfig{Provider: "openai", ModelID: "gpt-4.1", APIKey: "sk-test"}
+	cfg.Providers["anthropic"] = &config.ProviderConfig{Provider: "anthropic", ModelID: "claude-sonnet-4-20250514"}
+	if err := manager.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadAndRead(t *testing.T) {
+	writeTestConfig(t)
+	setup, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setup.DefaultProvider() != "openai" {
+		t.Errorf("default = %q", setup.DefaultProvider())
+	}
+	providers := setup.Providers()
+	if len(providers) != 2 || providers[0].ID != "anthropic" || providers[1].ID != "openai" {
+		t.Fatalf("providers = %+v", providers)
+	}
+	if !providers[1].Default || providers[1].APIKey != "sk-test" {
+		t.Errorf("openai entry = %+v", providers[1])
+	}
+	if key, ok := setup.Credentials("openai"); !ok || key != "sk-test" {
+		t.Errorf("credentials = %q, %v", key, ok)
+	}
+	if _, ok := setup.Credentials("gemini"); ok {
+		t.Error("unconfigured provider reported credentials")
+	}
+}
+
+func TestSetModelAndModels(t *testing.T) {
+	writeTestConfig(t)
+	setup, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := setup.SetModel("openai", "gpt-4.1-mini"); err != nil {
+		t.Fatal(err)
+	}
+	reloaded, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p, _ := reloaded.Provider("openai"); p.ModelID != "gpt-4.1-mini" {
+		t.Errorf("model after SetModel = %q", p.ModelID)
+	}
+	list, err := setup.Models(context.Background(), "openai")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) == 0 || list[0].ContextWindow == 0 {
+		t.Errorf("models = %+v", list)
+	}
+}
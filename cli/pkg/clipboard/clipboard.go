@@ -0,0 +1,81 @@
+// Package clipboard reads and writes the system clipboard by shelling out to
+// the platform's clipboard tool, so prompts and answers can skip temp files.
+package clipboard
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// tool is one clipboard helper: the command to paste from and copy to it.
+type tool struct {
+	read  []string
+	write []string
+}
+
+// toolsByOS lists candidates in preference order; the first one present on
+// PATH wins. Linux covers both Wayland and X11 setups.
+var toolsByOS = map[string][]tool{
+	"darwin": {
+		{read: []string{"pbpaste"}, write: []string{"pbcopy"}},
+	},
+	"linux": {
+		{read: []string{"wl-paste", "--no-newline"}, write: []string{"wl-copy"}},
+		{read: []string{"xclip", "-selection", "clipboard", "-o"}, write: []string{"xclip", "-selection", "clipboard"}},
+		{read: []string{"xsel", "--clipboard", "--output"}, write: []string{"xsel", "--clipboard", "--input"}},
+	},
+	"windows": {
+		{read: []string{"powershell", "-NoProfile", "-Command", "Get-Clipboard"}, write: []string{"clip"}},
+	},
+}
+
+// lookPath is swapped in tests.
+var lookPath = exec.LookPath
+
+// pickTool returns the first available tool for the OS.
+func pickTool(goos string) (tool, error) {
+	candidates, ok := toolsByOS[goos]
+	if !ok {
+		return tool{}, fmt.Errorf("no clipboard support on %s", goos)
+	}
+	for _, t := range candidates {
+		if _, err := lookPath(t.read[0]); err == nil {
+			return t, nil
+		}
+	}
+	names := make([]string, 0, len(candidates))
+	for _, t := range candidates {
+		names = append(names, t.read[0])
+	}
+	return tool{}, fmt.Errorf("no clipboard tool found (install one of: %s)", strings.Join(names, ", "))
+}
+
+// Read returns the clipboard contents.
+func Read() (string, error) {
+	t, err := pickTool(runtime.GOOS)
+	if err != nil {
+		return "", err
+	}
+	out, err := exec.Command(t.read[0], t.read[1:]...).Output()
+	if err != nil {
+		return "", fmt.Errorf("reading clipboard with %s: %w", t.read[0], err)
+	}
+	return string(out), nil
+}
+
+// Write replaces the clipboard contents.
+func Write(text string) error {
+	t, err := pickTool(runtime.GOOS)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(t.write[0], t.write[1:]...)
+	cmd.Stdin = bytes.NewReader([]byte(text))
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("writing clipboard with %s: %w", t.write[0], err)
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package clipboard
+
+import (
+	"fmt"
+	"testing"
+)
+
+func withLookPath(t *testing.T, available map[string]bool) {
+	t.Helper()
+	orig := lookPath
+	lookPath = func(name string) (string, error) {
+		if available[name] {
+			return "/usr/bin/" + name, nil
+		}
+		return "", fmt.Errorf("%s: not found", name)
+	}
+	t.Cleanup(func() { lookPath = orig })
+}
+
+func TestPickToolPreferenceOrder(t *testing.T) {
+	withLookPath(t, map[string]bool{"xclip": true, "xsel": true})
+	tool, err := pickTool("linux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tool.read[0] != "xclip" {
+		t.Errorf("picked %s, want xclip", tool.read[0])
+	}
+
+	withLookPath(t, map[string]bool{"wl-paste": true, "xclip": true})
+	tool, err = pickTool("linux")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tool.read[0] != "wl-paste" {
+		t.Errorf("picked %s, want wl-paste", tool.read[0])
+	}
+}
+
+func TestPickToolNoneAvailable(t *testing.T) {
+	withLookPath(t, nil)
+	if _, err := pickTool("linux"); err == nil {
+		t.Error("missing tools not reported")
+	}
+	if _, err := pickTool("plan9"); err == nil {
+		t.Error("unsupported OS not reported")
+	}
+}
+
+func TestPickToolDarwin(t *testing.T) {
+	withLookPath(t, map[string]bool{"pbpaste": true})
+	tool, err := pickTool("darwin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tool.write[0] != "pbcopy" {
+		t.Errorf("write tool = %s", tool.write[0])
+	}
+}
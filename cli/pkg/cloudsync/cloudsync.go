@@ -0,0 +1,229 @@
+// Package cloudsync pushes and pulls the user's settings through an account
+// sync service, so a new machine needs only the account token and the sync
+// passphrase. The whole document — provider entries including API keys,
+// instruction profiles, aliases — is sealed end-to-end with the passphrase
+// before it leaves the machine; the service only ever stores ciphertext.
+package cloudsync
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/cryptobox"
+	"github.com/cline/cline/cli/pkg/instructions"
+)
+
+// Settings keys configuring the sync service.
+const (
+	// SettingEndpoint is the sync service base URL; sync is opt-in and off
+	// until it is set.
+	SettingEndpoint = "sync.endpoint"
+	// SettingToken is the account bearer token sent to the service.
+	SettingToken = "sync.token"
+)
+
+// PassphraseEnv is where the sealing passphrase is read from, kept out of
+// argv so it never lands in shell history or process listings.
+const PassphraseEnv = "CLINE_SYNC_PASSPHRASE"
+
+const requestTimeout = 30 * time.Second
+
+// httpClient carries sync traffic so tests can install their own transport.
+var httpClient = &http.Client{}
+
+// SetHTTPTransport swaps the transport used for sync requests; nil restores
+// the default.
+func SetHTTPTransport(rt http.RoundTripper) {
+	httpClient.Transport = rt
+}
+
+// Document is everything sync carries between machines.
+type Document struct {
+	DefaultProvider string                            `json:"defaultProvider,omitempty"`
+	Providers       map[string]*config.ProviderConfig `json:"providers,omitempty"`
+	Settings        map[string]string                 `json:"settings,omitempty"`
+	ModelAliases    map[string]string                 `json:"modelAliases,omitempty"`
+	CommandAliases  map[string]string                 `json:"commandAliases,omitempty"`
+	// Instructions maps profile names to their content.
+	Instructions map[string]string `json:"instructions,omitempty"`
+}
+
+// Collect builds the sync document from the local config and instruction
+// profiles.
+func Collect(cfg *config.ClineConfig) (*Document, error) {
+	doc := &Document{
+		DefaultProvider: cfg.DefaultProvider,
+		Providers:       cfg.Providers,
+		Settings:        cfg.Settings,
+		ModelAliases:    cfg.ModelAliases,
+		CommandAliases:  cfg.CommandAliases,
+	}
+	names, err := instructions.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		text, err := instructions.Load(name)
+		if err != nil {
+			return nil, err
+		}
+		if doc.Instructions == nil {
+			doc.Instructions = make(map[string]string)
+		}
+		doc.Instructions[name] = text
+	}
+	return doc, nil
+}
+
+// Apply writes a pulled document onto this machine: the document wins for
+// everything it carries, local-only settings and profiles stay.
+func Apply(cfg *config.ClineConfig, doc *Document) error {
+	if doc.DefaultProvider != "" {
+		cfg.DefaultProvider = doc.DefaultProvider
+	}
+	for id, entry := range doc.Providers {
+		if cfg.Providers == nil {
+			cfg.Providers = make(map[string]*config.ProviderConfig)
+		}
+		cfg.Providers[id] = entry
+	}
+	merge := func(dst *map[string]string, src map[string]string) {
+		for k, v := range src {
+			if *dst == nil {
+				*dst = make(map[string]string)
+			}
+			(*dst)[k] = v
+		}
+	}
+	merge(&cfg.Settings, doc.Settings)
+	merge(&cfg.ModelAliases, doc.ModelAliases)
+	merge(&cfg.CommandAliases, doc.CommandAliases)
+	for name, text := range doc.Instructions {
+		if err := instructions.Save(name, text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SessionValid asks the service whether the account session behind token is
+// still live. A definitive 401/403 means revoked; transport failures are
+// returned as errors so an offline machine is not mistaken for a revoked one.
+func SessionValid(ctx context.Context, endpoint, token string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/v1/session", nil)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return false, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return false, fmt.Errorf("session check: %s", resp.Status)
+	}
+	return true, nil
+}
+
+// Revoke invalidates a user's session server-side; their machines wipe local
+// provider keys on next run when policy asks for it.
+func Revoke(ctx context.Context, endpoint, token, user string) error {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, strings.TrimSuffix(endpoint, "/")+"/v1/sessions/"+user, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("revoke %s: %s", user, resp.Status)
+	}
+	return nil
+}
+
+// settingsURL is where the sealed document lives on the service.
+func settingsURL(endpoint string) string {
+	return strings.TrimSuffix(endpoint, "/") + "/v1/settings"
+}
+
+// Push seals the document with the passphrase and uploads it.
+func Push(ctx context.Context, endpoint, token, passphrase string, doc *Document) error {
+	plaintext, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	sealed, err := cryptobox.Seal(passphrase, plaintext)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, settingsURL(endpoint), bytes.NewReader(sealed))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("sync push: %s", resp.Status)
+	}
+	return nil
+}
+
+// Pull downloads the sealed document and opens it with the passphrase.
+func Pull(ctx context.Context, endpoint, token, passphrase string) (*Document, error) {
+	ctx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, settingsURL(endpoint), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("the account has no synced settings yet; run `cline sync push` from a configured machine")
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("sync pull: %s", resp.Status)
+	}
+	sealed, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := cryptobox.Open(passphrase, sealed)
+	if err != nil {
+		return nil, err
+	}
+	doc := &Document{}
+	if err := json.Unmarshal(plaintext, doc); err != nil {
+		return nil, fmt.Errorf("decoding synced settings: %w", err)
+	}
+	return doc, nil
+}
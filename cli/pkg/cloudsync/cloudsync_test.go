@@ -0,0 +1,102 @@
+package cloudsync
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/instructions"
+)
+
+func TestPushPullRoundTrip(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	if err := instructions.Save("team", "Always write tests."); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewClineConfig()
+	cfg.DefaultProvider = "openai"
+	entry := cfg.Provider("openai")
+	entry.ModelID = "gpt-4.1"
+	entry.APIKey = "sk-roundtrip"
+
+	var stored []byte
+	var gotAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/settings" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		switch r.Method {
+		case http.MethodPut:
+			stored = make([]byte, r.ContentLength)
+			r.Body.Read(stored)
+		case http.MethodGet:
+			if stored == nil {
+				http.NotFound(w, r)
+				return
+			}
+			w.Write(stored)
+		}
+	}))
+	defer server.Close()
+
+	doc, err := Collect(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Push(context.Background(), server.URL, "tok", "passphrase", doc); err != nil {
+		t.Fatal(err)
+	}
+	if gotAuth != "Bearer tok" {
+		t.Errorf("Authorization = %q", gotAuth)
+	}
+	if string(stored) == "" || string(stored[:5]) != "cbox1" {
+		t.Fatal("service did not receive a sealed blob")
+	}
+
+	// A fresh machine: empty config dir, pull and apply.
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	pulled, err := Pull(context.Background(), server.URL, "tok", "passphrase")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fresh := config.NewClineConfig()
+	if err := Apply(fresh, pulled); err != nil {
+		t.Fatal(err)
+	}
+	if fresh.Providers["openai"] == nil || fresh.Providers["openai"].APIKey != "sk-roundtrip" {
+		t.Errorf("providers = %+v", fresh.Providers)
+	}
+	if text, err := instructions.Load("team"); err != nil || text != "Always write tests." {
+		t.Errorf("instructions = %q, %v", text, err)
+	}
+
+	if _, err := Pull(context.Background(), server.URL, "tok", "wrong"); err == nil {
+		t.Error("wrong passphrase accepted")
+	}
+}
+
+func TestSessionValid(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "Bearer live" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	if ok, err := SessionValid(context.Background(), server.URL, "live"); err != nil || !ok {
+		t.Errorf("live session = %v, %v", ok, err)
+	}
+	if ok, err := SessionValid(context.Background(), server.URL, "revoked"); err != nil || ok {
+		t.Errorf("revoked session = %v, %v", ok, err)
+	}
+	server.Close()
+	if _, err := SessionValid(context.Background(), server.URL, "live"); err == nil {
+		t.Error("transport failure should be an error, not a verdict")
+	}
+}
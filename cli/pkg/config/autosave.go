@@ -0,0 +1,96 @@
+package config
+
+import (
+	"sync"
+	"time"
+)
+
+// dirtyState tracks unsaved modifications and drives the optional debounced
+// autosave. It lives in its own struct so ConfigManager's save path stays
+// uncluttered.
+type dirtyState struct {
+	mu       sync.Mutex
+	dirty    bool
+	debounce time.Duration
+	timer    *time.Timer
+	onFlush  func()
+}
+
+// MarkDirty records that the in-memory config differs from disk. When
+// autosave is enabled the debounce timer is (re)armed, so a burst of edits
+// results in a single atomic write once the user pauses.
+func (m *ConfigManager) MarkDirty() {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.state.dirty = true
+	if m.state.debounce <= 0 {
+		return
+	}
+	if m.state.timer != nil {
+		m.state.timer.Stop()
+	}
+	m.state.timer = time.AfterFunc(m.state.debounce, func() {
+		m.flushAutosave()
+	})
+}
+
+// Dirty reports whether there are unsaved changes.
+func (m *ConfigManager) Dirty() bool {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	return m.state.dirty
+}
+
+// EnableAutosave turns on debounced autosave: after each MarkDirty, the
+// config is saved atomically once debounce elapses without further edits.
+// onFlush, if non-nil, is called after each successful autosave (the wizard
+// uses it to refresh its unsaved-changes indicator).
+func (m *ConfigManager) EnableAutosave(debounce time.Duration, onFlush func()) {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.state.debounce = debounce
+	m.state.onFlush = onFlush
+}
+
+// DisableAutosave stops the debounce timer without flushing.
+func (m *ConfigManager) DisableAutosave() {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.state.debounce = 0
+	if m.state.timer != nil {
+		m.state.timer.Stop()
+		m.state.timer = nil
+	}
+}
+
+// Flush saves immediately if there are unsaved changes.
+func (m *ConfigManager) Flush() error {
+	if !m.Dirty() || m.config == nil {
+		return nil
+	}
+	return m.Save(m.config)
+}
+
+func (m *ConfigManager) flushAutosave() {
+	if err := m.Flush(); err != nil {
+		// Autosave is best-effort; the explicit save path will surface the
+		// error to the user.
+		return
+	}
+	m.state.mu.Lock()
+	onFlush := m.state.onFlush
+	m.state.mu.Unlock()
+	if onFlush != nil {
+		onFlush()
+	}
+}
+
+func (m *ConfigManager) clearDirty() {
+	m.state.mu.Lock()
+	defer m.state.mu.Unlock()
+	m.state.dirty = false
+	if m.state.timer != nil {
+		m.state.timer.Stop()
+		m.state.timer = nil
+	}
+}
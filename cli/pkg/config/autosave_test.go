@@ -0,0 +1,60 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDirtyTrackingAndFlush(t *testing.T) {
+	m := NewConfigManagerAt(filepath.Join(t.TempDir(), "config.json"))
+	cfg, err := m.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Dirty() {
+		t.Fatal("fresh manager is dirty")
+	}
+	cfg.Provider("openai").APIKey = "sk-test"
+	m.MarkDirty()
+	if !m.Dirty() {
+		t.Fatal("MarkDirty did not set dirty")
+	}
+	if err := m.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if m.Dirty() {
+		t.Fatal("Flush left manager dirty")
+	}
+	if _, err := os.Stat(m.Path()); err != nil {
+		t.Fatalf("Flush did not write config: %v", err)
+	}
+}
+
+func TestDebouncedAutosave(t *testing.T) {
+	m := NewConfigManagerAt(filepath.Join(t.TempDir(), "config.json"))
+	cfg, err := m.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	flushed := make(chan struct{}, 1)
+	m.EnableAutosave(10*time.Millisecond, func() { flushed <- struct{}{} })
+	defer m.DisableAutosave()
+
+	cfg.Provider("openai").APIKey = "sk-test"
+	m.MarkDirty()
+	m.MarkDirty() // a burst of edits must still save once
+
+	select {
+	case <-flushed:
+	case <-time.After(2 * time.Second):
+		t.Fatal("autosave never flushed")
+	}
+	if m.Dirty() {
+		t.Fatal("autosave left manager dirty")
+	}
+	if _, err := os.Stat(m.Path()); err != nil {
+		t.Fatalf("autosave did not write config: %v", err)
+	}
+}
@@ -0,0 +1,283 @@
+// Package config owns the CLI's on-disk configuration: the provider entries
+// collected by the setup wizard, global settings, and the ConfigManager that
+// loads and saves them atomically under ~/.cline.
+package config
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ProviderConfig is one configured provider entry.
+type ProviderConfig struct {
+	Provider string `json:"provider"`
+	ModelID  string `json:"modelId,omitempty"`
+	// EmbeddingsModelID is the model used for `cline embed`; empty falls back
+	// to the provider definition's default embeddings model.
+	EmbeddingsModelID string `json:"embeddingsModelId,omitempty"`
+	APIKey            string `json:"apiKey,omitempty"`
+	BaseURL           string `json:"baseUrl,omitempty"`
+	// Headers are extra HTTP headers sent with every request to this provider
+	// (org IDs, tracing headers, gateway auth). They are set after the
+	// standard headers, so a custom Authorization wins over the APIKey-derived
+	// one. Values whose names look like credentials are redacted the same way
+	// API keys are.
+	Headers map[string]string `json:"headers,omitempty"`
+	// ExtraConfig carries provider-specific fields (AWS region, project ID,
+	// organization, ...) keyed by the generated ConfigField name.
+	ExtraConfig map[string]string `json:"extraConfig,omitempty"`
+	// Retry controls how requests to this provider are retried; nil means the
+	// client defaults (fail fast, no fallback).
+	Retry *RetryConfig `json:"retry,omitempty"`
+	// PromptCache controls provider-side prompt caching for models that
+	// support it; nil means enabled with the default breakpoint strategy.
+	PromptCache *PromptCacheConfig `json:"promptCache,omitempty"`
+	// ReasoningEffort (low, medium or high) is sent to models that support
+	// the knob (OpenAI o-series); empty uses the provider default.
+	ReasoningEffort string `json:"reasoningEffort,omitempty"`
+	// ThinkingBudgetTokens caps extended thinking for models that support it
+	// (Claude); zero leaves thinking off.
+	ThinkingBudgetTokens int `json:"thinkingBudgetTokens,omitempty"`
+	// HTTP tunes the transport used for this provider's requests; nil means
+	// the client defaults.
+	HTTP *HTTPConfig `json:"http,omitempty"`
+	// ModelOverrides replaces catalog metadata per model ID, e.g. when an
+	// Ollama model's reported context window does not match how the server
+	// is actually running it. Overrides live in the config, so they survive
+	// catalog refreshes.
+	ModelOverrides map[string]*ModelOverride `json:"modelOverrides,omitempty"`
+}
+
+// ModelOverride is a user correction to one model's catalog metadata. Nil
+// fields keep the catalog value; prices are USD per million tokens, like
+// the catalog.
+type ModelOverride struct {
+	ContextWindow *int     `json:"contextWindow,omitempty"`
+	MaxTokens     *int     `json:"maxTokens,omitempty"`
+	InputPrice    *float64 `json:"inputPrice,omitempty"`
+	OutputPrice   *float64 `json:"outputPrice,omitempty"`
+}
+
+// Empty reports whether the override changes nothing.
+func (o *ModelOverride) Empty() bool {
+	return o.ContextWindow == nil && o.MaxTokens == nil && o.InputPrice == nil && o.OutputPrice == nil
+}
+
+// OverrideFor returns the override for modelID, or nil.
+func (p *ProviderConfig) OverrideFor(modelID string) *ModelOverride {
+	if p == nil {
+		return nil
+	}
+	return p.ModelOverrides[modelID]
+}
+
+// PromptCacheConfig controls provider-side prompt caching (Anthropic and
+// Bedrock models advertise support in the registry metadata).
+type PromptCacheConfig struct {
+	// Disabled turns caching off even when the model supports it.
+	Disabled bool `json:"disabled,omitempty"`
+	// Breakpoints selects where cache breakpoints are placed: "final" (last
+	// content block only, the default) or "all" (every block).
+	Breakpoints string `json:"breakpoints,omitempty"`
+}
+
+// RetryConfig is the per-provider retry and fallback policy applied to
+// rate-limited (429) and server-error (5xx) responses.
+type RetryConfig struct {
+	// MaxAttempts is the total number of tries including the first; zero or
+	// one means fail fast.
+	MaxAttempts int `json:"maxAttempts,omitempty"`
+	// MaxBackoffMs caps the exponential backoff between attempts.
+	MaxBackoffMs int `json:"maxBackoffMs,omitempty"`
+	// FallbackProvider is tried once when all attempts fail retryably; empty
+	// means no fallback.
+	FallbackProvider string `json:"fallbackProvider,omitempty"`
+}
+
+// ClineConfig is the root of the CLI configuration file.
+type ClineConfig struct {
+	Version         int                        `json:"version"`
+	DefaultProvider string                     `json:"defaultProvider,omitempty"`
+	Providers       map[string]*ProviderConfig `json:"providers,omitempty"`
+	Settings        map[string]string          `json:"settings,omitempty"`
+	// Trust maps absolute directory paths to workspace trust levels
+	// (trusted, restricted, blocked).
+	Trust map[string]string `json:"trust,omitempty"`
+	// ModelAliases maps short names ("fast") to "provider/modelId" refs so
+	// commands can take `--model fast` anywhere a model ID is accepted.
+	ModelAliases map[string]string `json:"modelAliases,omitempty"`
+	// CommandAliases maps shortcut names ("cm") to the command line they
+	// expand to ("git commit-msg --push"), applied before dispatch.
+	CommandAliases map[string]string `json:"commandAliases,omitempty"`
+}
+
+// NewClineConfig returns an empty config at the current version.
+func NewClineConfig() *ClineConfig {
+	return &ClineConfig{
+		Version:   1,
+		Providers: make(map[string]*ProviderConfig),
+		Settings:  make(map[string]string),
+	}
+}
+
+// secretNameMarkers are the substrings that mark a field or header name as
+// credential-like.
+var secretNameMarkers = []string{"key", "token", "secret", "password", "credential"}
+
+// LooksLikeSecretName reports whether a field or header name suggests its
+// value is a credential; such values get the same redaction and stripping as
+// API keys.
+func LooksLikeSecretName(name string) bool {
+	lower := strings.ToLower(name)
+	for _, marker := range secretNameMarkers {
+		if strings.Contains(lower, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetentionAckSetting is the settings key recording that a provider's
+// data-retention notice has been acknowledged ("true").
+func RetentionAckSetting(providerID string) string {
+	return "retentionAck." + providerID
+}
+
+// ResolveModelAlias looks name up in the alias table. The part of the value
+// before the first slash is the provider, so OpenRouter-style model IDs that
+// themselves contain slashes still work ("openrouter/anthropic/..."); a value
+// without a slash names just a model on the caller's provider.
+func (c *ClineConfig) ResolveModelAlias(name string) (provider, modelID string, ok bool) {
+	value, ok := c.ModelAliases[name]
+	if !ok || value == "" {
+		return "", "", false
+	}
+	if p, m, found := strings.Cut(value, "/"); found {
+		return p, m, true
+	}
+	return "", value, true
+}
+
+// Provider returns the entry for id, creating it if absent.
+func (c *ClineConfig) Provider(id string) *ProviderConfig {
+	if c.Providers == nil {
+		c.Providers = make(map[string]*ProviderConfig)
+	}
+	p, ok := c.Providers[id]
+	if !ok {
+		p = &ProviderConfig{Provider: id}
+		c.Providers[id] = p
+	}
+	return p
+}
+
+// Field returns the value stored for a generated config field name, checking
+// the typed fields before ExtraConfig.
+func (p *ProviderConfig) Field(name string) string {
+	switch name {
+	case "apiKey":
+		return p.APIKey
+	case "baseUrl":
+		return p.BaseURL
+	case "embeddingsModel":
+		return p.EmbeddingsModelID
+	case "retry.maxAttempts":
+		if p.Retry == nil || p.Retry.MaxAttempts == 0 {
+			return ""
+		}
+		return strconv.Itoa(p.Retry.MaxAttempts)
+	case "retry.maxBackoffMs":
+		if p.Retry == nil || p.Retry.MaxBackoffMs == 0 {
+			return ""
+		}
+		return strconv.Itoa(p.Retry.MaxBackoffMs)
+	case "retry.fallbackProvider":
+		if p.Retry == nil {
+			return ""
+		}
+		return p.Retry.FallbackProvider
+	case "promptCache.enabled":
+		if p.PromptCache == nil {
+			return ""
+		}
+		return strconv.FormatBool(!p.PromptCache.Disabled)
+	case "promptCache.breakpoints":
+		if p.PromptCache == nil {
+			return ""
+		}
+		return p.PromptCache.Breakpoints
+	case "reasoningEffort":
+		return p.ReasoningEffort
+	case "thinkingBudgetTokens":
+		if p.ThinkingBudgetTokens == 0 {
+			return ""
+		}
+		return strconv.Itoa(p.ThinkingBudgetTokens)
+	case "requestTimeoutMs", "connectTimeoutMs", "keepAliveSeconds":
+		if v := p.httpSettings().field(name); v != 0 {
+			return strconv.Itoa(v)
+		}
+		return ""
+	}
+	if header, ok := strings.CutPrefix(name, "header."); ok {
+		return p.Headers[header]
+	}
+	return p.ExtraConfig[name]
+}
+
+// retry returns the retry config, creating it if absent.
+func (p *ProviderConfig) retry() *RetryConfig {
+	if p.Retry == nil {
+		p.Retry = &RetryConfig{}
+	}
+	return p.Retry
+}
+
+// promptCache returns the prompt cache config, creating it if absent.
+func (p *ProviderConfig) promptCache() *PromptCacheConfig {
+	if p.PromptCache == nil {
+		p.PromptCache = &PromptCacheConfig{}
+	}
+	return p.PromptCache
+}
+
+// SetField stores a value for a generated config field name.
+func (p *ProviderConfig) SetField(name, value string) {
+	switch name {
+	case "apiKey":
+		p.APIKey = value
+	case "baseUrl":
+		p.BaseURL = value
+	case "embeddingsModel":
+		p.EmbeddingsModelID = value
+	case "retry.maxAttempts":
+		p.retry().MaxAttempts, _ = strconv.Atoi(value)
+	case "retry.maxBackoffMs":
+		p.retry().MaxBackoffMs, _ = strconv.Atoi(value)
+	case "retry.fallbackProvider":
+		p.retry().FallbackProvider = value
+	case "promptCache.enabled":
+		p.promptCache().Disabled = value == "false"
+	case "promptCache.breakpoints":
+		p.promptCache().Breakpoints = value
+	case "reasoningEffort":
+		p.ReasoningEffort = value
+	case "thinkingBudgetTokens":
+		p.ThinkingBudgetTokens, _ = strconv.Atoi(value)
+	case "requestTimeoutMs":
+		p.http().RequestTimeoutMs, _ = strconv.Atoi(value)
+	case "connectTimeoutMs":
+		p.http().ConnectTimeoutMs, _ = strconv.Atoi(value)
+	case "keepAliveSeconds":
+		p.http().KeepAliveSeconds, _ = strconv.Atoi(value)
+	default:
+		if header, ok := strings.CutPrefix(name, "header."); ok {
+			p.SetHeader(header, value)
+			return
+		}
+		if p.ExtraConfig == nil {
+			p.ExtraConfig = make(map[string]string)
+		}
+		p.ExtraConfig[name] = value
+	}
+}
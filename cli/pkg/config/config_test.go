@@ -0,0 +1,27 @@
+package config
+
+import "testing"
+
+func TestResolveModelAlias(t *testing.T) {
+	cfg := &ClineConfig{ModelAliases: map[string]string{
+		"fast":  "groq/llama-3.3-70b",
+		"smart": "openrouter/anthropic/claude-sonnet-4",
+		"local": "llama3",
+	}}
+	provider, model, ok := cfg.ResolveModelAlias("fast")
+	if !ok || provider != "groq" || model != "llama-3.3-70b" {
+		t.Errorf("fast = %q/%q ok=%v", provider, model, ok)
+	}
+	// Only the first slash separates the provider, so slashed model IDs work.
+	provider, model, ok = cfg.ResolveModelAlias("smart")
+	if !ok || provider != "openrouter" || model != "anthropic/claude-sonnet-4" {
+		t.Errorf("smart = %q/%q ok=%v", provider, model, ok)
+	}
+	provider, model, ok = cfg.ResolveModelAlias("local")
+	if !ok || provider != "" || model != "llama3" {
+		t.Errorf("local = %q/%q ok=%v", provider, model, ok)
+	}
+	if _, _, ok := cfg.ResolveModelAlias("gpt-4.1"); ok {
+		t.Error("non-alias resolved")
+	}
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HTTPConfig tunes the transport used for one provider's requests. All
+// values are optional; zero means the client default.
+type HTTPConfig struct {
+	// RequestTimeoutMs bounds a whole request including the response body.
+	RequestTimeoutMs int `json:"requestTimeoutMs,omitempty"`
+	// ConnectTimeoutMs bounds establishing the TCP connection, useful behind
+	// slow corporate proxies.
+	ConnectTimeoutMs int `json:"connectTimeoutMs,omitempty"`
+	// KeepAliveSeconds sets the TCP keepalive interval; long completions over
+	// aggressive NAT need this lower than the 30s default.
+	KeepAliveSeconds int `json:"keepAliveSeconds,omitempty"`
+}
+
+// http returns the HTTP config, creating it if absent.
+func (p *ProviderConfig) http() *HTTPConfig {
+	if p.HTTP == nil {
+		p.HTTP = &HTTPConfig{}
+	}
+	return p.HTTP
+}
+
+// httpSettings merges the typed HTTP config with legacy flat keys that older
+// configs carried in ExtraConfig.
+func (p *ProviderConfig) httpSettings() HTTPConfig {
+	var s HTTPConfig
+	if p.HTTP != nil {
+		s = *p.HTTP
+	}
+	fromExtra := func(dst *int, key string) {
+		if *dst == 0 {
+			if v, err := strconv.Atoi(p.ExtraConfig[key]); err == nil {
+				*dst = v
+			}
+		}
+	}
+	fromExtra(&s.RequestTimeoutMs, "requestTimeoutMs")
+	fromExtra(&s.ConnectTimeoutMs, "connectTimeoutMs")
+	fromExtra(&s.KeepAliveSeconds, "keepAliveSeconds")
+	return s
+}
+
+func (s HTTPConfig) field(name string) int {
+	switch name {
+	case "requestTimeoutMs":
+		return s.RequestTimeoutMs
+	case "connectTimeoutMs":
+		return s.ConnectTimeoutMs
+	case "keepAliveSeconds":
+		return s.KeepAliveSeconds
+	}
+	return 0
+}
+
+// SetHeader stores a custom header for this provider; an empty value removes
+// it.
+func (p *ProviderConfig) SetHeader(name, value string) {
+	if value == "" {
+		delete(p.Headers, name)
+		if len(p.Headers) == 0 {
+			p.Headers = nil
+		}
+		return
+	}
+	if p.Headers == nil {
+		p.Headers = make(map[string]string)
+	}
+	p.Headers[name] = value
+}
+
+// ApplyHeaders sets the entry's custom headers on an outgoing request. Callers
+// apply it after the standard headers, so a configured Authorization (gateway
+// auth) replaces the APIKey-derived one.
+func (p *ProviderConfig) ApplyHeaders(h http.Header) {
+	for name, value := range p.Headers {
+		h.Set(name, value)
+	}
+}
+
+// RequestTimeout returns the entry's request timeout, or fallback when the
+// entry does not set one.
+func (p *ProviderConfig) RequestTimeout(fallback time.Duration) time.Duration {
+	if ms := p.httpSettings().RequestTimeoutMs; ms > 0 {
+		return time.Duration(ms) * time.Millisecond
+	}
+	return fallback
+}
+
+// DialTransport builds a transport applying the entry's connect timeout and
+// keepalive settings. It returns nil when neither is set, so callers keep
+// their shared pooled transport on the common path.
+func (p *ProviderConfig) DialTransport() http.RoundTripper {
+	s := p.httpSettings()
+	if s.ConnectTimeoutMs == 0 && s.KeepAliveSeconds == 0 {
+		return nil
+	}
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	if s.ConnectTimeoutMs > 0 {
+		dialer.Timeout = time.Duration(s.ConnectTimeoutMs) * time.Millisecond
+	}
+	if s.KeepAliveSeconds > 0 {
+		dialer.KeepAlive = time.Duration(s.KeepAliveSeconds) * time.Second
+	}
+	return &http.Transport{
+		Proxy:       http.ProxyFromEnvironment,
+		DialContext: dialer.DialContext,
+	}
+}
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequestTimeoutPrefersTypedThenExtra(t *testing.T) {
+	entry := &ProviderConfig{}
+	if got := entry.RequestTimeout(time.Minute); got != time.Minute {
+		t.Errorf("default = %v", got)
+	}
+	entry.ExtraConfig = map[string]string{"requestTimeoutMs": "5000"}
+	if got := entry.RequestTimeout(time.Minute); got != 5*time.Second {
+		t.Errorf("extra = %v", got)
+	}
+	entry.HTTP = &HTTPConfig{RequestTimeoutMs: 250}
+	if got := entry.RequestTimeout(time.Minute); got != 250*time.Millisecond {
+		t.Errorf("typed = %v", got)
+	}
+}
+
+func TestHTTPFieldRouting(t *testing.T) {
+	entry := &ProviderConfig{}
+	entry.SetField("connectTimeoutMs", "1500")
+	entry.SetField("keepAliveSeconds", "10")
+	if entry.HTTP == nil || entry.HTTP.ConnectTimeoutMs != 1500 || entry.HTTP.KeepAliveSeconds != 10 {
+		t.Fatalf("HTTP = %+v", entry.HTTP)
+	}
+	if got := entry.Field("connectTimeoutMs"); got != "1500" {
+		t.Errorf("Field = %q", got)
+	}
+}
+
+func TestCustomHeaders(t *testing.T) {
+	entry := &ProviderConfig{APIKey: "sk-test"}
+	entry.SetField("header.X-Org-Id", "acme")
+	entry.SetHeader("Authorization", "Bearer gateway-token")
+	if got := entry.Field("header.X-Org-Id"); got != "acme" {
+		t.Errorf("Field = %q", got)
+	}
+
+	h := http.Header{}
+	h.Set("Authorization", "Bearer "+entry.APIKey)
+	entry.ApplyHeaders(h)
+	if got := h.Get("X-Org-Id"); got != "acme" {
+		t.Errorf("X-Org-Id = %q", got)
+	}
+	if got := h.Get("Authorization"); got != "Bearer gateway-token" {
+		t.Errorf("custom Authorization should win, got %q", got)
+	}
+
+	entry.SetHeader("X-Org-Id", "")
+	entry.SetHeader("Authorization", "")
+	if entry.Headers != nil {
+		t.Errorf("Headers = %v after removing all", entry.Headers)
+	}
+}
+
+func TestDialTransportOnlyWhenTuned(t *testing.T) {
+	entry := &ProviderConfig{}
+	if entry.DialTransport() != nil {
+		t.Error("untuned entry should keep the shared transport")
+	}
+	entry.HTTP = &HTTPConfig{ConnectTimeoutMs: 100}
+	if entry.DialTransport() == nil {
+		t.Error("tuned entry should get a custom transport")
+	}
+}
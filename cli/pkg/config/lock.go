@@ -0,0 +1,102 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// lockRetryInterval is how often lock acquisition is retried.
+	lockRetryInterval = 50 * time.Millisecond
+	// lockStaleAfter is the age past which a lock left behind by a crashed
+	// process is considered stale and broken.
+	lockStaleAfter = 30 * time.Second
+)
+
+// lockPath returns the lock file guarding this manager's config.
+func (m *ConfigManager) lockPath() string {
+	return m.path + ".lock"
+}
+
+// acquireLock takes the advisory lock on the config file, waiting up to
+// timeout. The lock is a sibling file created exclusively and holding the
+// owner PID, so two concurrent `cline auth` runs serialize their
+// read-modify-write cycles instead of clobbering each other.
+func (m *ConfigManager) acquireLock(timeout time.Duration) (release func(), err error) {
+	path := m.lockPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, err
+	}
+	deadline := time.Now().Add(timeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			fmt.Fprintf(f, "%d %d\n", os.Getpid(), time.Now().Unix())
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("locking config: %w", err)
+		}
+		if lockIsStale(path) {
+			// Break the stale lock and retry immediately; racing breakers are
+			// harmless since the O_EXCL create still decides the winner.
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("config is locked by another cline process (remove %s if that process is gone)", path)
+		}
+		time.Sleep(lockRetryInterval)
+	}
+}
+
+// lockIsStale reports whether the lock file was abandoned: its owner PID is
+// gone, or it is older than lockStaleAfter.
+func lockIsStale(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if time.Since(info.ModTime()) > lockStaleAfter {
+		return true
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	fields := strings.Fields(string(data))
+	if len(fields) == 0 {
+		return false
+	}
+	pid, err := strconv.Atoi(fields[0])
+	if err != nil || pid <= 0 {
+		return false
+	}
+	return !processAlive(pid)
+}
+
+// Update runs a locked read-modify-write cycle: acquire the lock, reload the
+// config from disk, apply fn, and save. Callers mutating the config in
+// response to a command should prefer this over Load+Save so concurrent
+// invocations don't lose writes.
+func (m *ConfigManager) Update(fn func(*ClineConfig) error) error {
+	release, err := m.acquireLock(5 * time.Second)
+	if err != nil {
+		return err
+	}
+	defer release()
+	cfg, err := m.Load()
+	if err != nil {
+		return err
+	}
+	if err := fn(cfg); err != nil {
+		return err
+	}
+	return m.Save(cfg)
+}
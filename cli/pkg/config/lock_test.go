@@ -0,0 +1,63 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestUpdateSerializesConcurrentWriters(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	const writers = 8
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m := NewConfigManagerAt(path)
+			err := m.Update(func(cfg *ClineConfig) error {
+				n, _ := strconv.Atoi(cfg.Settings["counter"])
+				cfg.Settings["counter"] = strconv.Itoa(n + 1)
+				return nil
+			})
+			if err != nil {
+				t.Errorf("writer %d: %v", i, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	cfg, err := NewConfigManagerAt(path).Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Settings["counter"]; got != strconv.Itoa(writers) {
+		t.Errorf("counter = %s, want %d (lost updates)", got, writers)
+	}
+}
+
+func TestStaleLockIsBroken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	m := NewConfigManagerAt(path)
+	// Simulate a crashed process: a lock owned by a PID that cannot exist.
+	lock := path + ".lock"
+	if err := os.WriteFile(lock, []byte(fmt.Sprintf("%d %d\n", 1<<30, time.Now().Unix())), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- m.Update(func(cfg *ClineConfig) error { return nil })
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Update did not break stale lock: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Update hung on stale lock")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build windows
+
+package config
+
+import "os"
+
+// processAlive reports whether a process with the given PID exists. On
+// Windows FindProcess only succeeds for live processes.
+func processAlive(pid int) bool {
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	proc.Release()
+	return true
+}
@@ -0,0 +1,161 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ConfigManager loads and saves the CLI config file. Saves are atomic: the
+// config is written to a temp file in the same directory and renamed over the
+// old one, so a crash never leaves a half-written config.
+type ConfigManager struct {
+	path   string
+	config *ClineConfig
+	state  dirtyState
+	// preSave, when set, runs before each Save and can veto it by returning
+	// an error. The CLI layer uses it to fire the pre-config-save hook.
+	preSave func(*ClineConfig) error
+	// dryRunReport, when set, replaces every Save: instead of writing, the
+	// old and would-be new file contents are handed to the callback.
+	dryRunReport func(oldData, newData []byte)
+	// ephemeral keeps the config entirely in memory: Load starts from an
+	// empty config and Save never touches disk.
+	ephemeral bool
+}
+
+// SetPreSaveHook registers a callback invoked before every Save; a non-nil
+// return aborts the save.
+func (m *ConfigManager) SetPreSaveHook(fn func(*ClineConfig) error) {
+	m.preSave = fn
+}
+
+// SetDryRunReport puts the manager in dry-run mode: saves no longer touch
+// disk and the callback receives the current and would-be file contents so
+// the caller can show the exact change. The in-memory config still advances,
+// keeping multi-step commands coherent.
+func (m *ConfigManager) SetDryRunReport(fn func(oldData, newData []byte)) {
+	m.dryRunReport = fn
+}
+
+// NewConfigManager returns a manager over the default config path.
+func NewConfigManager() (*ConfigManager, error) {
+	path, err := GetConfigPath()
+	if err != nil {
+		return nil, err
+	}
+	return NewConfigManagerAt(LongPathSafe(path)), nil
+}
+
+// NewConfigManagerAt returns a manager over an explicit config path; used by
+// tests and by commands honoring a config-path override.
+func NewConfigManagerAt(path string) *ConfigManager {
+	return &ConfigManager{path: path}
+}
+
+// NewEphemeralManager returns a manager that never reads or writes the
+// config file: it starts empty and changes live only in this process. Used
+// by the global --ephemeral flag for demos, shared machines and CI jobs.
+func NewEphemeralManager() *ConfigManager {
+	return &ConfigManager{ephemeral: true, config: NewClineConfig()}
+}
+
+// Path returns the config file path this manager reads and writes; empty for
+// an ephemeral manager.
+func (m *ConfigManager) Path() string { return m.path }
+
+// Load reads the config from disk, returning an empty config if the file does
+// not exist yet.
+func (m *ConfigManager) Load() (*ClineConfig, error) {
+	if m.ephemeral {
+		if m.config == nil {
+			m.config = NewClineConfig()
+		}
+		return m.config, nil
+	}
+	data, err := os.ReadFile(m.path)
+	if errors.Is(err, os.ErrNotExist) {
+		m.config = NewClineConfig()
+		return m.config, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config: %w", err)
+	}
+	cfg := NewClineConfig()
+	if err := json.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", m.path, err)
+	}
+	m.config = cfg
+	return cfg, nil
+}
+
+// Config returns the last loaded config, loading it on first use.
+func (m *ConfigManager) Config() (*ClineConfig, error) {
+	if m.config != nil {
+		return m.config, nil
+	}
+	return m.Load()
+}
+
+// Save writes the config atomically with owner-only permissions.
+func (m *ConfigManager) Save(cfg *ClineConfig) error {
+	if m.ephemeral {
+		// Nothing reaches disk, so there is no save for the pre-save hook to
+		// guard either.
+		m.config = cfg
+		return nil
+	}
+	if m.dryRunReport != nil {
+		newData, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			return err
+		}
+		// The pre-save hook is skipped too: hooks are user scripts and a dry
+		// run must not trigger their side effects.
+		oldData, _ := os.ReadFile(m.path)
+		m.dryRunReport(oldData, append(newData, '\n'))
+		m.config = cfg
+		return nil
+	}
+	if m.preSave != nil {
+		if err := m.preSave(cfg); err != nil {
+			return err
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(m.path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(m.path), ".config-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if err := tmp.Chmod(0o600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), m.path); err != nil {
+		return fmt.Errorf("saving config: %w", err)
+	}
+	// NTFS ignores the Unix mode set above; restate owner-only access in
+	// platform terms.
+	if err := HardenFile(m.path); err != nil {
+		return err
+	}
+	m.config = cfg
+	m.clearDirty()
+	return nil
+}
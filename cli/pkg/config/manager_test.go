@@ -0,0 +1,82 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDryRunReportSuppressesWrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	m := NewConfigManagerAt(path)
+	if err := m.Update(func(cfg *ClineConfig) error {
+		cfg.DefaultProvider = "openai"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	var gotOld, gotNew []byte
+	m.SetDryRunReport(func(oldData, newData []byte) {
+		gotOld, gotNew = oldData, newData
+	})
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := m.Update(func(cfg *ClineConfig) error {
+		cfg.DefaultProvider = "anthropic"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Error("dry run wrote to disk")
+	}
+	if !bytes.Equal(gotOld, before) {
+		t.Errorf("old = %q", gotOld)
+	}
+	if !bytes.Contains(gotNew, []byte("anthropic")) {
+		t.Errorf("new = %q", gotNew)
+	}
+	// The in-memory view advances so multi-step commands stay coherent.
+	cfg, err := m.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultProvider != "anthropic" {
+		t.Errorf("in-memory DefaultProvider = %q", cfg.DefaultProvider)
+	}
+}
+
+func TestEphemeralManagerNeverWritesDisk(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("CLINE_CONFIG_DIR", dir)
+	m := NewEphemeralManager()
+	if err := m.Update(func(cfg *ClineConfig) error {
+		cfg.DefaultProvider = "openai"
+		cfg.Provider("openai").APIKey = "sk-demo"
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := m.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.DefaultProvider != "openai" {
+		t.Errorf("DefaultProvider = %q", cfg.DefaultProvider)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("ephemeral update created files: %v", entries)
+	}
+}
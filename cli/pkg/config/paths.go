@@ -0,0 +1,141 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// GetConfigDir returns the directory holding the CLI's configuration, hooks,
+// and prompts, creating nothing. Resolution order: $CLINE_CONFIG_DIR, a
+// legacy ~/.cline directory when one exists, then $XDG_CONFIG_HOME/cline
+// (~/.config/cline by default).
+func GetConfigDir() (string, error) {
+	if dir := os.Getenv("CLINE_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	legacy, err := legacyConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if dirExists(legacy) {
+		return legacy, nil
+	}
+	return xdgDir("XDG_CONFIG_HOME", ".config")
+}
+
+// GetDataDir returns the directory for generated data: usage stats, task
+// transcripts, recents, and catalog caches. Resolution mirrors GetConfigDir
+// but lands in $XDG_DATA_HOME/cline (~/.local/share/cline by default) for
+// users without a legacy directory.
+func GetDataDir() (string, error) {
+	if dir := os.Getenv("CLINE_CONFIG_DIR"); dir != "" {
+		return dir, nil
+	}
+	legacy, err := legacyConfigDir()
+	if err != nil {
+		return "", err
+	}
+	if dirExists(legacy) {
+		return legacy, nil
+	}
+	return xdgDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+}
+
+// GetConfigPath returns the path of the config file itself.
+func GetConfigPath() (string, error) {
+	dir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.json"), nil
+}
+
+func legacyConfigDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cline"), nil
+}
+
+func dirExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+func xdgDir(envVar, fallback string) (string, error) {
+	if base := os.Getenv(envVar); base != "" {
+		return filepath.Join(base, "cline"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, fallback, "cline"), nil
+}
+
+// configEntries are the names inside a legacy ~/.cline that belong in the
+// config dir after migration: the things a user edits by hand. Everything
+// else is generated data and defaults to the data dir, so new state files
+// migrate correctly without this list knowing about them.
+var configEntries = map[string]bool{
+	"config.json":  true,
+	"hooks":        true,
+	"instructions": true,
+	"prompts":      true,
+}
+
+// MigrateLegacyDir moves an existing ~/.cline directory to the XDG
+// locations and describes each move. There is nothing to do when no legacy
+// directory exists, and nothing sensible to do while CLINE_CONFIG_DIR pins
+// everything to one directory. Migration stops (leaving the rest in place)
+// rather than overwrite a file that already exists at a destination.
+func MigrateLegacyDir() ([]string, error) {
+	if os.Getenv("CLINE_CONFIG_DIR") != "" {
+		return nil, fmt.Errorf("CLINE_CONFIG_DIR is set; unset it to migrate the legacy directory")
+	}
+	legacy, err := legacyConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	if !dirExists(legacy) {
+		return nil, nil
+	}
+	configDir, err := xdgDir("XDG_CONFIG_HOME", ".config")
+	if err != nil {
+		return nil, err
+	}
+	dataDir, err := xdgDir("XDG_DATA_HOME", filepath.Join(".local", "share"))
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(legacy)
+	if err != nil {
+		return nil, err
+	}
+	var moved []string
+	for _, entry := range entries {
+		dest := dataDir
+		if configEntries[entry.Name()] {
+			dest = configDir
+		}
+		if err := os.MkdirAll(dest, 0o700); err != nil {
+			return moved, err
+		}
+		src := filepath.Join(legacy, entry.Name())
+		target := filepath.Join(dest, entry.Name())
+		if _, err := os.Stat(target); err == nil {
+			return moved, fmt.Errorf("%s already exists; move %s aside by hand and rerun", target, src)
+		}
+		if err := os.Rename(src, target); err != nil {
+			return moved, err
+		}
+		moved = append(moved, fmt.Sprintf("%s -> %s", src, target))
+	}
+	// The emptied legacy dir must go, or it would keep winning resolution.
+	if err := os.Remove(legacy); err != nil {
+		return moved, err
+	}
+	return moved, nil
+}
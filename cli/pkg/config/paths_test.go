@@ -0,0 +1,112 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestConfigDirResolution(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("CLINE_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+
+	if dir, err := GetConfigDir(); err != nil || dir != filepath.Join(home, ".config", "cline") {
+		t.Errorf("default config dir = %q, %v", dir, err)
+	}
+	if dir, err := GetDataDir(); err != nil || dir != filepath.Join(home, ".local", "share", "cline") {
+		t.Errorf("default data dir = %q, %v", dir, err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "xdg-config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "xdg-data"))
+	if dir, _ := GetConfigDir(); dir != filepath.Join(home, "xdg-config", "cline") {
+		t.Errorf("XDG config dir = %q", dir)
+	}
+	if dir, _ := GetDataDir(); dir != filepath.Join(home, "xdg-data", "cline") {
+		t.Errorf("XDG data dir = %q", dir)
+	}
+
+	// A legacy ~/.cline beats XDG so existing setups keep working.
+	legacy := filepath.Join(home, ".cline")
+	if err := os.MkdirAll(legacy, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if dir, _ := GetConfigDir(); dir != legacy {
+		t.Errorf("legacy config dir = %q", dir)
+	}
+	if dir, _ := GetDataDir(); dir != legacy {
+		t.Errorf("legacy data dir = %q", dir)
+	}
+
+	// CLINE_CONFIG_DIR beats everything.
+	pinned := filepath.Join(home, "pinned")
+	t.Setenv("CLINE_CONFIG_DIR", pinned)
+	if dir, _ := GetConfigDir(); dir != pinned {
+		t.Errorf("pinned config dir = %q", dir)
+	}
+	if dir, _ := GetDataDir(); dir != pinned {
+		t.Errorf("pinned data dir = %q", dir)
+	}
+}
+
+func TestMigrateLegacyDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("CLINE_CONFIG_DIR", "")
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+
+	legacy := filepath.Join(home, ".cline")
+	for _, dir := range []string{"tasks", "hooks", "history"} {
+		if err := os.MkdirAll(filepath.Join(legacy, dir), 0o700); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range []string{"config.json", "stats.jsonl", "usage.jsonl", "history.key"} {
+		if err := os.WriteFile(filepath.Join(legacy, name), []byte("{}"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	moved, err := MigrateLegacyDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(moved) != 7 {
+		t.Errorf("moved = %v", moved)
+	}
+	for _, name := range []string{"config.json", "hooks"} {
+		if _, err := os.Stat(filepath.Join(home, ".config", "cline", name)); err != nil {
+			t.Errorf("%s not in config dir: %v", name, err)
+		}
+	}
+	// Unlisted entries default to the data dir, so state files added after
+	// this list was written still migrate next to their readers.
+	for _, name := range []string{"stats.jsonl", "tasks", "usage.jsonl", "history", "history.key"} {
+		if _, err := os.Stat(filepath.Join(home, ".local", "share", "cline", name)); err != nil {
+			t.Errorf("%s not in data dir: %v", name, err)
+		}
+	}
+	if dirExists(legacy) {
+		t.Error("legacy dir still exists")
+	}
+	if dir, _ := GetConfigDir(); dir != filepath.Join(home, ".config", "cline") {
+		t.Errorf("post-migration config dir = %q", dir)
+	}
+
+	// A second run finds nothing to do.
+	if moved, err := MigrateLegacyDir(); err != nil || moved != nil {
+		t.Errorf("rerun = %v, %v", moved, err)
+	}
+}
+
+func TestMigrateLegacyDirRefusesWhenPinned(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("CLINE_CONFIG_DIR", "/tmp/pinned")
+	if _, err := MigrateLegacyDir(); err == nil {
+		t.Error("pinned migration did not error")
+	}
+}
@@ -0,0 +1,38 @@
+//go:build !windows
+
+package config
+
+import (
+	"fmt"
+	"os"
+)
+
+// HardenFile restricts a secret-bearing file to its owner. On Unix that is
+// the 0600 permission bits.
+func HardenFile(path string) error {
+	return os.Chmod(path, 0o600)
+}
+
+// CheckPrivate reports an error when a secret-bearing path is accessible to
+// other users.
+func CheckPrivate(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if mode := info.Mode().Perm(); mode&0o077 != 0 {
+		return fmt.Errorf("%s has mode %04o; remove group/world access (chmod go-rwx)", path, mode)
+	}
+	return nil
+}
+
+// LongPathSafe returns the path unchanged; only Windows needs the
+// extended-length path syntax.
+func LongPathSafe(path string) string { return path }
+
+// ProtectSecret and UnprotectSecret are DPAPI wrappers on Windows. On Unix
+// file permissions are the protection boundary, so data passes through.
+func ProtectSecret(data []byte) ([]byte, error) { return data, nil }
+
+// UnprotectSecret is the inverse of ProtectSecret.
+func UnprotectSecret(data []byte) ([]byte, error) { return data, nil }
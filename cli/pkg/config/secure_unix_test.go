@@ -0,0 +1,36 @@
+//go:build !windows
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckPrivateAndHardenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckPrivate(path); err == nil {
+		t.Error("world-readable file passed CheckPrivate")
+	}
+	if err := HardenFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := CheckPrivate(path); err != nil {
+		t.Errorf("hardened file failed CheckPrivate: %v", err)
+	}
+}
+
+func TestProtectSecretPassThrough(t *testing.T) {
+	enc, err := ProtectSecret([]byte("sk-test"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dec, err := UnprotectSecret(enc)
+	if err != nil || string(dec) != "sk-test" {
+		t.Errorf("round-trip = %q, %v", dec, err)
+	}
+}
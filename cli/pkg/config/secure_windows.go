@@ -0,0 +1,117 @@
+//go:build windows
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+// HardenFile restricts a secret-bearing file to its owner. Unix permission
+// bits do not map onto NTFS, so the ACL is reset to a single owner-only
+// grant with inheritance removed.
+func HardenFile(path string) error {
+	user := os.Getenv("USERNAME")
+	if user == "" {
+		return fmt.Errorf("USERNAME is not set; cannot build an owner-only ACL")
+	}
+	cmd := exec.Command("icacls", path, "/inheritance:r", "/grant:r", user+":F")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("icacls: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// broadGrants are ACL principals that give access beyond the owner and the
+// system accounts.
+var broadGrants = []string{"Everyone:", "BUILTIN\\Users:", "Authenticated Users:"}
+
+// CheckPrivate reports an error when the path's ACL grants access beyond the
+// owner and the system accounts.
+func CheckPrivate(path string) error {
+	out, err := exec.Command("icacls", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("icacls: %v", err)
+	}
+	for _, grant := range broadGrants {
+		if strings.Contains(string(out), grant) {
+			return fmt.Errorf("%s is granted to %s; run `cline doctor` and re-save the config to tighten it", path, strings.TrimSuffix(grant, ":"))
+		}
+	}
+	return nil
+}
+
+// LongPathSafe rewrites long absolute paths in extended-length syntax so
+// config files under deep home directories keep working past the
+// 260-character MAX_PATH limit.
+func LongPathSafe(path string) string {
+	if len(path) < 248 || strings.HasPrefix(path, `\\?\`) || !filepath.IsAbs(path) {
+		return path
+	}
+	return `\\?\` + path
+}
+
+var (
+	crypt32       = syscall.NewLazyDLL("crypt32.dll")
+	kernel32      = syscall.NewLazyDLL("kernel32.dll")
+	procProtect   = crypt32.NewProc("CryptProtectData")
+	procUnprotect = crypt32.NewProc("CryptUnprotectData")
+	procLocalFree = kernel32.NewProc("LocalFree")
+)
+
+// cryptprotectUIForbidden keeps DPAPI from ever raising a dialog; the CLI
+// may be running without a desktop session.
+const cryptprotectUIForbidden = 0x1
+
+// dataBlob is the Win32 DATA_BLOB structure.
+type dataBlob struct {
+	cbData uint32
+	pbData *byte
+}
+
+func newBlob(d []byte) *dataBlob {
+	if len(d) == 0 {
+		return &dataBlob{}
+	}
+	return &dataBlob{cbData: uint32(len(d)), pbData: &d[0]}
+}
+
+func (b *dataBlob) copyAndFree() []byte {
+	defer procLocalFree.Call(uintptr(unsafe.Pointer(b.pbData)))
+	out := make([]byte, b.cbData)
+	copy(out, unsafe.Slice(b.pbData, b.cbData))
+	return out
+}
+
+// ProtectSecret encrypts data with DPAPI under the current user account,
+// the fallback protection when no credential manager entry is possible.
+func ProtectSecret(data []byte) ([]byte, error) {
+	var out dataBlob
+	r, _, err := procProtect.Call(
+		uintptr(unsafe.Pointer(newBlob(data))), 0, 0, 0, 0,
+		cryptprotectUIForbidden,
+		uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return nil, fmt.Errorf("CryptProtectData: %v", err)
+	}
+	return out.copyAndFree(), nil
+}
+
+// UnprotectSecret decrypts data produced by ProtectSecret. It only succeeds
+// under the same user account that protected it.
+func UnprotectSecret(data []byte) ([]byte, error) {
+	var out dataBlob
+	r, _, err := procUnprotect.Call(
+		uintptr(unsafe.Pointer(newBlob(data))), 0, 0, 0, 0,
+		cryptprotectUIForbidden,
+		uintptr(unsafe.Pointer(&out)))
+	if r == 0 {
+		return nil, fmt.Errorf("CryptUnprotectData: %v", err)
+	}
+	return out.copyAndFree(), nil
+}
@@ -0,0 +1,110 @@
+package config
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// watchInterval is how often the Service checks the config file for outside
+// changes. Polling keeps the CLI dependency-free; at 1s it is cheap and more
+// than fast enough for config edits.
+const watchInterval = time.Second
+
+// Service owns a single loaded config for long-running modes (chat/task
+// REPLs, serve). It loads once, watches the file for changes, and hands out
+// consistent snapshots, so packages stop constructing their own
+// ConfigManager and re-reading the file per call.
+type Service struct {
+	manager *ConfigManager
+
+	mu       sync.RWMutex
+	snapshot *ClineConfig
+	mtime    time.Time
+
+	stopOnce sync.Once
+	stop     chan struct{}
+	changed  chan struct{}
+}
+
+// NewService loads the config once and starts watching for changes.
+func NewService(manager *ConfigManager) (*Service, error) {
+	cfg, err := manager.Load()
+	if err != nil {
+		return nil, err
+	}
+	s := &Service{
+		manager:  manager,
+		snapshot: cfg,
+		mtime:    fileMTime(manager.Path()),
+		stop:     make(chan struct{}),
+		changed:  make(chan struct{}, 1),
+	}
+	go s.watch()
+	return s, nil
+}
+
+// Snapshot returns the current config. The returned value is shared and must
+// be treated as read-only; it stays internally consistent even if the file
+// changes, because reloads swap in a freshly decoded config.
+func (s *Service) Snapshot() *ClineConfig {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.snapshot
+}
+
+// Changed returns a channel that receives a signal after each reload.
+// Notifications are coalesced; a slow consumer sees at least one.
+func (s *Service) Changed() <-chan struct{} {
+	return s.changed
+}
+
+// Close stops the watcher.
+func (s *Service) Close() {
+	s.stopOnce.Do(func() { close(s.stop) })
+}
+
+func (s *Service) watch() {
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.reloadIfChanged()
+		}
+	}
+}
+
+func (s *Service) reloadIfChanged() {
+	mtime := fileMTime(s.manager.Path())
+	s.mu.RLock()
+	unchanged := mtime.Equal(s.mtime)
+	s.mu.RUnlock()
+	if unchanged {
+		return
+	}
+	cfg, err := s.manager.Load()
+	if err != nil {
+		// Keep serving the last good snapshot; a partially written or
+		// invalid file must not take down a long-running session.
+		return
+	}
+	s.mu.Lock()
+	s.snapshot = cfg
+	s.mtime = mtime
+	s.mu.Unlock()
+	select {
+	case s.changed <- struct{}{}:
+	default:
+	}
+}
+
+func fileMTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
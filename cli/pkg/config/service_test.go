@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestServiceReloadsOnFileChange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	m := NewConfigManagerAt(path)
+	cfg, err := m.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Provider("openai").ModelID = "gpt-4.1"
+	if err := m.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	svc, err := NewService(NewConfigManagerAt(path))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer svc.Close()
+	if got := svc.Snapshot().Providers["openai"].ModelID; got != "gpt-4.1" {
+		t.Fatalf("initial snapshot model = %q", got)
+	}
+
+	// An outside writer changes the file; ensure the mtime moves even on
+	// coarse-grained filesystems.
+	cfg.Providers["openai"].ModelID = "o4-mini"
+	if err := m.Save(cfg); err != nil {
+		t.Fatal(err)
+	}
+	future := time.Now().Add(2 * time.Second)
+	if err := os.Chtimes(path, future, future); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-svc.Changed():
+	case <-time.After(5 * time.Second):
+		t.Fatal("service never noticed the config change")
+	}
+	if got := svc.Snapshot().Providers["openai"].ModelID; got != "o4-mini" {
+		t.Errorf("snapshot after reload model = %q, want o4-mini", got)
+	}
+}
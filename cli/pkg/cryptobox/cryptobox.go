@@ -0,0 +1,181 @@
+// Package cryptobox seals small documents with a passphrase, for settings
+// that leave the machine (cloud sync, shared snapshots). The format is
+// self-describing: AES-256-GCM with a key derived by PBKDF2-HMAC-SHA256, so
+// only someone holding the passphrase — not the service storing the blob —
+// can read the contents.
+package cryptobox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// magic identifies sealed blobs and versions the format.
+var magic = []byte("cbox1")
+
+// defaultIterations is the PBKDF2 work factor for new blobs.
+const defaultIterations = 600_000
+
+// maxIterations caps what Open accepts, so a crafted blob cannot pin the CPU.
+const maxIterations = 10_000_000
+
+const (
+	saltSize  = 16
+	nonceSize = 12
+	keySize   = 32
+)
+
+// Seal encrypts plaintext under a passphrase-derived key. The blob embeds
+// everything needed to decrypt except the passphrase.
+func Seal(passphrase string, plaintext []byte) ([]byte, error) {
+	if passphrase == "" {
+		return nil, fmt.Errorf("passphrase must not be empty")
+	}
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt, defaultIterations)
+	if err != nil {
+		return nil, err
+	}
+	box := make([]byte, 0, len(magic)+4+saltSize+nonceSize+len(plaintext)+aead.Overhead())
+	box = append(box, magic...)
+	box = binary.BigEndian.AppendUint32(box, defaultIterations)
+	box = append(box, salt...)
+	box = append(box, nonce...)
+	return aead.Seal(box, nonce, plaintext, magic), nil
+}
+
+// Open decrypts a blob produced by Seal. A wrong passphrase and a tampered
+// blob are indistinguishable by design; both fail authentication.
+func Open(passphrase string, box []byte) ([]byte, error) {
+	header := len(magic) + 4 + saltSize + nonceSize
+	if len(box) < header || string(box[:len(magic)]) != string(magic) {
+		return nil, fmt.Errorf("not a sealed document")
+	}
+	iterations := binary.BigEndian.Uint32(box[len(magic):])
+	if iterations == 0 || iterations > maxIterations {
+		return nil, fmt.Errorf("sealed document asks for an unreasonable work factor")
+	}
+	salt := box[len(magic)+4 : len(magic)+4+saltSize]
+	nonce := box[len(magic)+4+saltSize : header]
+	aead, err := newAEAD(passphrase, salt, int(iterations))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, box[header:], magic)
+	if err != nil {
+		return nil, fmt.Errorf("wrong passphrase or tampered document")
+	}
+	return plaintext, nil
+}
+
+func newAEAD(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(pbkdf2Key([]byte(passphrase), salt, iterations, keySize))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// pbkdf2Key is PBKDF2 (RFC 8018) with HMAC-SHA256, implemented here to keep
+// the module dependency-free.
+func pbkdf2Key(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	hashLen := prf.Size()
+	blocks := (keyLen + hashLen - 1) / hashLen
+	key := make([]byte, 0, blocks*hashLen)
+	var block [4]byte
+	for i := 1; i <= blocks; i++ {
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(block[:], uint32(i))
+		prf.Write(block[:])
+		u := prf.Sum(nil)
+		out := make([]byte, len(u))
+		copy(out, u)
+		for n := 1; n < iterations; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for x := range out {
+				out[x] ^= u[x]
+			}
+		}
+		key = append(key, out...)
+	}
+	return key[:keyLen]
+}
+
+// keyMagic identifies blobs sealed with a raw key instead of a passphrase,
+// for data that never leaves the machine and whose key lives in a local
+// file: no derivation work factor is needed.
+var keyMagic = []byte("cboxk1")
+
+// KeySize is the raw key length SealWithKey expects.
+const KeySize = keySize
+
+// NewKey returns a fresh random key for SealWithKey.
+func NewKey() ([]byte, error) {
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// SealWithKey encrypts plaintext under a raw 32-byte key. The blob is
+// keyMagic || nonce || ciphertext.
+func SealWithKey(key, plaintext []byte) ([]byte, error) {
+	aead, err := newKeyAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	box := make([]byte, 0, len(keyMagic)+nonceSize+len(plaintext)+aead.Overhead())
+	box = append(box, keyMagic...)
+	box = append(box, nonce...)
+	return aead.Seal(box, nonce, plaintext, keyMagic), nil
+}
+
+// OpenWithKey decrypts a blob produced by SealWithKey.
+func OpenWithKey(key, box []byte) ([]byte, error) {
+	header := len(keyMagic) + nonceSize
+	if len(box) < header || string(box[:len(keyMagic)]) != string(keyMagic) {
+		return nil, fmt.Errorf("not a sealed document")
+	}
+	aead, err := newKeyAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := box[len(keyMagic):header]
+	plaintext, err := aead.Open(nil, nonce, box[header:], keyMagic)
+	if err != nil {
+		return nil, fmt.Errorf("wrong key or tampered document")
+	}
+	return plaintext, nil
+}
+
+func newKeyAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, fmt.Errorf("key must be %d bytes", keySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,83 @@
+package cryptobox
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSealOpenRoundTrip(t *testing.T) {
+	plaintext := []byte(`{"providers":{"openai":{"apiKey":"sk-test"}}}`)
+	box, err := Seal("correct horse", plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(box, []byte("sk-test")) {
+		t.Fatal("plaintext visible in sealed blob")
+	}
+	got, err := Open("correct horse", box)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, plaintext) {
+		t.Errorf("round trip = %q", got)
+	}
+
+	if _, err := Open("wrong passphrase", box); err == nil {
+		t.Error("wrong passphrase accepted")
+	}
+	box[len(box)-1] ^= 0xff
+	if _, err := Open("correct horse", box); err == nil {
+		t.Error("tampered blob accepted")
+	}
+}
+
+func TestOpenRejectsGarbage(t *testing.T) {
+	if _, err := Open("p", []byte("not a box")); err == nil {
+		t.Error("garbage accepted")
+	}
+	if _, err := Seal("", []byte("x")); err == nil {
+		t.Error("empty passphrase accepted")
+	}
+}
+
+// TestPBKDF2Vector pins the derivation against RFC 6070's test vectors
+// adapted to SHA-256 (from RFC 7914 appendix B).
+func TestPBKDF2Vector(t *testing.T) {
+	got := pbkdf2Key([]byte("passwd"), []byte("salt"), 1, 8)
+	want := []byte{0x55, 0xac, 0x04, 0x6e, 0x56, 0xe3, 0x08, 0x9f}
+	if !bytes.Equal(got, want) {
+		t.Errorf("pbkdf2 = %x, want %x", got, want)
+	}
+	got = pbkdf2Key([]byte("Password"), []byte("NaCl"), 80000, 8)
+	want = []byte{0x4d, 0xdc, 0xd8, 0xf6, 0x0b, 0x98, 0xbe, 0x21}
+	if !bytes.Equal(got, want) {
+		t.Errorf("pbkdf2 = %x, want %x", got, want)
+	}
+}
+
+func TestSealOpenWithKey(t *testing.T) {
+	key, err := NewKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	box, err := SealWithKey(key, []byte("local transcript"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext, err := OpenWithKey(key, box)
+	if err != nil || string(plaintext) != "local transcript" {
+		t.Fatalf("OpenWithKey = %q, %v", plaintext, err)
+	}
+	other, _ := NewKey()
+	if _, err := OpenWithKey(other, box); err == nil {
+		t.Error("wrong key did not fail")
+	}
+	tampered := append([]byte(nil), box...)
+	tampered[len(tampered)-1] ^= 1
+	if _, err := OpenWithKey(key, tampered); err == nil {
+		t.Error("tampered blob did not fail")
+	}
+	if _, err := SealWithKey([]byte("short"), nil); err == nil {
+		t.Error("short key did not fail")
+	}
+}
@@ -0,0 +1,229 @@
+// Package currency formats USD cost figures in the user's currency and
+// locale. Provider prices are always USD internally; conversion happens only
+// at display time, using FX rates cached in the data directory so offline
+// use keeps working with the last known rates.
+package currency
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+const (
+	// SettingCurrency is the settings key for the display currency, an ISO
+	// 4217 code such as "EUR" or "JPY". Empty means USD, no conversion.
+	SettingCurrency = "cost.currency"
+	// SettingLocale is the settings key for the number format: a language
+	// tag such as "de" or "fr-FR"; only the language part is used.
+	SettingLocale = "cost.locale"
+)
+
+// ratesURL serves USD-based rates for every supported currency without an
+// API key.
+const ratesURL = "https://open.er-api.com/v6/latest/USD"
+
+// ratesTTL is how long cached rates are considered fresh. Stale rates are
+// still used when a refresh fails; costs are estimates either way.
+const ratesTTL = 24 * time.Hour
+
+// httpClient carries rate fetches so tests can install their own transport.
+var httpClient = &http.Client{Timeout: 10 * time.Second}
+
+// SetHTTPTransport swaps the transport used for rate fetches; nil restores
+// the default.
+func SetHTTPTransport(rt http.RoundTripper) {
+	httpClient.Transport = rt
+}
+
+// localeSpec is the part of a locale that matters for money: the decimal
+// separator and the thousands grouping separator.
+type localeSpec struct {
+	decimal string
+	group   string
+}
+
+// locales covers the common cases by language; anything unlisted falls back
+// to "en". A full CLDR dependency is not worth it for one number format.
+var locales = map[string]localeSpec{
+	"en": {decimal: ".", group: ","},
+	"de": {decimal: ",", group: "."},
+	"es": {decimal: ",", group: "."},
+	"it": {decimal: ",", group: "."},
+	"nl": {decimal: ",", group: "."},
+	"pt": {decimal: ",", group: "."},
+	"fr": {decimal: ",", group: " "},
+	"ja": {decimal: ".", group: ","},
+	"ko": {decimal: ".", group: ","},
+	"zh": {decimal: ".", group: ","},
+}
+
+// symbols maps currency codes to their usual sign; unlisted codes are
+// rendered as "CODE amount".
+var symbols = map[string]string{
+	"USD": "$",
+	"EUR": "€",
+	"GBP": "£",
+	"JPY": "¥",
+	"KRW": "₩",
+	"INR": "₹",
+	"CNY": "¥",
+}
+
+// zeroDecimal lists currencies that are not subdivided; fractional digits
+// would be noise.
+var zeroDecimal = map[string]bool{"JPY": true, "KRW": true}
+
+// Formatter converts and formats USD amounts. The zero value formats plain
+// USD the way the CLI always has.
+type Formatter struct {
+	currency string
+	rate     float64
+	locale   localeSpec
+}
+
+// FromSettings builds the formatter for the configured currency and locale.
+// When the currency's rate cannot be resolved (offline with a cold cache,
+// unknown code), it falls back to USD rather than failing the command.
+func FromSettings(settings map[string]string) *Formatter {
+	f := &Formatter{currency: "USD", rate: 1, locale: locales["en"]}
+	if lang, _, _ := strings.Cut(settings[SettingLocale], "-"); lang != "" {
+		if spec, ok := locales[strings.ToLower(lang)]; ok {
+			f.locale = spec
+		}
+	}
+	code := strings.ToUpper(settings[SettingCurrency])
+	if code != "" && code != "USD" {
+		if rate, err := Rate(code); err == nil {
+			f.currency = code
+			f.rate = rate
+		}
+	}
+	return f
+}
+
+// Format renders a USD amount in the formatter's currency with the given
+// number of fractional digits (capped for currencies without subdivisions).
+func (f *Formatter) Format(usd float64, decimals int) string {
+	if zeroDecimal[f.currency] {
+		decimals = 0
+	}
+	amount := usd * f.rate
+	number := group(fmt.Sprintf("%.*f", decimals, amount), f.locale)
+	if symbol, ok := symbols[f.currency]; ok {
+		return symbol + number
+	}
+	return f.currency + " " + number
+}
+
+// group applies the locale's separators to a plain "%.Nf" rendering.
+func group(s string, spec localeSpec) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, hasFrac := strings.Cut(s, ".")
+	var b strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(spec.group)
+		}
+		b.WriteRune(digit)
+	}
+	out := sign + b.String()
+	if hasFrac {
+		out += spec.decimal + frac
+	}
+	return out
+}
+
+// ratesDocument is the cached rates file and the relevant part of the rate
+// service's response.
+type ratesDocument struct {
+	FetchedAt time.Time          `json:"fetchedAt"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+// Rate returns how many units of code one USD buys, refreshing the cached
+// rates when they are older than a day. A failed refresh falls back to the
+// cached rates whatever their age.
+func Rate(code string) (float64, error) {
+	doc, err := cachedRates()
+	if err != nil || time.Since(doc.FetchedAt) > ratesTTL {
+		if fresh, ferr := fetchRates(); ferr == nil {
+			doc = fresh
+		} else if err != nil {
+			return 0, ferr
+		}
+	}
+	rate, ok := doc.Rates[code]
+	if !ok {
+		return 0, fmt.Errorf("no exchange rate for %q", code)
+	}
+	return rate, nil
+}
+
+func ratesPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "fx-rates.json"), nil
+}
+
+func cachedRates() (*ratesDocument, error) {
+	path, err := ratesPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var doc ratesDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return &doc, nil
+}
+
+func fetchRates() (*ratesDocument, error) {
+	resp, err := httpClient.Get(ratesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("rate service returned %s", resp.Status)
+	}
+	var body struct {
+		Rates map[string]float64 `json:"rates"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, err
+	}
+	if len(body.Rates) == 0 {
+		return nil, fmt.Errorf("rate service returned no rates")
+	}
+	for code, rate := range body.Rates {
+		if rate <= 0 || math.IsNaN(rate) || math.IsInf(rate, 0) {
+			delete(body.Rates, code)
+		}
+	}
+	doc := &ratesDocument{FetchedAt: time.Now().UTC(), Rates: body.Rates}
+	if path, err := ratesPath(); err == nil {
+		if data, err := json.MarshalIndent(doc, "", "  "); err == nil {
+			// Best effort: a read-only data dir just means refetching next time.
+			_ = os.MkdirAll(filepath.Dir(path), 0o700)
+			_ = os.WriteFile(path, data, 0o600)
+		}
+	}
+	return doc, nil
+}
@@ -0,0 +1,120 @@
+package currency
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestFormatLocales(t *testing.T) {
+	cases := []struct {
+		name     string
+		settings map[string]string
+		rates    map[string]float64
+		usd      float64
+		decimals int
+		want     string
+	}{
+		{"default is USD", nil, nil, 1234.5, 2, "$1,234.50"},
+		{"four decimals", nil, nil, 0.0432, 4, "$0.0432"},
+		{"german euro", map[string]string{SettingCurrency: "EUR", SettingLocale: "de"}, map[string]float64{"EUR": 0.9}, 1234.5, 2, "€1.111,05"},
+		{"french grouping", map[string]string{SettingCurrency: "EUR", SettingLocale: "fr-FR"}, map[string]float64{"EUR": 1}, 1234567.0, 2, "€1 234 567,00"},
+		{"yen drops decimals", map[string]string{SettingCurrency: "JPY", SettingLocale: "ja"}, map[string]float64{"JPY": 150}, 2.5, 4, "¥375"},
+		{"unknown symbol uses code", map[string]string{SettingCurrency: "CHF"}, map[string]float64{"CHF": 0.8}, 10, 2, "CHF 8.00"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+			if tc.rates != nil {
+				writeRates(t, tc.rates, time.Now())
+			}
+			got := FromSettings(tc.settings).Format(tc.usd, tc.decimals)
+			if got != tc.want {
+				t.Errorf("Format = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestUnresolvableRateFallsBackToUSD(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	SetHTTPTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, os.ErrDeadlineExceeded
+	}))
+	defer SetHTTPTransport(nil)
+
+	got := FromSettings(map[string]string{SettingCurrency: "EUR"}).Format(2.5, 2)
+	if got != "$2.50" {
+		t.Errorf("Format = %q, want USD fallback", got)
+	}
+}
+
+func TestRateRefreshAndStaleFallback(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"rates":{"EUR":0.5}}`))
+	}))
+	defer srv.Close()
+	SetHTTPTransport(rewriteTo(srv.URL))
+	defer SetHTTPTransport(nil)
+
+	// A stale cache triggers a refresh.
+	writeRates(t, map[string]float64{"EUR": 0.9}, time.Now().Add(-48*time.Hour))
+	rate, err := Rate("EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 0.5 {
+		t.Errorf("refreshed rate = %v, want 0.5", rate)
+	}
+
+	// When the refresh fails, stale cached rates still serve.
+	writeRates(t, map[string]float64{"EUR": 0.9}, time.Now().Add(-48*time.Hour))
+	SetHTTPTransport(roundTripFunc(func(*http.Request) (*http.Response, error) {
+		return nil, os.ErrDeadlineExceeded
+	}))
+	rate, err = Rate("EUR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate != 0.9 {
+		t.Errorf("stale rate = %v, want 0.9", rate)
+	}
+}
+
+func writeRates(t *testing.T, rates map[string]float64, fetchedAt time.Time) {
+	t.Helper()
+	path, err := ratesPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(ratesDocument{FetchedAt: fetchedAt, Rates: rates})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// rewriteTo sends every request to the test server regardless of the URL the
+// package asks for.
+func rewriteTo(base string) http.RoundTripper {
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		req := r.Clone(r.Context())
+		req.URL.Scheme = "http"
+		req.URL.Host = base[len("http://"):]
+		return http.DefaultTransport.RoundTrip(req)
+	})
+}
@@ -0,0 +1,76 @@
+// Package dotenv reads the `.env` file format used by team secret
+// distribution: one KEY=VALUE per line, `#` comments, optional `export `
+// prefixes and quoted values. Files are parsed into plain maps — nothing is
+// injected into the process environment.
+package dotenv
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Parse decodes dotenv data into a key/value map.
+func Parse(data []byte) (map[string]string, error) {
+	env := make(map[string]string)
+	for lineNo, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimSpace(strings.TrimSuffix(raw, "\r"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNo+1)
+		}
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("line %d: empty key", lineNo+1)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 {
+			if (value[0] == '"' && value[len(value)-1] == '"') || (value[0] == '\'' && value[len(value)-1] == '\'') {
+				value = value[1 : len(value)-1]
+			}
+		}
+		env[key] = value
+	}
+	return env, nil
+}
+
+// Load parses the dotenv file at path.
+func Load(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	env, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return env, nil
+}
+
+// Discover loads conventional dotenv files from dir, later files overriding
+// earlier ones. Missing files are skipped; it returns nil when none exist.
+func Discover(dir string) (map[string]string, error) {
+	var merged map[string]string
+	for _, name := range []string{".env", ".env.cline"} {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		env, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		if merged == nil {
+			merged = make(map[string]string)
+		}
+		for k, v := range env {
+			merged[k] = v
+		}
+	}
+	return merged, nil
+}
@@ -0,0 +1,63 @@
+package dotenv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	env, err := Parse([]byte(`# team keys
+OPENAI_API_KEY=sk-abc123
+export OPENAI_BASE_URL="https://proxy.internal/v1"
+EMPTY=
+QUOTED='single quoted'
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"OPENAI_API_KEY":  "sk-abc123",
+		"OPENAI_BASE_URL": "https://proxy.internal/v1",
+		"EMPTY":           "",
+		"QUOTED":          "single quoted",
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("env[%q] = %q, want %q", k, env[k], v)
+		}
+	}
+}
+
+func TestParseRejectsBareWord(t *testing.T) {
+	if _, err := Parse([]byte("NOT_AN_ASSIGNMENT\n")); err == nil {
+		t.Error("line without = accepted")
+	}
+}
+
+func TestDiscoverMergesWithOverride(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, ".env"), []byte("A=1\nB=2\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".env.cline"), []byte("B=3\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	env, err := Discover(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env["A"] != "1" || env["B"] != "3" {
+		t.Errorf("env = %v", env)
+	}
+}
+
+func TestDiscoverEmptyDir(t *testing.T) {
+	env, err := Discover(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if env != nil {
+		t.Errorf("env = %v, want nil", env)
+	}
+}
@@ -0,0 +1,199 @@
+// Package eval parses and checks user-defined prompt suites, so a cheaper
+// model can be validated against a team's real prompts before it becomes the
+// default. Suites are YAML files restricted to the same small subset the
+// repository policy uses.
+package eval
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/schema"
+)
+
+// Suite is one parsed suite file: the models to evaluate and the cases to
+// run against each of them.
+type Suite struct {
+	Models []string
+	Cases  []Case
+}
+
+// Case is one prompt with its assertions. Every non-empty assertion must
+// hold for the case to pass.
+type Case struct {
+	Name   string
+	Prompt string
+	// Contains requires the reply to contain this substring.
+	Contains string
+	// Regex requires the reply to match this pattern.
+	Regex string
+	// Schema names a JSON schema file, relative to the suite file, the
+	// reply's JSON document must conform to.
+	Schema string
+	// Graded states free-form criteria for a model to judge the reply by.
+	Graded string
+}
+
+// Grader judges a reply against free-form criteria; the CLI backs it with a
+// configured model.
+type Grader func(criteria, reply string) (pass bool, detail string, err error)
+
+// ParseSuite reads a suite file: a `models` list and a `cases` list of
+// name/prompt/assertion entries.
+func ParseSuite(data []byte) (*Suite, error) {
+	s := &Suite{}
+	section := ""
+	var current *Case
+	for lineNo, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+			key, value, found := strings.Cut(trimmed, ":")
+			if !found {
+				return nil, fmt.Errorf("line %d: expected `key:`", lineNo+1)
+			}
+			switch strings.TrimSpace(key) {
+			case "models":
+				section = "models"
+				// Inline form: `models: [a, b]`.
+				for _, item := range strings.Split(strings.Trim(strings.TrimSpace(value), "[]"), ",") {
+					if item = unquote(strings.TrimSpace(item)); item != "" {
+						s.Models = append(s.Models, item)
+					}
+				}
+			case "cases":
+				section = "cases"
+				current = nil
+			default:
+				return nil, fmt.Errorf("line %d: unknown suite key %q", lineNo+1, key)
+			}
+			continue
+		}
+		switch {
+		case section == "models" && strings.HasPrefix(trimmed, "- "):
+			s.Models = append(s.Models, unquote(strings.TrimPrefix(trimmed, "- ")))
+		case section == "cases" && strings.HasPrefix(trimmed, "- "):
+			s.Cases = append(s.Cases, Case{})
+			current = &s.Cases[len(s.Cases)-1]
+			if rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "- ")); rest != "" {
+				if err := setCaseField(current, rest, lineNo); err != nil {
+					return nil, err
+				}
+			}
+		case section == "cases" && current != nil:
+			if err := setCaseField(current, trimmed, lineNo); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("line %d: %q outside a models or cases list", lineNo+1, trimmed)
+		}
+	}
+	for i := range s.Cases {
+		c := &s.Cases[i]
+		if c.Name == "" {
+			c.Name = fmt.Sprintf("case-%d", i+1)
+		}
+		if c.Prompt == "" {
+			return nil, fmt.Errorf("case %q has no prompt", c.Name)
+		}
+		if c.Contains == "" && c.Regex == "" && c.Schema == "" && c.Graded == "" {
+			return nil, fmt.Errorf("case %q has no assertion (contains, regex, schema, or graded)", c.Name)
+		}
+		if c.Regex != "" {
+			if _, err := regexp.Compile(c.Regex); err != nil {
+				return nil, fmt.Errorf("case %q: %v", c.Name, err)
+			}
+		}
+	}
+	if len(s.Cases) == 0 {
+		return nil, fmt.Errorf("suite has no cases")
+	}
+	return s, nil
+}
+
+func setCaseField(c *Case, field string, lineNo int) error {
+	key, value, found := strings.Cut(field, ":")
+	if !found {
+		return fmt.Errorf("line %d: expected `key: value`", lineNo+1)
+	}
+	value = unquote(strings.TrimSpace(value))
+	switch strings.TrimSpace(key) {
+	case "name":
+		c.Name = value
+	case "prompt":
+		c.Prompt = value
+	case "contains":
+		c.Contains = value
+	case "regex":
+		c.Regex = value
+	case "schema":
+		c.Schema = value
+	case "graded":
+		c.Graded = value
+	default:
+		return fmt.Errorf("line %d: unknown case key %q", lineNo+1, key)
+	}
+	return nil
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"'`)
+}
+
+// Check evaluates the case's assertions against a reply. Schema files are
+// resolved relative to baseDir; graded assertions need a Grader.
+func (c Case) Check(baseDir, reply string, grade Grader) (bool, string, error) {
+	if c.Contains != "" && !strings.Contains(reply, c.Contains) {
+		return false, fmt.Sprintf("reply does not contain %q", c.Contains), nil
+	}
+	if c.Regex != "" {
+		re, err := regexp.Compile(c.Regex)
+		if err != nil {
+			return false, "", err
+		}
+		if !re.MatchString(reply) {
+			return false, fmt.Sprintf("reply does not match /%s/", c.Regex), nil
+		}
+	}
+	if c.Schema != "" {
+		data, err := os.ReadFile(filepath.Join(baseDir, c.Schema))
+		if err != nil {
+			return false, "", err
+		}
+		s, err := schema.Parse(data)
+		if err != nil {
+			return false, "", fmt.Errorf("%s: %w", c.Schema, err)
+		}
+		doc, err := schema.ExtractJSON(reply)
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		var value any
+		if err := json.Unmarshal([]byte(doc), &value); err != nil {
+			return false, err.Error(), nil
+		}
+		if problems := s.Validate(value); len(problems) > 0 {
+			return false, strings.Join(problems, "; "), nil
+		}
+	}
+	if c.Graded != "" {
+		if grade == nil {
+			return false, "", fmt.Errorf("case %q needs a grading model", c.Name)
+		}
+		pass, detail, err := grade(c.Graded, reply)
+		if err != nil {
+			return false, "", err
+		}
+		if !pass {
+			return false, detail, nil
+		}
+	}
+	return true, "", nil
+}
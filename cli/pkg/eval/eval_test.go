@@ -0,0 +1,110 @@
+package eval
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const sampleSuite = `# team suite
+models:
+  - openai/gpt-4.1
+  - anthropic/claude-sonnet-4-20250514
+cases:
+  - name: capital
+    prompt: What is the capital of France?
+    contains: Paris
+  - prompt: "2+2? Reply with digits only."
+    regex: "^4$"
+  - name: tone
+    prompt: Decline the request politely.
+    graded: The reply is polite and does not apologize more than once.
+`
+
+func TestParseSuite(t *testing.T) {
+	s, err := ParseSuite([]byte(sampleSuite))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Models) != 2 || s.Models[0] != "openai/gpt-4.1" {
+		t.Errorf("models = %v", s.Models)
+	}
+	if len(s.Cases) != 3 {
+		t.Fatalf("cases = %+v", s.Cases)
+	}
+	if s.Cases[0].Name != "capital" || s.Cases[0].Contains != "Paris" {
+		t.Errorf("case 0 = %+v", s.Cases[0])
+	}
+	if s.Cases[1].Name != "case-2" || s.Cases[1].Regex != "^4$" {
+		t.Errorf("case 1 = %+v", s.Cases[1])
+	}
+	if s.Cases[2].Graded == "" {
+		t.Errorf("case 2 = %+v", s.Cases[2])
+	}
+}
+
+func TestParseSuiteInlineModels(t *testing.T) {
+	s, err := ParseSuite([]byte("models: [a/b, c/d]\ncases:\n  - prompt: p\n    contains: x\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Models) != 2 || s.Models[1] != "c/d" {
+		t.Errorf("models = %v", s.Models)
+	}
+}
+
+func TestParseSuiteRejectsBadInput(t *testing.T) {
+	bad := []string{
+		"cases:\n  - name: x\n    prompt: p\n",       // no assertion
+		"cases:\n  - name: x\n    contains: y\n",     // no prompt
+		"cases:\n  - prompt: p\n    regex: \"[\"\n",  // bad regex
+		"cases:\n  - prompt: p\n    flavour: mint\n", // unknown key
+		"wut: true\n",      // unknown top-level key
+		"models:\n  - a\n", // no cases
+	}
+	for _, src := range bad {
+		if _, err := ParseSuite([]byte(src)); err == nil {
+			t.Errorf("accepted %q", src)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	c := Case{Name: "x", Prompt: "p", Contains: "Paris", Regex: "(?i)paris"}
+	if ok, detail, err := c.Check("", "Paris, of course.", nil); !ok || err != nil {
+		t.Errorf("check = %v, %q, %v", ok, detail, err)
+	}
+	if ok, _, _ := c.Check("", "Lyon", nil); ok {
+		t.Error("wrong reply passed")
+	}
+
+	graded := Case{Name: "g", Prompt: "p", Graded: "is polite"}
+	grade := func(criteria, reply string) (bool, string, error) {
+		return strings.Contains(reply, "please"), "judged", nil
+	}
+	if ok, _, _ := graded.Check("", "please and thank you", grade); !ok {
+		t.Error("graded pass failed")
+	}
+	if ok, detail, _ := graded.Check("", "no", grade); ok || detail != "judged" {
+		t.Errorf("graded fail = %v, %q", ok, detail)
+	}
+	if _, _, err := graded.Check("", "no", nil); err == nil {
+		t.Error("graded without grader did not error")
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	dir := t.TempDir()
+	schemaDoc := `{"type":"object","properties":{"n":{"type":"number"}},"required":["n"]}`
+	if err := os.WriteFile(filepath.Join(dir, "out.schema.json"), []byte(schemaDoc), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	c := Case{Name: "s", Prompt: "p", Schema: "out.schema.json"}
+	if ok, detail, err := c.Check(dir, `{"n": 4}`, nil); !ok || err != nil {
+		t.Errorf("schema pass = %v, %q, %v", ok, detail, err)
+	}
+	if ok, _, err := c.Check(dir, `{"m": 4}`, nil); ok || err != nil {
+		t.Errorf("schema fail = %v, %v", ok, err)
+	}
+}
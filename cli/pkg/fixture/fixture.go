@@ -0,0 +1,135 @@
+// Package fixture generates deterministic fake configurations and usage data
+// for demos, documentation screenshots and integration tests, so none of them
+// ever need a real credential. The same seed always yields the same fixture.
+package fixture
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/stats"
+)
+
+// Options control the fixture's shape. Zero values select the defaults.
+type Options struct {
+	// Providers is how many provider entries to generate (default 3, at most
+	// the built-in catalog below).
+	Providers int
+	// Seed drives every generated value; equal seeds give equal fixtures.
+	Seed int64
+	// Samples is how many usage samples to generate per provider (default 25).
+	Samples int
+}
+
+func (o Options) withDefaults() Options {
+	if o.Providers == 0 {
+		o.Providers = 3
+	}
+	if o.Seed == 0 {
+		o.Seed = 1
+	}
+	if o.Samples == 0 {
+		o.Samples = 25
+	}
+	return o
+}
+
+// fixtureProviders pairs real provider IDs with plausible models, so fixtures
+// exercise the same code paths as real configs.
+var fixtureProviders = []struct{ id, model string }{
+	{"openai", "gpt-4.1"},
+	{"anthropic", "claude-sonnet-4-20250514"},
+	{"openrouter", "anthropic/claude-sonnet-4"},
+	{"ollama", "llama3"},
+	{"deepseek", "deepseek-chat"},
+	{"xai", "grok-3"},
+	{"gemini", "gemini-2.5-pro"},
+	{"lmstudio", "qwen2.5-7b-instruct"},
+}
+
+// fixtureEpoch anchors generated timestamps so fixtures do not drift with the
+// wall clock.
+var fixtureEpoch = time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// Config returns a complete fake configuration. Keys are self-evidently fake
+// ("sk-fixture-...") and never pass a real provider's auth.
+func Config(opts Options) (*config.ClineConfig, error) {
+	opts = opts.withDefaults()
+	if opts.Providers > len(fixtureProviders) {
+		return nil, fmt.Errorf("at most %d fixture providers are available, asked for %d", len(fixtureProviders), opts.Providers)
+	}
+	cfg := config.NewClineConfig()
+	for i := 0; i < opts.Providers; i++ {
+		p := fixtureProviders[i]
+		cfg.Providers[p.id] = &config.ProviderConfig{
+			Provider: p.id,
+			ModelID:  p.model,
+			APIKey:   fmt.Sprintf("sk-fixture-%04d", i+1),
+		}
+		if i == 0 {
+			cfg.DefaultProvider = p.id
+		}
+	}
+	cfg.Settings["fixture"] = "true"
+	return cfg, nil
+}
+
+// Samples returns deterministic usage samples spread over the day before the
+// fixture epoch, with occasional errors so failure paths render too.
+func Samples(opts Options) []stats.Sample {
+	opts = opts.withDefaults()
+	rng := rand.New(rand.NewSource(opts.Seed))
+	var samples []stats.Sample
+	for i := 0; i < opts.Providers && i < len(fixtureProviders); i++ {
+		provider := fixtureProviders[i].id
+		for j := 0; j < opts.Samples; j++ {
+			samples = append(samples, stats.Sample{
+				Time:     fixtureEpoch.Add(-time.Duration(j+1) * 3 * time.Minute),
+				Provider: provider,
+				Seconds:  0.3 + rng.Float64()*2.4,
+				Error:    rng.Intn(10) == 0,
+			})
+		}
+	}
+	return samples
+}
+
+// Install writes the fixture config through the manager and the usage samples
+// to the stats file, replacing whatever is there. Callers are expected to
+// point the config dir somewhere disposable first.
+func Install(manager *config.ConfigManager, opts Options) error {
+	cfg, err := Config(opts)
+	if err != nil {
+		return err
+	}
+	if err := manager.Save(cfg); err != nil {
+		return err
+	}
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(dir, "stats.jsonl"), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	for _, sample := range Samples(opts) {
+		data, err := json.Marshal(sample)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(append(data, '\n')); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package fixture
+
+import (
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func TestConfigDeterministic(t *testing.T) {
+	a, err := Config(Options{Providers: 4, Seed: 7})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := Config(Options{Providers: 4, Seed: 7})
+	if len(a.Providers) != 4 || a.DefaultProvider == "" {
+		t.Errorf("config = %+v", a)
+	}
+	for id, entry := range a.Providers {
+		other := b.Providers[id]
+		if other == nil || other.ModelID != entry.ModelID || other.APIKey != entry.APIKey {
+			t.Errorf("same seed differs for %s: %+v vs %+v", id, entry, other)
+		}
+	}
+	if _, err := Config(Options{Providers: 100}); err == nil {
+		t.Error("oversized provider count accepted")
+	}
+}
+
+func TestSamplesDeterministic(t *testing.T) {
+	a := Samples(Options{Seed: 3})
+	b := Samples(Options{Seed: 3})
+	if len(a) != 3*25 {
+		t.Fatalf("len = %d", len(a))
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			t.Fatalf("sample %d differs: %+v vs %+v", i, a[i], b[i])
+		}
+	}
+}
+
+func TestInstall(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	manager, err := config.NewConfigManager()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := Install(manager, Options{Providers: 2, Samples: 5}); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := manager.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Providers) != 2 || cfg.Settings["fixture"] != "true" {
+		t.Errorf("installed config = %+v", cfg)
+	}
+}
@@ -0,0 +1,48 @@
+package generated
+
+import (
+	"context"
+	"sync"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// ListOptions carries pagination hints for dynamic model listing. Fetchers
+// pass them to the server where the API supports it and otherwise apply them
+// while streaming the response, so huge catalogs (OpenRouter) never have to
+// be materialized whole.
+type ListOptions struct {
+	// Limit caps the number of models returned; 0 means no limit.
+	Limit int
+	// Offset skips that many models from the start of the catalog.
+	Offset int
+}
+
+// ModelFetcher retrieves the live model list for a dynamic-model provider.
+type ModelFetcher func(ctx context.Context, entry *config.ProviderConfig, opts ListOptions) ([]ModelInfo, error)
+
+var (
+	fetchersMu    sync.RWMutex
+	modelFetchers = make(map[string]ModelFetcher)
+)
+
+// RegisterModelFetcher installs the fetcher for a dynamic-model provider.
+// The models package registers fetchers for OpenRouter, Ollama, and the other
+// runtime-listed providers at init time.
+func RegisterModelFetcher(providerID string, fetcher ModelFetcher) {
+	fetchersMu.Lock()
+	defer fetchersMu.Unlock()
+	modelFetchers[providerID] = fetcher
+}
+
+// ModelFetcherFor returns the registered fetcher for a provider, or nil.
+func ModelFetcherFor(providerID string) ModelFetcher {
+	fetchersMu.RLock()
+	defer fetchersMu.RUnlock()
+	return modelFetchers[providerID]
+}
+
+// HasModelFetcher reports whether a fetcher is registered for a provider.
+func HasModelFetcher(providerID string) bool {
+	return ModelFetcherFor(providerID) != nil
+}
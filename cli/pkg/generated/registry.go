@@ -0,0 +1,114 @@
+package generated
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+//go:embed definitions.json
+var definitionsJSON []byte
+
+// providerEntry holds one provider's raw definition bytes plus the decoded
+// form once someone has asked for it.
+type providerEntry struct {
+	raw  json.RawMessage
+	once sync.Once
+	def  *ProviderDefinition
+	err  error
+}
+
+// ProviderRegistry answers provider and model lookups over the embedded
+// definitions. Construction only indexes provider IDs; the full definition
+// (fields, models, pricing) for a provider is decoded the first time it is
+// requested, so commands that never touch a provider don't pay for the 400+
+// model definitions in the catalog.
+type ProviderRegistry struct {
+	entries map[string]*providerEntry
+	order   []string
+}
+
+// NewProviderRegistry indexes the embedded definitions and returns a registry
+// over them. Individual definitions are decoded lazily by Provider.
+func NewProviderRegistry() (*ProviderRegistry, error) {
+	return NewProviderRegistryFromJSON(definitionsJSON)
+}
+
+// DefinitionsJSON returns a copy of the embedded definitions document, so
+// tooling (offline bundles) can ship it to machines running older builds.
+func DefinitionsJSON() []byte {
+	out := make([]byte, len(definitionsJSON))
+	copy(out, definitionsJSON)
+	return out
+}
+
+// NewProviderRegistryFromJSON builds a registry over an explicit definitions
+// document instead of the embedded one, e.g. when an offline bundle delivered
+// newer definitions than the binary was built with.
+func NewProviderRegistryFromJSON(doc []byte) (*ProviderRegistry, error) {
+	var data struct {
+		Providers []json.RawMessage `json:"providers"`
+	}
+	if err := json.Unmarshal(doc, &data); err != nil {
+		return nil, fmt.Errorf("decoding provider definitions: %w", err)
+	}
+	r := &ProviderRegistry{entries: make(map[string]*providerEntry, len(data.Providers))}
+	for _, raw := range data.Providers {
+		var header struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return nil, fmt.Errorf("decoding provider definition header: %w", err)
+		}
+		if header.ID == "" {
+			return nil, fmt.Errorf("provider definition without id")
+		}
+		if _, dup := r.entries[header.ID]; dup {
+			return nil, fmt.Errorf("duplicate provider definition %q", header.ID)
+		}
+		r.entries[header.ID] = &providerEntry{raw: raw}
+		r.order = append(r.order, header.ID)
+	}
+	sort.Strings(r.order)
+	return r, nil
+}
+
+// ProviderIDs returns all known provider IDs in sorted order.
+func (r *ProviderRegistry) ProviderIDs() []string {
+	ids := make([]string, len(r.order))
+	copy(ids, r.order)
+	return ids
+}
+
+// Provider returns the definition for id, decoding it on first use.
+func (r *ProviderRegistry) Provider(id string) (*ProviderDefinition, error) {
+	entry, ok := r.entries[id]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider %q", id)
+	}
+	entry.once.Do(func() {
+		def := &ProviderDefinition{}
+		if err := json.Unmarshal(entry.raw, def); err != nil {
+			entry.err = fmt.Errorf("decoding provider %q: %w", id, err)
+			return
+		}
+		entry.def = def
+	})
+	return entry.def, entry.err
+}
+
+// Model returns the static model info for modelID under provider id.
+func (r *ProviderRegistry) Model(id, modelID string) (*ModelInfo, error) {
+	p, err := r.Provider(id)
+	if err != nil {
+		return nil, err
+	}
+	for i := range p.Models {
+		if p.Models[i].ID == modelID {
+			return &p.Models[i], nil
+		}
+	}
+	return nil, fmt.Errorf("provider %q has no model %q", id, modelID)
+}
@@ -0,0 +1,59 @@
+package generated
+
+import "testing"
+
+func TestNewProviderRegistryIndexesWithoutDecoding(t *testing.T) {
+	r, err := NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.ProviderIDs()) == 0 {
+		t.Fatal("registry has no providers")
+	}
+	for _, id := range r.ProviderIDs() {
+		if r.entries[id].def != nil {
+			t.Errorf("provider %q was decoded eagerly", id)
+		}
+	}
+}
+
+func TestProviderDecodesOnDemand(t *testing.T) {
+	r, err := NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := r.Provider("anthropic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if def.DefaultModelID == "" || len(def.Fields) == 0 {
+		t.Errorf("anthropic definition incomplete: %+v", def)
+	}
+	again, err := r.Provider("anthropic")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != def {
+		t.Error("Provider did not cache the decoded definition")
+	}
+	if _, err := r.Provider("nope"); err == nil {
+		t.Error("unknown provider lookup succeeded")
+	}
+}
+
+func TestModelLookup(t *testing.T) {
+	r, err := NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, err := r.Model("openai", "gpt-4.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.ContextWindow <= 0 {
+		t.Errorf("gpt-4.1 context window = %d", m.ContextWindow)
+	}
+	if _, err := r.Model("openai", "missing-model"); err == nil {
+		t.Error("missing model lookup succeeded")
+	}
+}
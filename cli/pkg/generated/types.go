@@ -0,0 +1,69 @@
+// Package generated contains the provider and model definitions that are
+// generated from the TypeScript sources in src/shared/api.ts and
+// src/shared/providers/providers.json. Do not hand-edit the embedded data;
+// regenerate it with `npm run protos` and the definition generator instead.
+package generated
+
+// FieldCategory groups config fields in the setup wizard.
+type FieldCategory string
+
+const (
+	CategoryAuth     FieldCategory = "auth"
+	CategoryEndpoint FieldCategory = "endpoint"
+	CategoryOptional FieldCategory = "optional"
+)
+
+// ConfigField describes a single configuration input a provider needs.
+type ConfigField struct {
+	Name     string        `json:"name"`
+	Label    string        `json:"label"`
+	Category FieldCategory `json:"category"`
+	Required bool          `json:"required"`
+	Secret   bool          `json:"secret"`
+	EnvVar   string        `json:"envVar,omitempty"`
+	Default  string        `json:"default,omitempty"`
+}
+
+// ModelInfo mirrors the ModelInfo shape used by the extension. Prices are
+// USD per million tokens.
+type ModelInfo struct {
+	ID                  string  `json:"id"`
+	Name                string  `json:"name,omitempty"`
+	ContextWindow       int     `json:"contextWindow"`
+	MaxTokens           int     `json:"maxTokens"`
+	InputPrice          float64 `json:"inputPrice"`
+	OutputPrice         float64 `json:"outputPrice"`
+	CacheReadsPrice     float64 `json:"cacheReadsPrice,omitempty"`
+	CacheWritesPrice    float64 `json:"cacheWritesPrice,omitempty"`
+	SupportsImages      bool    `json:"supportsImages,omitempty"`
+	SupportsPromptCache bool    `json:"supportsPromptCache,omitempty"`
+	// SupportsReasoningEffort marks models taking a reasoning_effort knob
+	// (OpenAI o-series); SupportsExtendedThinking marks models taking a
+	// thinking token budget (Claude).
+	SupportsReasoningEffort  bool `json:"supportsReasoningEffort,omitempty"`
+	SupportsExtendedThinking bool `json:"supportsExtendedThinking,omitempty"`
+}
+
+// ProviderDefinition describes one API provider: the fields the wizard must
+// collect, the static model list (if any), and the default model.
+type ProviderDefinition struct {
+	ID             string        `json:"id"`
+	Name           string        `json:"name"`
+	Fields         []ConfigField `json:"fields"`
+	DefaultModelID string        `json:"defaultModelId,omitempty"`
+	// DefaultEmbeddingsModelID is used by `cline embed` when the entry does
+	// not pick an embeddings model; empty means the provider has no
+	// embeddings endpoint.
+	DefaultEmbeddingsModelID string      `json:"defaultEmbeddingsModelId,omitempty"`
+	Models                   []ModelInfo `json:"models,omitempty"`
+	// DynamicModels marks providers whose model list is fetched at runtime
+	// (OpenRouter, Ollama, ...) rather than baked into the definitions.
+	DynamicModels bool   `json:"dynamicModels,omitempty"`
+	BaseURL       string `json:"baseUrl,omitempty"`
+	// DataRetention is a one-line summary of the provider's retention and
+	// training policy, shown at configuration time and in `providers show`.
+	DataRetention string `json:"dataRetention,omitempty"`
+	// APIKeyURL is the dashboard page where the provider issues API keys;
+	// setup links to it before prompting for the key.
+	APIKeyURL string `json:"apiKeyUrl,omitempty"`
+}
@@ -0,0 +1,51 @@
+package generated
+
+import "fmt"
+
+// Verify cross-checks the embedded definitions for generator drift: required
+// fields without a category, default models missing from the static list,
+// negative pricing, and dynamic-model providers with no fetcher registered.
+// It returns one message per problem found; an error is returned only when
+// the definitions cannot be decoded at all.
+func Verify() ([]string, error) {
+	r, err := NewProviderRegistry()
+	if err != nil {
+		return nil, err
+	}
+	var issues []string
+	report := func(providerID, format string, args ...any) {
+		issues = append(issues, fmt.Sprintf("%s: %s", providerID, fmt.Sprintf(format, args...)))
+	}
+	for _, id := range r.ProviderIDs() {
+		def, err := r.Provider(id)
+		if err != nil {
+			issues = append(issues, err.Error())
+			continue
+		}
+		for _, field := range def.Fields {
+			if field.Required && field.Category == "" {
+				report(id, "required field %q has no category", field.Name)
+			}
+			if field.Label == "" {
+				report(id, "field %q has no label", field.Name)
+			}
+		}
+		if def.DefaultModelID != "" && !def.DynamicModels {
+			if _, err := r.Model(id, def.DefaultModelID); err != nil {
+				report(id, "default model %q is not in the model list", def.DefaultModelID)
+			}
+		}
+		for _, m := range def.Models {
+			if m.InputPrice < 0 || m.OutputPrice < 0 || m.CacheReadsPrice < 0 || m.CacheWritesPrice < 0 {
+				report(id, "model %q has negative pricing", m.ID)
+			}
+			if m.ContextWindow <= 0 {
+				report(id, "model %q has no context window", m.ID)
+			}
+		}
+		if def.DynamicModels && !HasModelFetcher(id) {
+			report(id, "dynamic models but no fetcher registered")
+		}
+	}
+	return issues, nil
+}
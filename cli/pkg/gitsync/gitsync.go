@@ -0,0 +1,195 @@
+// Package gitsync shares the non-secret portions of the configuration
+// through a team git repository: provider entries without their keys,
+// settings, and aliases. Secrets never enter the repo; a pull merges the
+// shared document over the local config while keeping local credentials.
+package gitsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// SettingRemote is the settings key holding the sync repository URL.
+const SettingRemote = "sync.remote"
+
+// sharedFile is the document name inside the sync repository.
+const sharedFile = "shared.json"
+
+// SharedConfig is what the team repo carries: everything a teammate needs
+// except credentials.
+type SharedConfig struct {
+	DefaultProvider string                            `json:"defaultProvider,omitempty"`
+	Providers       map[string]*config.ProviderConfig `json:"providers,omitempty"`
+	Settings        map[string]string                 `json:"settings,omitempty"`
+	ModelAliases    map[string]string                 `json:"modelAliases,omitempty"`
+	CommandAliases  map[string]string                 `json:"commandAliases,omitempty"`
+}
+
+// Dir is the local clone of the sync repository, under the data directory.
+func Dir() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "sync"), nil
+}
+
+// runGit runs one git command in dir and returns its combined output; errors
+// carry the output, since git explains itself on stderr.
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	text := strings.TrimSpace(string(out))
+	if err != nil {
+		return text, fmt.Errorf("git %s: %v\n%s", strings.Join(args, " "), err, text)
+	}
+	return text, nil
+}
+
+// Ensure makes the local clone of remote current: cloning on first use,
+// pulling fast-forward after. It refuses a clone pointed at a different
+// remote rather than mixing two teams' configs.
+func Ensure(remote string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err != nil {
+		if err := os.MkdirAll(filepath.Dir(dir), 0o700); err != nil {
+			return "", err
+		}
+		if _, err := runGit(filepath.Dir(dir), "clone", remote, dir); err != nil {
+			return "", err
+		}
+		return dir, nil
+	}
+	origin, err := runGit(dir, "remote", "get-url", "origin")
+	if err != nil {
+		return "", err
+	}
+	if origin != remote {
+		return "", fmt.Errorf("%s tracks %s, not %s; remove the directory to switch remotes", dir, origin, remote)
+	}
+	if _, err := runGit(dir, "pull", "--ff-only"); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
+
+// Export builds the shared document from a config, stripping API keys and
+// secret-valued headers.
+func Export(cfg *config.ClineConfig) *SharedConfig {
+	shared := &SharedConfig{
+		DefaultProvider: cfg.DefaultProvider,
+		Settings:        cfg.Settings,
+		ModelAliases:    cfg.ModelAliases,
+		CommandAliases:  cfg.CommandAliases,
+	}
+	if len(cfg.Providers) > 0 {
+		shared.Providers = make(map[string]*config.ProviderConfig, len(cfg.Providers))
+		for id, entry := range cfg.Providers {
+			stripped := *entry
+			stripped.APIKey = ""
+			stripped.Headers = nil
+			for name, value := range entry.Headers {
+				if config.LooksLikeSecretName(name) || strings.EqualFold(name, "authorization") {
+					continue
+				}
+				stripped.SetHeader(name, value)
+			}
+			shared.Providers[id] = &stripped
+		}
+	}
+	return shared
+}
+
+// Merge applies a shared document to a local config. Shared values win for
+// what the document carries; local API keys, secret headers, and anything
+// the document does not mention stay untouched.
+func Merge(cfg *config.ClineConfig, shared *SharedConfig) {
+	if shared.DefaultProvider != "" {
+		cfg.DefaultProvider = shared.DefaultProvider
+	}
+	for id, incoming := range shared.Providers {
+		entry := cfg.Provider(id)
+		apiKey, headers := entry.APIKey, entry.Headers
+		*entry = *incoming
+		entry.APIKey = apiKey
+		for name, value := range headers {
+			if config.LooksLikeSecretName(name) || strings.EqualFold(name, "authorization") {
+				entry.SetHeader(name, value)
+			}
+		}
+	}
+	for key, value := range shared.Settings {
+		if cfg.Settings == nil {
+			cfg.Settings = make(map[string]string)
+		}
+		cfg.Settings[key] = value
+	}
+	for name, ref := range shared.ModelAliases {
+		if cfg.ModelAliases == nil {
+			cfg.ModelAliases = make(map[string]string)
+		}
+		cfg.ModelAliases[name] = ref
+	}
+	for name, expansion := range shared.CommandAliases {
+		if cfg.CommandAliases == nil {
+			cfg.CommandAliases = make(map[string]string)
+		}
+		cfg.CommandAliases[name] = expansion
+	}
+}
+
+// Push writes the shared document into the clone at dir and pushes it; a
+// no-op push reports itself without creating an empty commit.
+func Push(dir string, cfg *config.ClineConfig) (string, error) {
+	data, err := json.MarshalIndent(Export(cfg), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, sharedFile)
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return "", err
+	}
+	status, err := runGit(dir, "status", "--porcelain")
+	if err != nil {
+		return "", err
+	}
+	if status == "" {
+		return "Already up to date.", nil
+	}
+	if _, err := runGit(dir, "add", sharedFile); err != nil {
+		return "", err
+	}
+	if _, err := runGit(dir, "commit", "-m", "Update shared cline config"); err != nil {
+		return "", err
+	}
+	if _, err := runGit(dir, "push"); err != nil {
+		return "", err
+	}
+	return "Pushed shared config.", nil
+}
+
+// Load reads the shared document from the clone at dir.
+func Load(dir string) (*SharedConfig, error) {
+	data, err := os.ReadFile(filepath.Join(dir, sharedFile))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("the sync repository has no %s yet; run `cline config push` from a configured machine first", sharedFile)
+		}
+		return nil, err
+	}
+	shared := &SharedConfig{}
+	if err := json.Unmarshal(data, shared); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", sharedFile, err)
+	}
+	return shared, nil
+}
@@ -0,0 +1,68 @@
+package gitsync
+
+import (
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func TestExportStripsSecrets(t *testing.T) {
+	cfg := config.NewClineConfig()
+	entry := cfg.Provider("openai")
+	entry.ModelID = "gpt-4.1"
+	entry.APIKey = "sk-secret"
+	entry.SetHeader("X-Org-Id", "acme")
+	entry.SetHeader("X-Gateway-Token", "t0ken")
+
+	shared := Export(cfg)
+	got := shared.Providers["openai"]
+	if got.APIKey != "" {
+		t.Errorf("APIKey = %q", got.APIKey)
+	}
+	if got.Headers["X-Org-Id"] != "acme" {
+		t.Errorf("Headers = %v", got.Headers)
+	}
+	if _, ok := got.Headers["X-Gateway-Token"]; ok {
+		t.Error("secret header exported")
+	}
+	// The original entry must not be mutated by the export.
+	if entry.APIKey != "sk-secret" || len(entry.Headers) != 2 {
+		t.Errorf("entry mutated: %+v", entry)
+	}
+}
+
+func TestMergeKeepsLocalSecrets(t *testing.T) {
+	local := config.NewClineConfig()
+	localEntry := local.Provider("openai")
+	localEntry.APIKey = "sk-local"
+	localEntry.ModelID = "gpt-4o"
+	localEntry.SetHeader("X-Gateway-Token", "t0ken")
+	local.Settings["theme"] = "dark"
+
+	shared := &SharedConfig{
+		DefaultProvider: "openai",
+		Providers: map[string]*config.ProviderConfig{
+			"openai":    {Provider: "openai", ModelID: "gpt-4.1", Headers: map[string]string{"X-Org-Id": "acme"}},
+			"anthropic": {Provider: "anthropic", ModelID: "claude-sonnet-4-20250514"},
+		},
+		ModelAliases: map[string]string{"fast": "openai/gpt-4.1-mini"},
+	}
+	Merge(local, shared)
+
+	entry := local.Providers["openai"]
+	if entry.APIKey != "sk-local" {
+		t.Errorf("APIKey = %q", entry.APIKey)
+	}
+	if entry.ModelID != "gpt-4.1" {
+		t.Errorf("ModelID = %q", entry.ModelID)
+	}
+	if entry.Headers["X-Gateway-Token"] != "t0ken" || entry.Headers["X-Org-Id"] != "acme" {
+		t.Errorf("Headers = %v", entry.Headers)
+	}
+	if local.Providers["anthropic"] == nil || local.DefaultProvider != "openai" {
+		t.Errorf("merge missed shared entries: %+v", local)
+	}
+	if local.Settings["theme"] != "dark" || local.ModelAliases["fast"] == "" {
+		t.Errorf("local-only values lost: %v %v", local.Settings, local.ModelAliases)
+	}
+}
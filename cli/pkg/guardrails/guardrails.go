@@ -0,0 +1,99 @@
+// Package guardrails enforces client-side context window limits before input
+// is sent to a provider: a per-provider token budget derived from the model's
+// context window, and a configurable truncation strategy for oversized input.
+package guardrails
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// Strategy selects which part of oversized input survives truncation.
+type Strategy string
+
+const (
+	// StrategyHead keeps the beginning of the input.
+	StrategyHead Strategy = "head"
+	// StrategyTail keeps the end of the input.
+	StrategyTail Strategy = "tail"
+	// StrategyMiddleOut keeps both ends and drops the middle, which preserves
+	// instructions and the most recent context.
+	StrategyMiddleOut Strategy = "middle-out"
+)
+
+// ParseStrategy validates a truncation strategy name; empty selects the
+// middle-out default.
+func ParseStrategy(s string) (Strategy, error) {
+	switch Strategy(s) {
+	case "":
+		return StrategyMiddleOut, nil
+	case StrategyHead, StrategyTail, StrategyMiddleOut:
+		return Strategy(s), nil
+	}
+	return "", fmt.Errorf("unknown truncation strategy %q (want head, tail or middle-out)", s)
+}
+
+// Limits is the guardrail configuration resolved for one provider.
+type Limits struct {
+	// BudgetTokens is the maximum input size; 0 means unknown/unlimited.
+	BudgetTokens int
+	Strategy     Strategy
+}
+
+// ForProvider resolves limits for a provider entry. The budget starts from the
+// configured model's context window minus its output reservation, and the
+// per-provider settings context.maxInputTokens.<id> / context.truncation.<id>
+// can lower the budget or pick a strategy.
+func ForProvider(def *generated.ProviderDefinition, entry *config.ProviderConfig, settings map[string]string) (Limits, error) {
+	limits := Limits{Strategy: StrategyMiddleOut}
+	for _, m := range def.Models {
+		if m.ID == entry.ModelID {
+			limits.BudgetTokens = m.ContextWindow - m.MaxTokens
+			break
+		}
+	}
+	if v := settings["context.maxInputTokens."+def.ID]; v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return limits, fmt.Errorf("invalid context.maxInputTokens.%s value %q", def.ID, v)
+		}
+		if limits.BudgetTokens == 0 || n < limits.BudgetTokens {
+			limits.BudgetTokens = n
+		}
+	}
+	strategy, err := ParseStrategy(settings["context.truncation."+def.ID])
+	if err != nil {
+		return limits, err
+	}
+	limits.Strategy = strategy
+	return limits, nil
+}
+
+// EstimateTokens approximates the token count of text. Four characters per
+// token is the usual planning heuristic across current tokenizers; guardrails
+// only need to be in the right ballpark.
+func EstimateTokens(text string) int {
+	return (len(text) + 3) / 4
+}
+
+// Truncate shrinks text to roughly budgetTokens using the given strategy. It
+// reports whether anything was cut.
+func Truncate(text string, budgetTokens int, strategy Strategy) (string, bool) {
+	if budgetTokens <= 0 || EstimateTokens(text) <= budgetTokens {
+		return text, false
+	}
+	maxChars := budgetTokens * 4
+	const marker = "\n[... input truncated to fit context window ...]\n"
+	switch strategy {
+	case StrategyHead:
+		return text[:maxChars] + marker, true
+	case StrategyTail:
+		return marker + text[len(text)-maxChars:], true
+	default:
+		half := maxChars / 2
+		return text[:half] + marker + text[len(text)-half:], true
+	}
+}
@@ -0,0 +1,77 @@
+package guardrails
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func testDef() *generated.ProviderDefinition {
+	return &generated.ProviderDefinition{
+		ID: "openai",
+		Models: []generated.ModelInfo{
+			{ID: "gpt-4.1", ContextWindow: 10000, MaxTokens: 2000},
+		},
+	}
+}
+
+func TestForProviderDerivesBudgetFromModel(t *testing.T) {
+	entry := &config.ProviderConfig{Provider: "openai", ModelID: "gpt-4.1"}
+	limits, err := ForProvider(testDef(), entry, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limits.BudgetTokens != 8000 || limits.Strategy != StrategyMiddleOut {
+		t.Errorf("limits = %+v", limits)
+	}
+}
+
+func TestForProviderSettingsLowerBudget(t *testing.T) {
+	entry := &config.ProviderConfig{Provider: "openai", ModelID: "gpt-4.1"}
+	settings := map[string]string{
+		"context.maxInputTokens.openai": "500",
+		"context.truncation.openai":     "tail",
+	}
+	limits, err := ForProvider(testDef(), entry, settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if limits.BudgetTokens != 500 || limits.Strategy != StrategyTail {
+		t.Errorf("limits = %+v", limits)
+	}
+}
+
+func TestForProviderRejectsBadValues(t *testing.T) {
+	entry := &config.ProviderConfig{Provider: "openai", ModelID: "gpt-4.1"}
+	if _, err := ForProvider(testDef(), entry, map[string]string{"context.maxInputTokens.openai": "lots"}); err == nil {
+		t.Error("non-numeric budget accepted")
+	}
+	if _, err := ForProvider(testDef(), entry, map[string]string{"context.truncation.openai": "random"}); err == nil {
+		t.Error("unknown strategy accepted")
+	}
+}
+
+func TestTruncateStrategies(t *testing.T) {
+	text := strings.Repeat("Q", 400) + strings.Repeat("Z", 400)
+	if out, cut := Truncate(text, 50, StrategyHead); !cut || !strings.HasPrefix(out, "QQQQ") || strings.Contains(out, "Z") {
+		t.Errorf("head: cut=%v out=%q", cut, out)
+	}
+	if out, cut := Truncate(text, 50, StrategyTail); !cut || !strings.HasSuffix(out, "ZZZZ") || strings.Contains(out, "Q") {
+		t.Errorf("tail: cut=%v out=%q", cut, out)
+	}
+	out, cut := Truncate(text, 50, StrategyMiddleOut)
+	if !cut || !strings.HasPrefix(out, "QQQQ") || !strings.HasSuffix(out, "ZZZZ") {
+		t.Errorf("middle-out: cut=%v out=%q", cut, out)
+	}
+	if !strings.Contains(out, "truncated") {
+		t.Errorf("middle-out missing marker: %q", out)
+	}
+}
+
+func TestTruncateWithinBudgetUntouched(t *testing.T) {
+	if out, cut := Truncate("short", 100, StrategyMiddleOut); cut || out != "short" {
+		t.Errorf("out=%q cut=%v", out, cut)
+	}
+}
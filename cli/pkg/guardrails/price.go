@@ -0,0 +1,62 @@
+package guardrails
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// SettingPriceWarnThreshold is the settings key holding the blended price (USD
+// per million tokens) above which selecting a model asks for confirmation;
+// "0" disables the warning.
+const SettingPriceWarnThreshold = "cost.warnThreshold"
+
+// defaultPriceWarnThreshold catches o1-class pricing while leaving the
+// workhorse models alone.
+const defaultPriceWarnThreshold = 15.0
+
+// Typical task volumes behind the per-task estimate shown with the warning.
+// They mirror a mid-sized coding exchange: a lot of context in, a diff out.
+const (
+	typicalTaskInputTokens  = 50_000
+	typicalTaskOutputTokens = 5_000
+)
+
+// PriceWarning explains why selecting a model should be confirmed.
+type PriceWarning struct {
+	// BlendedPrice and Threshold are USD per million tokens.
+	BlendedPrice float64
+	Threshold    float64
+	// TypicalTaskUSD estimates one mid-sized task at the model's prices.
+	TypicalTaskUSD float64
+}
+
+// BlendedPrice folds a model's input and output prices into one comparable
+// number, weighting input three to one since prompts dominate task traffic.
+func BlendedPrice(m *generated.ModelInfo) float64 {
+	return (3*m.InputPrice + m.OutputPrice) / 4
+}
+
+// CheckPrice returns a warning when the model's blended price exceeds the
+// configured threshold, and nil when the selection needs no confirmation.
+func CheckPrice(m *generated.ModelInfo, settings map[string]string) (*PriceWarning, error) {
+	threshold := defaultPriceWarnThreshold
+	if v := settings[SettingPriceWarnThreshold]; v != "" {
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil || f < 0 {
+			return nil, fmt.Errorf("invalid %s value %q", SettingPriceWarnThreshold, v)
+		}
+		threshold = f
+	}
+	blended := BlendedPrice(m)
+	if threshold == 0 || blended <= threshold {
+		return nil, nil
+	}
+	return &PriceWarning{
+		BlendedPrice: blended,
+		Threshold:    threshold,
+		TypicalTaskUSD: float64(typicalTaskInputTokens)*m.InputPrice/1_000_000 +
+			float64(typicalTaskOutputTokens)*m.OutputPrice/1_000_000,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package guardrails
+
+import (
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestCheckPrice(t *testing.T) {
+	cheap := &generated.ModelInfo{ID: "gpt-4.1-mini", InputPrice: 0.4, OutputPrice: 1.6}
+	pricey := &generated.ModelInfo{ID: "o1", InputPrice: 15, OutputPrice: 60}
+
+	if w, err := CheckPrice(cheap, nil); err != nil || w != nil {
+		t.Errorf("cheap model warned: %+v, %v", w, err)
+	}
+	w, err := CheckPrice(pricey, nil)
+	if err != nil || w == nil {
+		t.Fatalf("pricey model did not warn: %v", err)
+	}
+	if w.BlendedPrice != 26.25 {
+		t.Errorf("blended = %v", w.BlendedPrice)
+	}
+	if diff := w.TypicalTaskUSD - 1.05; diff < -1e-9 || diff > 1e-9 {
+		t.Errorf("typical task = %v", w.TypicalTaskUSD)
+	}
+	// "0" disables, a higher threshold raises the bar.
+	if w, _ := CheckPrice(pricey, map[string]string{SettingPriceWarnThreshold: "0"}); w != nil {
+		t.Errorf("disabled threshold still warned: %+v", w)
+	}
+	if w, _ := CheckPrice(cheap, map[string]string{SettingPriceWarnThreshold: "0.5"}); w == nil {
+		t.Error("lowered threshold did not warn")
+	}
+	if _, err := CheckPrice(cheap, map[string]string{SettingPriceWarnThreshold: "lots"}); err == nil {
+		t.Error("invalid threshold accepted")
+	}
+}
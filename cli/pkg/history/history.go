@@ -0,0 +1,300 @@
+// Package history records past prompt invocations — what was asked, which
+// provider and model answered, and what it cost — so they can be listed and
+// rerun against a different model for comparison. Entries live under the
+// data directory's history/ folder, each sealed with a local key, since
+// prompts often contain proprietary code.
+package history
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/cryptobox"
+)
+
+// SettingRetention controls how long history is kept: "never" disables
+// recording entirely, "30d" (or any Go duration) drops older entries, and
+// unset keeps everything.
+const SettingRetention = "history.retention"
+
+// Entry is one recorded invocation. Prompts are stored after redaction, so
+// the history store never holds more secrets than the provider saw.
+type Entry struct {
+	ID       string    `json:"id"`
+	Time     time.Time `json:"time"`
+	Command  string    `json:"command"`
+	Provider string    `json:"provider"`
+	ModelID  string    `json:"modelId,omitempty"`
+	Prompt   string    `json:"prompt"`
+	CostUSD  float64   `json:"costUsd,omitempty"`
+	// Workspace is the directory the command ran in, for search filters.
+	Workspace string `json:"workspace,omitempty"`
+}
+
+func dirPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "history"), nil
+}
+
+// NewID returns a sortable, user-typeable history ID.
+func NewID() string {
+	var b [3]byte
+	rand.Read(b[:])
+	return time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b[:])
+}
+
+// Retention parses the retention setting: store reports whether recording is
+// allowed at all, and keep is how long entries live (zero: forever). "30d"
+// style day counts are accepted alongside Go durations.
+func Retention(settings map[string]string) (store bool, keep time.Duration) {
+	value := strings.TrimSpace(settings[SettingRetention])
+	if value == "never" {
+		return false, 0
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return true, time.Duration(n) * 24 * time.Hour
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d > 0 {
+		return true, d
+	}
+	return true, 0
+}
+
+// loadOrCreateKey returns the local history key, generating one on first
+// use. The key never leaves the machine; losing it loses the history.
+func loadOrCreateKey() ([]byte, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "history.key")
+	if data, err := os.ReadFile(path); err == nil {
+		key, err := hex.DecodeString(strings.TrimSpace(string(data)))
+		if err != nil || len(key) != cryptobox.KeySize {
+			return nil, fmt.Errorf("%s is corrupted; remove it to start a fresh history", path)
+		}
+		return key, nil
+	}
+	key, err := cryptobox.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(path, []byte(hex.EncodeToString(key)+"\n"), 0o600); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Record stores one entry, filling ID and Time when unset and honoring the
+// retention setting. Errors are swallowed: history is advisory and must not
+// interfere with the request path.
+func Record(settings map[string]string, e Entry) {
+	store, keep := Retention(settings)
+	if !store {
+		return
+	}
+	if e.ID == "" {
+		e.ID = NewID()
+	}
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Workspace == "" {
+		e.Workspace, _ = os.Getwd()
+	}
+	key, err := loadOrCreateKey()
+	if err != nil {
+		return
+	}
+	dir, err := dirPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	box, err := cryptobox.SealWithKey(key, data)
+	if err != nil {
+		return
+	}
+	os.WriteFile(filepath.Join(dir, e.ID+".cbox"), box, 0o600)
+	if keep > 0 {
+		Purge(keep)
+	}
+}
+
+// List returns all recorded entries, newest first. A missing directory is an
+// empty history. Undecryptable files are skipped so one bad record does not
+// hide the rest.
+func List() ([]Entry, error) {
+	dir, err := dirPath()
+	if err != nil {
+		return nil, err
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.cbox"))
+	if err != nil || len(names) == 0 {
+		return nil, err
+	}
+	key, err := loadOrCreateKey()
+	if err != nil {
+		return nil, err
+	}
+	var entries []Entry
+	for _, name := range names {
+		box, err := os.ReadFile(name)
+		if err != nil {
+			continue
+		}
+		data, err := cryptobox.OpenWithKey(key, box)
+		if err != nil {
+			continue
+		}
+		var e Entry
+		if err := json.Unmarshal(data, &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	// File names start with the entry's timestamp, so the glob's sorted
+	// order is oldest first; reverse for newest first.
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, nil
+}
+
+// Purge removes entries older than the given age; a non-positive age removes
+// everything. It returns how many entries were removed.
+func Purge(olderThan time.Duration) (int, error) {
+	dir, err := dirPath()
+	if err != nil {
+		return 0, err
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.cbox"))
+	if err != nil {
+		return 0, err
+	}
+	cutoff := time.Now().UTC().Add(-olderThan)
+	removed := 0
+	for _, name := range names {
+		if olderThan > 0 {
+			// The ID prefix is the entry's UTC timestamp; trusting it
+			// avoids decrypting every record just to expire it.
+			at, ok := idTime(name)
+			if !ok || at.After(cutoff) {
+				continue
+			}
+		}
+		if os.Remove(name) == nil {
+			removed++
+		}
+	}
+	return removed, nil
+}
+
+// idTime parses the timestamp prefix of an entry file name.
+func idTime(name string) (time.Time, bool) {
+	parts := strings.SplitN(strings.TrimSuffix(filepath.Base(name), ".cbox"), "-", 3)
+	if len(parts) < 2 {
+		return time.Time{}, false
+	}
+	at, err := time.Parse("20060102-150405", parts[0]+"-"+parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// Find returns the entry with the given ID; a prefix works when it matches
+// exactly one entry.
+func Find(id string) (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	var match *Entry
+	for i := range entries {
+		if entries[i].ID == id {
+			return &entries[i], nil
+		}
+		if strings.HasPrefix(entries[i].ID, id) {
+			if match != nil {
+				return nil, fmt.Errorf("history ID prefix %q is ambiguous", id)
+			}
+			match = &entries[i]
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("no history entry %q", id)
+	}
+	return match, nil
+}
+
+// Filter narrows a Search: zero values match everything.
+type Filter struct {
+	// Since drops entries recorded before this time.
+	Since time.Time
+	// Provider and ModelID require exact matches.
+	Provider string
+	ModelID  string
+	// Workspace requires the entry to have been recorded in this directory.
+	Workspace string
+}
+
+// Search returns entries whose prompt contains every word of the query,
+// case-insensitively, newest first. An empty query matches everything, so
+// the filters can be used alone.
+func Search(query string, f Filter) ([]Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	terms := strings.Fields(strings.ToLower(query))
+	var matches []Entry
+	for _, e := range entries {
+		if !f.Since.IsZero() && e.Time.Before(f.Since) {
+			continue
+		}
+		if f.Provider != "" && e.Provider != f.Provider {
+			continue
+		}
+		if f.ModelID != "" && e.ModelID != f.ModelID {
+			continue
+		}
+		if f.Workspace != "" && e.Workspace != f.Workspace {
+			continue
+		}
+		prompt := strings.ToLower(e.Prompt)
+		matched := true
+		for _, term := range terms {
+			if !strings.Contains(prompt, term) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, e)
+		}
+	}
+	return matches, nil
+}
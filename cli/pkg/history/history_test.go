@@ -0,0 +1,148 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecordListFind(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	Record(nil, Entry{ID: "20260101-120000-aaaaaa", Command: "ask", Provider: "openai", Prompt: "first"})
+	Record(nil, Entry{ID: "20260101-120100-bbbbbb", Command: "ask", Provider: "anthropic", Prompt: "second", CostUSD: 0.02})
+
+	entries, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].Prompt != "second" || entries[1].Prompt != "first" {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if entries[0].Time.IsZero() {
+		t.Error("Record did not fill Time")
+	}
+
+	if e, err := Find("20260101-120000-aaaaaa"); err != nil || e.Prompt != "first" {
+		t.Errorf("exact find = %+v, %v", e, err)
+	}
+	if e, err := Find("20260101-1201"); err != nil || e.Prompt != "second" {
+		t.Errorf("prefix find = %+v, %v", e, err)
+	}
+	if _, err := Find("20260101"); err == nil {
+		t.Error("ambiguous prefix did not error")
+	}
+	if _, err := Find("nope"); err == nil {
+		t.Error("missing ID did not error")
+	}
+}
+
+func TestEntriesAreEncryptedOnDisk(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	Record(nil, Entry{Command: "ask", Provider: "openai", Prompt: "proprietary algorithm"})
+	dir, err := dirPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.cbox"))
+	if err != nil || len(names) != 1 {
+		t.Fatalf("entry files = %v, %v", names, err)
+	}
+	raw, err := os.ReadFile(names[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(raw), "proprietary") {
+		t.Error("prompt is visible in the stored file")
+	}
+}
+
+func TestRetentionNeverStores(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	Record(map[string]string{SettingRetention: "never"}, Entry{Command: "ask", Prompt: "secret"})
+	if entries, _ := List(); entries != nil {
+		t.Errorf("entries = %+v, want none", entries)
+	}
+}
+
+func TestRetentionParsing(t *testing.T) {
+	if store, keep := Retention(nil); !store || keep != 0 {
+		t.Errorf("default = %v, %v", store, keep)
+	}
+	if store, _ := Retention(map[string]string{SettingRetention: "never"}); store {
+		t.Error("never should disable storing")
+	}
+	if _, keep := Retention(map[string]string{SettingRetention: "30d"}); keep != 30*24*time.Hour {
+		t.Errorf("30d = %v", keep)
+	}
+	if _, keep := Retention(map[string]string{SettingRetention: "12h"}); keep != 12*time.Hour {
+		t.Errorf("12h = %v", keep)
+	}
+	if store, keep := Retention(map[string]string{SettingRetention: "nope"}); !store || keep != 0 {
+		t.Errorf("malformed = %v, %v", store, keep)
+	}
+}
+
+func TestPurge(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	old := time.Now().UTC().Add(-48 * time.Hour)
+	Record(nil, Entry{ID: old.Format("20060102-150405") + "-aaaaaa", Time: old, Command: "ask", Prompt: "stale"})
+	Record(nil, Entry{Command: "ask", Prompt: "fresh"})
+
+	removed, err := Purge(24 * time.Hour)
+	if err != nil || removed != 1 {
+		t.Fatalf("Purge = %d, %v", removed, err)
+	}
+	entries, _ := List()
+	if len(entries) != 1 || entries[0].Prompt != "fresh" {
+		t.Fatalf("entries = %+v", entries)
+	}
+
+	if removed, err = Purge(0); err != nil || removed != 1 {
+		t.Fatalf("Purge all = %d, %v", removed, err)
+	}
+	if entries, _ := List(); entries != nil {
+		t.Errorf("entries after purge all = %+v", entries)
+	}
+}
+
+func TestNewIDFormat(t *testing.T) {
+	id := NewID()
+	if len(id) != len("20060102-150405-abcdef") {
+		t.Errorf("id = %q", id)
+	}
+	if id[:8] != time.Now().UTC().Format("20060102") {
+		t.Errorf("id date = %q", id)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	old := time.Now().UTC().Add(-72 * time.Hour)
+	Record(nil, Entry{ID: old.Format("20060102-150405") + "-aaaaaa", Time: old, Provider: "openai", ModelID: "gpt-4o",
+		Prompt: "why does pgbouncer close idle connections", Workspace: "/work/api"})
+	Record(nil, Entry{Provider: "anthropic", ModelID: "claude", Prompt: "Tune PgBouncer timeout settings", Workspace: "/work/api"})
+	Record(nil, Entry{Provider: "openai", ModelID: "gpt-4o", Prompt: "unrelated question", Workspace: "/work/web"})
+
+	matches, err := Search("pgbouncer timeout", Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 1 || matches[0].Provider != "anthropic" {
+		t.Fatalf("matches = %+v", matches)
+	}
+
+	if matches, _ = Search("pgbouncer", Filter{}); len(matches) != 2 {
+		t.Fatalf("case-insensitive matches = %+v", matches)
+	}
+	if matches, _ = Search("pgbouncer", Filter{ModelID: "gpt-4o"}); len(matches) != 1 {
+		t.Fatalf("model-filtered matches = %+v", matches)
+	}
+	if matches, _ = Search("pgbouncer", Filter{Since: time.Now().Add(-time.Hour)}); len(matches) != 1 {
+		t.Fatalf("since-filtered matches = %+v", matches)
+	}
+	if matches, _ = Search("", Filter{Workspace: "/work/web"}); len(matches) != 1 || matches[0].Prompt != "unrelated question" {
+		t.Fatalf("workspace-filtered matches = %+v", matches)
+	}
+}
@@ -0,0 +1,132 @@
+// Package hooks runs user-provided lifecycle hooks. Executables placed in
+// ~/.cline/hooks/ named after an event (optionally with a suffix, e.g.
+// pre-config-save-audit) are invoked with a JSON payload on stdin whenever
+// that event fires, so teams can plug in policy checks, notifications, or
+// secret injection without forking the CLI.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// Event names a lifecycle point hooks can attach to.
+type Event string
+
+const (
+	PreTask       Event = "pre-task"
+	PostTask      Event = "post-task"
+	PreConfigSave Event = "pre-config-save"
+	PostAuth      Event = "post-auth"
+)
+
+// hookTimeout bounds each hook invocation so a hung script cannot wedge the
+// CLI.
+const hookTimeout = 30 * time.Second
+
+// Payload is what each hook receives on stdin, as a single JSON document.
+type Payload struct {
+	Event     Event          `json:"event"`
+	Timestamp time.Time      `json:"timestamp"`
+	Data      map[string]any `json:"data,omitempty"`
+}
+
+// Dir returns the hooks directory (~/.cline/hooks).
+func Dir() (string, error) {
+	base, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, "hooks"), nil
+}
+
+// Fire runs every hook registered for event, in name order, passing the
+// payload on stdin. It returns the first hook failure; for pre-* events the
+// caller should treat an error as a veto and abort the operation, for post-*
+// events callers typically only warn.
+func Fire(ctx context.Context, event Event, data map[string]any) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	scripts, err := hooksFor(dir, event)
+	if err != nil || len(scripts) == 0 {
+		return err
+	}
+	payload, err := json.Marshal(Payload{Event: event, Timestamp: time.Now().UTC(), Data: data})
+	if err != nil {
+		return err
+	}
+	for _, script := range scripts {
+		if err := runHook(ctx, script, payload); err != nil {
+			return fmt.Errorf("hook %s: %w", filepath.Base(script), err)
+		}
+	}
+	return nil
+}
+
+// hooksFor lists executables in dir whose name is the event name or starts
+// with "<event>-", sorted for deterministic ordering.
+func hooksFor(dir string, event Event) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var scripts []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		name := entry.Name()
+		base := strings.TrimSuffix(name, filepath.Ext(name))
+		if base != string(event) && !strings.HasPrefix(base, string(event)+"-") {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || !isExecutable(info) {
+			continue
+		}
+		scripts = append(scripts, filepath.Join(dir, name))
+	}
+	sort.Strings(scripts)
+	return scripts, nil
+}
+
+func isExecutable(info fs.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(info.Name()))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd" || ext == ".ps1"
+	}
+	return info.Mode()&0o111 != 0
+}
+
+func runHook(ctx context.Context, script string, payload []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, hookTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, script)
+	cmd.Stdin = bytes.NewReader(payload)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
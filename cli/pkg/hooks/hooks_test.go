@@ -0,0 +1,71 @@
+package hooks
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func writeHook(t *testing.T, dir, name, script string) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFireRunsMatchingHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script hooks are not runnable on windows")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".cline", "hooks")
+	out := filepath.Join(home, "out.txt")
+	writeHook(t, dir, "post-auth", "#!/bin/sh\ncat > "+out+"\n")
+	writeHook(t, dir, "pre-task", "#!/bin/sh\nexit 1\n")
+
+	if err := Fire(context.Background(), PostAuth, map[string]any{"provider": "openai"}); err != nil {
+		t.Fatalf("Fire(post-auth) = %v", err)
+	}
+	payload, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatalf("hook did not receive payload: %v", err)
+	}
+	for _, want := range []string{`"event":"post-auth"`, `"provider":"openai"`} {
+		if !strings.Contains(string(payload), want) {
+			t.Errorf("payload %s missing %s", payload, want)
+		}
+	}
+}
+
+func TestFireReportsHookFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("shell script hooks are not runnable on windows")
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".cline", "hooks")
+	writeHook(t, dir, "pre-config-save", "#!/bin/sh\necho 'policy denied' >&2\nexit 3\n")
+
+	err := Fire(context.Background(), PreConfigSave, nil)
+	if err == nil {
+		t.Fatal("Fire(pre-config-save) succeeded, want policy failure")
+	}
+	if !strings.Contains(err.Error(), "policy denied") {
+		t.Errorf("error %v does not surface hook stderr", err)
+	}
+}
+
+func TestFireIgnoresNonMatchingAndMissingDir(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	if err := Fire(context.Background(), PreTask, nil); err != nil {
+		t.Fatalf("Fire with no hooks dir = %v", err)
+	}
+}
@@ -0,0 +1,141 @@
+// Package ignore decides which workspace files stay out of collected
+// context, honoring .clineignore and .gitignore. It implements the commonly
+// used subset of gitignore syntax — comments, negation, directory-only and
+// anchored patterns — which covers real ignore files without pulling in a
+// full git implementation.
+package ignore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Rule is one parsed ignore pattern, remembering where it came from so an
+// exclusion can be explained.
+type Rule struct {
+	Pattern  string
+	Negate   bool
+	DirOnly  bool
+	Anchored bool
+	Source   string
+	Line     int
+}
+
+// Matcher holds the rules of a workspace's ignore files, in order; the last
+// matching rule wins, like git.
+type Matcher struct {
+	rules []Rule
+}
+
+// Load reads the workspace's ignore files. .gitignore is read first and
+// .clineignore second, so cline-specific rules override. Missing files are
+// simply empty.
+func Load(root string) *Matcher {
+	m := &Matcher{}
+	for _, name := range []string{".gitignore", ".clineignore"} {
+		m.read(filepath.Join(root, name), name)
+	}
+	return m
+}
+
+func (m *Matcher) read(path, source string) {
+	f, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		rule := Rule{Source: source, Line: lineNo}
+		if strings.HasPrefix(line, "!") {
+			rule.Negate = true
+			line = line[1:]
+		}
+		if strings.HasSuffix(line, "/") {
+			rule.DirOnly = true
+			line = strings.TrimSuffix(line, "/")
+		}
+		if strings.HasPrefix(line, "/") {
+			rule.Anchored = true
+			line = strings.TrimPrefix(line, "/")
+		} else if strings.Contains(line, "/") {
+			// A slash anywhere anchors the pattern to the root, per git.
+			rule.Anchored = true
+		}
+		rule.Pattern = line
+		m.rules = append(m.rules, rule)
+	}
+}
+
+// Excluded reports whether a path, relative to the workspace root, is
+// ignored. isDir matters for directory-only patterns.
+func (m *Matcher) Excluded(rel string, isDir bool) bool {
+	excluded, _ := m.Explain(rel, isDir)
+	return excluded
+}
+
+// Explain reports whether a path is ignored and which rule decided it, as
+// "source:line: pattern". Paths no rule matches return false with an empty
+// reason.
+func (m *Matcher) Explain(rel string, isDir bool) (bool, string) {
+	rel = filepath.ToSlash(rel)
+	excluded := false
+	reason := ""
+	for _, rule := range m.rules {
+		if !rule.matches(rel, isDir) {
+			continue
+		}
+		excluded = !rule.Negate
+		verb := "excluded by"
+		if rule.Negate {
+			verb = "re-included by"
+		}
+		pattern := rule.Pattern
+		if rule.DirOnly {
+			pattern += "/"
+		}
+		reason = fmt.Sprintf("%s %s:%d: %s", verb, rule.Source, rule.Line, pattern)
+	}
+	return excluded, reason
+}
+
+// matches checks one rule against a path. Directory-only rules also match
+// everything under a matching directory, and unanchored rules match the
+// pattern in any directory.
+func (r Rule) matches(rel string, isDir bool) bool {
+	segments := strings.Split(rel, "/")
+	if r.Anchored {
+		if ok, _ := path.Match(r.Pattern, rel); ok && (!r.DirOnly || isDir) {
+			return true
+		}
+		// A matching parent directory excludes everything below it.
+		for i := 1; i < len(segments); i++ {
+			if ok, _ := path.Match(r.Pattern, strings.Join(segments[:i], "/")); ok {
+				return true
+			}
+		}
+		return false
+	}
+	// Unanchored: the pattern may match the base name or any parent
+	// directory's name.
+	base := segments[len(segments)-1]
+	if ok, _ := path.Match(r.Pattern, base); ok && (!r.DirOnly || isDir) {
+		return true
+	}
+	for _, parent := range segments[:len(segments)-1] {
+		if ok, _ := path.Match(r.Pattern, parent); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func matcherFor(t *testing.T, gitignore, clineignore string) *Matcher {
+	t.Helper()
+	root := t.TempDir()
+	if gitignore != "" {
+		if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte(gitignore), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if clineignore != "" {
+		if err := os.WriteFile(filepath.Join(root, ".clineignore"), []byte(clineignore), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return Load(root)
+}
+
+func TestExcluded(t *testing.T) {
+	m := matcherFor(t, "*.log\nbuild/\n/secrets.env\ndocs/internal\n", "")
+	cases := []struct {
+		rel   string
+		isDir bool
+		want  bool
+	}{
+		{"debug.log", false, true},
+		{"sub/dir/debug.log", false, true},
+		{"debug.log.txt", false, false},
+		{"build", true, true},
+		{"build/out.bin", false, true},
+		{"builder", true, false},
+		{"secrets.env", false, true},
+		{"sub/secrets.env", false, false}, // anchored to the root
+		{"docs/internal", false, true},
+		{"docs/internal/plan.md", false, true},
+		{"internal", false, false},
+		{"main.go", false, false},
+	}
+	for _, tc := range cases {
+		if got := m.Excluded(tc.rel, tc.isDir); got != tc.want {
+			t.Errorf("Excluded(%q, isDir=%v) = %v, want %v", tc.rel, tc.isDir, got, tc.want)
+		}
+	}
+}
+
+func TestNegationAndPrecedence(t *testing.T) {
+	m := matcherFor(t, "*.md\n", "!README.md\nnotes/\n")
+	if m.Excluded("README.md", false) {
+		t.Error(".clineignore negation should re-include README.md")
+	}
+	if !m.Excluded("CHANGELOG.md", false) {
+		t.Error("other .md files stay excluded")
+	}
+	if !m.Excluded("notes/today.txt", false) {
+		t.Error(".clineignore rules should apply")
+	}
+}
+
+func TestExplain(t *testing.T) {
+	m := matcherFor(t, "*.log\n", "!keep.log\n")
+	excluded, reason := m.Explain("debug.log", false)
+	if !excluded || !strings.Contains(reason, ".gitignore:1") || !strings.Contains(reason, "*.log") {
+		t.Errorf("Explain = %v, %q", excluded, reason)
+	}
+	excluded, reason = m.Explain("keep.log", false)
+	if excluded || !strings.Contains(reason, ".clineignore:1") {
+		t.Errorf("negated Explain = %v, %q", excluded, reason)
+	}
+	if excluded, reason = m.Explain("main.go", false); excluded || reason != "" {
+		t.Errorf("unmatched Explain = %v, %q", excluded, reason)
+	}
+}
+
+func TestCommentsAndBlanks(t *testing.T) {
+	m := matcherFor(t, "# comment\n\n*.tmp\n", "")
+	if !m.Excluded("a.tmp", false) {
+		t.Error("*.tmp should be excluded")
+	}
+	if m.Excluded("# comment", false) {
+		t.Error("comments are not patterns")
+	}
+}
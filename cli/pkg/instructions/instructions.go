@@ -0,0 +1,143 @@
+// Package instructions manages named system-prompt profiles stored as
+// markdown files under ~/.cline/instructions, shared across ask and task
+// commands.
+package instructions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// settingPrefix keys per-workspace default profiles in the settings map:
+// instructions.default.<absolute dir> = <profile name>.
+const settingPrefix = "instructions.default."
+
+// Dir returns the profile directory.
+func Dir() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "instructions"), nil
+}
+
+// path returns the file backing a profile name.
+func path(name string) (string, error) {
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid profile name %q", name)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, name+".md"), nil
+}
+
+// Load returns the instruction text for a named profile.
+func Load(name string) (string, error) {
+	p, err := path(name)
+	if err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		names, _ := List()
+		if len(names) == 0 {
+			return "", fmt.Errorf("no instruction profile %q (none saved yet; run `cline instructions set %s`)", name, name)
+		}
+		return "", fmt.Errorf("no instruction profile %q (have: %s)", name, strings.Join(names, ", "))
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Save writes a profile.
+func Save(name, text string) error {
+	p, err := path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(p, []byte(strings.TrimSpace(text)+"\n"), 0o600)
+}
+
+// Remove deletes a profile.
+func Remove(name string) error {
+	p, err := path(name)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); os.IsNotExist(err) {
+		return fmt.Errorf("no instruction profile %q", name)
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+// List returns the saved profile names, sorted.
+func List() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".md") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".md"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// DefaultFor returns the default profile name for a workspace directory,
+// honoring the deepest configured ancestor, like workspace trust does.
+func DefaultFor(settings map[string]string, dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	best, bestLen := "", -1
+	for key, name := range settings {
+		if !strings.HasPrefix(key, settingPrefix) {
+			continue
+		}
+		root := strings.TrimSuffix(key[len(settingPrefix):], string(filepath.Separator))
+		rel, err := filepath.Rel(root, abs)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(root) > bestLen {
+			best, bestLen = name, len(root)
+		}
+	}
+	return best
+}
+
+// SetDefault records a workspace's default profile in the settings map.
+func SetDefault(settings map[string]string, dir, name string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	settings[settingPrefix+abs] = name
+	return nil
+}
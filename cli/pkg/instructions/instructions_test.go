@@ -0,0 +1,76 @@
+package instructions
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoadListRemove(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Save("go-expert", "You are a Go reviewer.\n"); err != nil {
+		t.Fatal(err)
+	}
+	text, err := Load("go-expert")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text != "You are a Go reviewer." {
+		t.Errorf("text = %q", text)
+	}
+	names, err := List()
+	if err != nil || len(names) != 1 || names[0] != "go-expert" {
+		t.Errorf("names = %v, err = %v", names, err)
+	}
+	if err := Remove("go-expert"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load("go-expert"); err == nil {
+		t.Error("removed profile still loads")
+	}
+}
+
+func TestLoadMissingNamesAlternatives(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Save("concise-reviewer", "Be brief."); err != nil {
+		t.Fatal(err)
+	}
+	_, err := Load("typo")
+	if err == nil {
+		t.Fatal("missing profile did not error")
+	}
+	if want := "concise-reviewer"; err != nil && !strings.Contains(err.Error(), want) {
+		t.Errorf("error %q does not mention %q", err, want)
+	}
+}
+
+func TestPathRejectsTraversal(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if err := Save("../evil", "x"); err == nil {
+		t.Error("traversal name accepted")
+	}
+	if _, err := Load(".hidden"); err == nil {
+		t.Error("dotfile name accepted")
+	}
+}
+
+func TestWorkspaceDefaults(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "svc", "api")
+	settings := map[string]string{}
+	if err := SetDefault(settings, root, "go-expert"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SetDefault(settings, nested, "concise-reviewer"); err != nil {
+		t.Fatal(err)
+	}
+	if got := DefaultFor(settings, nested); got != "concise-reviewer" {
+		t.Errorf("nested default = %q", got)
+	}
+	if got := DefaultFor(settings, filepath.Join(root, "other")); got != "go-expert" {
+		t.Errorf("root default = %q", got)
+	}
+	if got := DefaultFor(settings, t.TempDir()); got != "" {
+		t.Errorf("unrelated dir default = %q", got)
+	}
+}
@@ -0,0 +1,218 @@
+package interop
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// DefaultToolConfigPath returns where a competing tool keeps its config on
+// this machine, or "" when unknown.
+func DefaultToolConfigPath(tool string) string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	switch tool {
+	case "aider":
+		return filepath.Join(home, ".aider.conf.yml")
+	case "llm":
+		base := os.Getenv("XDG_CONFIG_HOME")
+		if base == "" {
+			base = filepath.Join(home, ".config")
+		}
+		return filepath.Join(base, "io.datasette.llm", "keys.json")
+	case "continue":
+		return filepath.Join(home, ".continue", "config.json")
+	}
+	return ""
+}
+
+// ImportTool parses another CLI tool's config into an ImportResult, mapping
+// model names onto registry IDs where possible. Unmappable models are kept
+// verbatim and reported in Unmapped.
+func ImportTool(tool string, data []byte, registry *generated.ProviderRegistry) (*ImportResult, error) {
+	switch tool {
+	case "aider":
+		return importAider(data, registry)
+	case "llm":
+		return importLLMKeys(data)
+	case "continue":
+		return importContinue(data, registry)
+	}
+	return nil, fmt.Errorf("unsupported import source %q", tool)
+}
+
+// importAider reads ~/.aider.conf.yml, which is flat `key: value` YAML.
+func importAider(data []byte, registry *generated.ProviderRegistry) (*ImportResult, error) {
+	values := parseFlatYAML(data)
+	result := &ImportResult{Providers: make(map[string]*config.ProviderConfig)}
+	if key := values["openai-api-key"]; key != "" {
+		entry := &config.ProviderConfig{Provider: "openai", APIKey: key}
+		if base := values["openai-api-base"]; base != "" {
+			entry.BaseURL = base
+		}
+		result.Providers["openai"] = entry
+	}
+	if key := values["anthropic-api-key"]; key != "" {
+		result.Providers["anthropic"] = &config.ProviderConfig{Provider: "anthropic", APIKey: key}
+	}
+	if model := values["model"]; model != "" {
+		providerID, modelID, ok := mapModel(registry, model)
+		if !ok {
+			result.Unmapped = append(result.Unmapped, model)
+		} else {
+			entry := result.Providers[providerID]
+			if entry == nil {
+				entry = &config.ProviderConfig{Provider: providerID}
+				result.Providers[providerID] = entry
+			}
+			entry.ModelID = modelID
+			result.DefaultProvider = providerID
+		}
+	}
+	return result, nil
+}
+
+// llmKeyAliases maps llm's key names onto registry provider IDs.
+var llmKeyAliases = map[string]string{
+	"openai":     "openai",
+	"claude":     "anthropic",
+	"anthropic":  "anthropic",
+	"gemini":     "gemini",
+	"openrouter": "openrouter",
+	"deepseek":   "deepseek",
+	"xai":        "xai",
+}
+
+// importLLMKeys reads the `llm` tool's keys.json, a flat name→key object.
+func importLLMKeys(data []byte) (*ImportResult, error) {
+	var keys map[string]string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("parsing llm keys.json: %w", err)
+	}
+	result := &ImportResult{Providers: make(map[string]*config.ProviderConfig)}
+	for name, key := range keys {
+		providerID, ok := llmKeyAliases[name]
+		if !ok {
+			result.Unmapped = append(result.Unmapped, name)
+			continue
+		}
+		result.Providers[providerID] = &config.ProviderConfig{Provider: providerID, APIKey: key}
+	}
+	sort.Strings(result.Unmapped)
+	return result, nil
+}
+
+// importContinue reads Continue's config.json models array.
+func importContinue(data []byte, registry *generated.ProviderRegistry) (*ImportResult, error) {
+	var cfg struct {
+		Models []struct {
+			Provider string `json:"provider"`
+			Model    string `json:"model"`
+			APIKey   string `json:"apiKey"`
+			APIBase  string `json:"apiBase"`
+		} `json:"models"`
+	}
+	if err := json.Unmarshal(stripJSONC(data), &cfg); err != nil {
+		return nil, fmt.Errorf("parsing continue config: %w", err)
+	}
+	result := &ImportResult{Providers: make(map[string]*config.ProviderConfig)}
+	for _, m := range cfg.Models {
+		providerID := m.Provider
+		if _, err := registry.Provider(providerID); err != nil {
+			result.Unmapped = append(result.Unmapped, fmt.Sprintf("%s/%s", m.Provider, m.Model))
+			continue
+		}
+		entry := result.Providers[providerID]
+		if entry == nil {
+			entry = &config.ProviderConfig{Provider: providerID}
+			result.Providers[providerID] = entry
+		}
+		if m.APIKey != "" {
+			entry.APIKey = m.APIKey
+		}
+		if m.APIBase != "" {
+			entry.BaseURL = m.APIBase
+		}
+		if entry.ModelID == "" && m.Model != "" {
+			if _, modelID, ok := mapModelUnder(registry, providerID, m.Model); ok {
+				entry.ModelID = modelID
+			} else {
+				entry.ModelID = m.Model
+				result.Unmapped = append(result.Unmapped, fmt.Sprintf("%s/%s", m.Provider, m.Model))
+			}
+		}
+		if result.DefaultProvider == "" {
+			result.DefaultProvider = providerID
+		}
+	}
+	return result, nil
+}
+
+// mapModel finds which provider offers a model matching name, searching every
+// provider's static catalog.
+func mapModel(registry *generated.ProviderRegistry, name string) (providerID, modelID string, ok bool) {
+	// Names like "anthropic/claude-..." carry their provider.
+	if prefix, rest, found := strings.Cut(name, "/"); found {
+		if _, err := registry.Provider(prefix); err == nil {
+			if _, id, ok := mapModelUnder(registry, prefix, rest); ok {
+				return prefix, id, true
+			}
+		}
+	}
+	for _, id := range registry.ProviderIDs() {
+		if _, modelID, ok := mapModelUnder(registry, id, name); ok {
+			return id, modelID, true
+		}
+	}
+	return "", "", false
+}
+
+// mapModelUnder matches name against one provider's static models, exactly
+// first and then by prefix (aider drops date suffixes like -20240620).
+func mapModelUnder(registry *generated.ProviderRegistry, providerID, name string) (string, string, bool) {
+	def, err := registry.Provider(providerID)
+	if err != nil {
+		return "", "", false
+	}
+	for _, m := range def.Models {
+		if m.ID == name {
+			return providerID, m.ID, true
+		}
+	}
+	for _, m := range def.Models {
+		if strings.HasPrefix(m.ID, name) || strings.HasPrefix(name, m.ID) {
+			return providerID, m.ID, true
+		}
+	}
+	return "", "", false
+}
+
+// parseFlatYAML handles the flat `key: value` subset used by aider's conf
+// file; comments and nested structures are ignored.
+func parseFlatYAML(data []byte) map[string]string {
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		value = strings.Trim(value, `"'`)
+		if value != "" {
+			values[strings.TrimSpace(key)] = value
+		}
+	}
+	return values
+}
@@ -0,0 +1,76 @@
+package interop
+
+import (
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func testRegistry(t *testing.T) *generated.ProviderRegistry {
+	t.Helper()
+	r, err := generated.NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+func TestImportAider(t *testing.T) {
+	conf := `
+# aider config
+model: claude-sonnet-4
+anthropic-api-key: sk-ant-xyz
+openai-api-key: sk-oai-xyz
+openai-api-base: https://gateway.internal/v1
+`
+	result, err := ImportTool("aider", []byte(conf), testRegistry(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Providers["anthropic"].APIKey != "sk-ant-xyz" {
+		t.Error("anthropic key not imported")
+	}
+	if result.Providers["openai"].BaseURL != "https://gateway.internal/v1" {
+		t.Error("openai base URL not imported")
+	}
+	// "claude-sonnet-4" should prefix-match the dated registry ID.
+	if got := result.Providers["anthropic"].ModelID; got != "claude-sonnet-4-20250514" {
+		t.Errorf("mapped model = %q", got)
+	}
+	if result.DefaultProvider != "anthropic" {
+		t.Errorf("default provider = %q", result.DefaultProvider)
+	}
+}
+
+func TestImportLLMKeys(t *testing.T) {
+	keys := `{"openai": "sk-1", "claude": "sk-2", "mystery": "sk-3"}`
+	result, err := ImportTool("llm", []byte(keys), testRegistry(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Providers["openai"].APIKey != "sk-1" || result.Providers["anthropic"].APIKey != "sk-2" {
+		t.Errorf("keys not mapped: %+v", result.Providers)
+	}
+	if len(result.Unmapped) != 1 || result.Unmapped[0] != "mystery" {
+		t.Errorf("unmapped = %v", result.Unmapped)
+	}
+}
+
+func TestImportContinue(t *testing.T) {
+	conf := `{
+		"models": [
+			{"provider": "openai", "model": "gpt-4.1", "apiKey": "sk-oai"},
+			{"provider": "not-a-provider", "model": "whatever"}
+		]
+	}`
+	result, err := ImportTool("continue", []byte(conf), testRegistry(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.Providers["openai"]; got == nil || got.ModelID != "gpt-4.1" || got.APIKey != "sk-oai" {
+		t.Errorf("openai entry = %+v", got)
+	}
+	if len(result.Unmapped) != 1 {
+		t.Errorf("unmapped = %v", result.Unmapped)
+	}
+}
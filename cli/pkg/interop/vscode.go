@@ -0,0 +1,62 @@
+// Package interop converts between the CLI's config and the formats used by
+// the Cline VS Code extension and other tools, so users can mirror a setup
+// across surfaces without retyping keys.
+package interop
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// VSCodeSettings is the settings.json fragment the Cline extension reads,
+// plus the secret-storage instructions for values that must not land in
+// settings.json.
+type VSCodeSettings struct {
+	Settings map[string]any
+	// SecretInstructions tells the user how to store each API key through the
+	// extension's secret storage (keys never belong in settings.json).
+	SecretInstructions []string
+}
+
+// ExportVSCode renders the configured providers as a VS Code settings
+// fragment. Secrets are omitted from the fragment and reported as
+// instructions instead.
+func ExportVSCode(cfg *config.ClineConfig) *VSCodeSettings {
+	out := &VSCodeSettings{Settings: make(map[string]any)}
+	if cfg.DefaultProvider != "" {
+		out.Settings["cline.apiProvider"] = cfg.DefaultProvider
+		if def := cfg.Providers[cfg.DefaultProvider]; def != nil && def.ModelID != "" {
+			out.Settings["cline.apiModelId"] = def.ModelID
+		}
+	}
+	ids := make([]string, 0, len(cfg.Providers))
+	for id := range cfg.Providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	providers := make(map[string]any, len(ids))
+	for _, id := range ids {
+		entry := cfg.Providers[id]
+		p := map[string]any{}
+		if entry.ModelID != "" {
+			p["modelId"] = entry.ModelID
+		}
+		if entry.BaseURL != "" {
+			p["baseUrl"] = entry.BaseURL
+		}
+		for k, v := range entry.ExtraConfig {
+			p[k] = v
+		}
+		providers[id] = p
+		if entry.APIKey != "" {
+			out.SecretInstructions = append(out.SecretInstructions,
+				fmt.Sprintf("%s: open the Cline extension settings, select %q, and paste the API key; it is stored in VS Code secret storage", id, id))
+		}
+	}
+	if len(providers) > 0 {
+		out.Settings["cline.providers"] = providers
+	}
+	return out
+}
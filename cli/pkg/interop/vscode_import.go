@@ -0,0 +1,204 @@
+package interop
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// ImportResult is what could be recovered from another tool's configuration.
+// Secrets generally cannot be read (VS Code keeps them in secret storage), so
+// providers whose keys are missing are listed for the caller to prompt for.
+type ImportResult struct {
+	DefaultProvider string
+	Providers       map[string]*config.ProviderConfig
+	// MissingSecrets lists provider IDs that were imported without an API
+	// key and need one prompted for.
+	MissingSecrets []string
+	// Unmapped lists model or key names from the source tool that could not
+	// be matched against the registry.
+	Unmapped []string
+}
+
+// LocateVSCodeSettings returns existing VS Code user settings.json paths on
+// this machine, covering stable, Insiders, and VSCodium installs.
+func LocateVSCodeSettings() []string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+	var bases []string
+	switch runtime.GOOS {
+	case "darwin":
+		root := filepath.Join(home, "Library", "Application Support")
+		bases = []string{filepath.Join(root, "Code"), filepath.Join(root, "Code - Insiders"), filepath.Join(root, "VSCodium")}
+	case "windows":
+		root := os.Getenv("APPDATA")
+		if root == "" {
+			return nil
+		}
+		bases = []string{filepath.Join(root, "Code"), filepath.Join(root, "Code - Insiders"), filepath.Join(root, "VSCodium")}
+	default:
+		root := os.Getenv("XDG_CONFIG_HOME")
+		if root == "" {
+			root = filepath.Join(home, ".config")
+		}
+		bases = []string{filepath.Join(root, "Code"), filepath.Join(root, "Code - Insiders"), filepath.Join(root, "VSCodium")}
+	}
+	var found []string
+	for _, base := range bases {
+		path := filepath.Join(base, "User", "settings.json")
+		if _, err := os.Stat(path); err == nil {
+			found = append(found, path)
+		}
+	}
+	return found
+}
+
+// ImportVSCode extracts Cline provider settings from a VS Code settings.json
+// document (JSON with comments tolerated).
+func ImportVSCode(data []byte) (*ImportResult, error) {
+	var settings map[string]json.RawMessage
+	if err := json.Unmarshal(stripJSONC(data), &settings); err != nil {
+		return nil, fmt.Errorf("parsing settings.json: %w", err)
+	}
+	result := &ImportResult{Providers: make(map[string]*config.ProviderConfig)}
+	if raw, ok := settings["cline.apiProvider"]; ok {
+		json.Unmarshal(raw, &result.DefaultProvider)
+	}
+	var defaultModel string
+	if raw, ok := settings["cline.apiModelId"]; ok {
+		json.Unmarshal(raw, &defaultModel)
+	}
+	if raw, ok := settings["cline.providers"]; ok {
+		var providers map[string]map[string]string
+		if err := json.Unmarshal(raw, &providers); err != nil {
+			return nil, fmt.Errorf("parsing cline.providers: %w", err)
+		}
+		for id, fields := range providers {
+			entry := &config.ProviderConfig{Provider: id}
+			for name, value := range fields {
+				switch name {
+				case "modelId":
+					entry.ModelID = value
+				default:
+					entry.SetField(name, value)
+				}
+			}
+			result.Providers[id] = entry
+		}
+	}
+	if result.DefaultProvider != "" {
+		entry := result.Providers[result.DefaultProvider]
+		if entry == nil {
+			entry = &config.ProviderConfig{Provider: result.DefaultProvider}
+			result.Providers[result.DefaultProvider] = entry
+		}
+		if entry.ModelID == "" {
+			entry.ModelID = defaultModel
+		}
+	}
+	for id, entry := range result.Providers {
+		if entry.APIKey == "" && providerNeedsKey(id) {
+			result.MissingSecrets = append(result.MissingSecrets, id)
+		}
+	}
+	sort.Strings(result.MissingSecrets)
+	return result, nil
+}
+
+// providerNeedsKey is a conservative check for whether a provider uses an API
+// key; local servers do not.
+func providerNeedsKey(id string) bool {
+	switch id {
+	case "ollama", "lmstudio", "vertex", "bedrock":
+		return false
+	}
+	return true
+}
+
+// stripJSONC removes // and /* */ comments and trailing commas, the JSONC
+// extensions VS Code allows in settings.json. Comments are removed first so a
+// comma separated from its closing brace only by a comment is still
+// recognized as trailing.
+func stripJSONC(data []byte) []byte {
+	return stripTrailingCommas(stripComments(data))
+}
+
+func stripComments(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				out = append(out, data[i+1])
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		switch {
+		case c == '"':
+			inString = true
+			out = append(out, c)
+		case c == '/' && i+1 < len(data) && data[i+1] == '/':
+			for i < len(data) && data[i] != '\n' {
+				i++
+			}
+			if i < len(data) {
+				out = append(out, '\n')
+			}
+		case c == '/' && i+1 < len(data) && data[i+1] == '*':
+			i += 2
+			for i+1 < len(data) && !(data[i] == '*' && data[i+1] == '/') {
+				i++
+			}
+			i++
+		default:
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+func stripTrailingCommas(data []byte) []byte {
+	out := make([]byte, 0, len(data))
+	inString := false
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			out = append(out, c)
+			if c == '\\' && i+1 < len(data) {
+				out = append(out, data[i+1])
+				i++
+			} else if c == '"' {
+				inString = false
+			}
+			continue
+		}
+		if c == '"' {
+			inString = true
+			out = append(out, c)
+			continue
+		}
+		if c == ',' {
+			j := i + 1
+			for j < len(data) && (data[j] == ' ' || data[j] == '\t' || data[j] == '\n' || data[j] == '\r') {
+				j++
+			}
+			if j < len(data) && (data[j] == '}' || data[j] == ']') {
+				continue
+			}
+		}
+		out = append(out, c)
+	}
+	return out
+}
@@ -0,0 +1,48 @@
+package interop
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const sampleSettings = `{
+	// editor settings elided
+	"cline.apiProvider": "anthropic",
+	"cline.apiModelId": "claude-sonnet-4-20250514",
+	"cline.providers": {
+		"anthropic": { "modelId": "claude-sonnet-4-20250514" },
+		"ollama": { "baseUrl": "http://localhost:11434" }, /* local */
+	},
+}`
+
+func TestImportVSCode(t *testing.T) {
+	result, err := ImportVSCode([]byte(sampleSettings))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.DefaultProvider != "anthropic" {
+		t.Errorf("default provider = %q", result.DefaultProvider)
+	}
+	if got := result.Providers["anthropic"].ModelID; got != "claude-sonnet-4-20250514" {
+		t.Errorf("anthropic model = %q", got)
+	}
+	if got := result.Providers["ollama"].BaseURL; got != "http://localhost:11434" {
+		t.Errorf("ollama baseUrl = %q", got)
+	}
+	// Anthropic's key lives in secret storage, so it must be prompted for;
+	// ollama needs no key.
+	if len(result.MissingSecrets) != 1 || result.MissingSecrets[0] != "anthropic" {
+		t.Errorf("missing secrets = %v", result.MissingSecrets)
+	}
+}
+
+func TestStripJSONCPreservesStrings(t *testing.T) {
+	in := `{"a": "http://x//y", "b": "/* not a comment */",}`
+	var out map[string]string
+	if err := json.Unmarshal(stripJSONC([]byte(in)), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out["a"] != "http://x//y" || out["b"] != "/* not a comment */" {
+		t.Errorf("strings were mangled: %v", out)
+	}
+}
@@ -0,0 +1,36 @@
+package interop
+
+import (
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func TestExportVSCodeOmitsSecrets(t *testing.T) {
+	cfg := config.NewClineConfig()
+	cfg.DefaultProvider = "anthropic"
+	a := cfg.Provider("anthropic")
+	a.ModelID = "claude-sonnet-4-20250514"
+	a.APIKey = "sk-ant-secret"
+	o := cfg.Provider("ollama")
+	o.BaseURL = "http://localhost:11434"
+
+	out := ExportVSCode(cfg)
+	if out.Settings["cline.apiProvider"] != "anthropic" {
+		t.Errorf("apiProvider = %v", out.Settings["cline.apiProvider"])
+	}
+	if out.Settings["cline.apiModelId"] != "claude-sonnet-4-20250514" {
+		t.Errorf("apiModelId = %v", out.Settings["cline.apiModelId"])
+	}
+	providers := out.Settings["cline.providers"].(map[string]any)
+	anthropic := providers["anthropic"].(map[string]any)
+	if _, leaked := anthropic["apiKey"]; leaked {
+		t.Error("API key leaked into settings fragment")
+	}
+	if len(out.SecretInstructions) != 1 {
+		t.Errorf("got %d secret instructions, want 1", len(out.SecretInstructions))
+	}
+	if providers["ollama"].(map[string]any)["baseUrl"] != "http://localhost:11434" {
+		t.Error("ollama base URL missing from export")
+	}
+}
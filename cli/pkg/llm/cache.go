@@ -0,0 +1,65 @@
+package llm
+
+import (
+	"fmt"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// Breakpoint strategies for prompt caching.
+const (
+	// BreakpointsFinal places one cache breakpoint on the last content block.
+	BreakpointsFinal = "final"
+	// BreakpointsAll places a breakpoint on every content block.
+	BreakpointsAll = "all"
+)
+
+// cachingProviders speak the cache_control content-block extension.
+var cachingProviders = map[string]bool{"anthropic": true, "bedrock": true}
+
+// promptCachingActive reports whether caching should be requested: the
+// provider must support the extension, the selected model must advertise
+// prompt cache support, and the entry must not have disabled it.
+func promptCachingActive(def *generated.ProviderDefinition, entry *config.ProviderConfig) bool {
+	if !cachingProviders[def.ID] {
+		return false
+	}
+	if entry.PromptCache != nil && entry.PromptCache.Disabled {
+		return false
+	}
+	for _, m := range def.Models {
+		if m.ID == entry.ModelID {
+			return m.SupportsPromptCache
+		}
+	}
+	return false
+}
+
+// cacheBreakpoints returns the entry's breakpoint strategy, validated.
+func cacheBreakpoints(entry *config.ProviderConfig) (string, error) {
+	if entry.PromptCache == nil || entry.PromptCache.Breakpoints == "" {
+		return BreakpointsFinal, nil
+	}
+	switch s := entry.PromptCache.Breakpoints; s {
+	case BreakpointsFinal, BreakpointsAll:
+		return s, nil
+	default:
+		return "", fmt.Errorf("unknown cache breakpoints strategy %q (want %s or %s)", s, BreakpointsFinal, BreakpointsAll)
+	}
+}
+
+// cachedContent rewrites a plain text message into content blocks carrying
+// cache_control markers per the strategy. With a single block the strategies
+// coincide; multi-block message builders diverge on "all".
+func cachedContent(texts []string, strategy string) []map[string]any {
+	blocks := make([]map[string]any, 0, len(texts))
+	for i, text := range texts {
+		block := map[string]any{"type": "text", "text": text}
+		if strategy == BreakpointsAll || i == len(texts)-1 {
+			block["cache_control"] = map[string]string{"type": "ephemeral"}
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
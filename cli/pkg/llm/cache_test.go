@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func cachingDef(baseURL string) *generated.ProviderDefinition {
+	return &generated.ProviderDefinition{
+		ID:      "anthropic",
+		BaseURL: baseURL,
+		Models: []generated.ModelInfo{{
+			ID:                  "claude-sonnet-4-20250514",
+			InputPrice:          3.0,
+			OutputPrice:         15.0,
+			CacheReadsPrice:     0.3,
+			CacheWritesPrice:    3.75,
+			SupportsPromptCache: true,
+		}},
+	}
+}
+
+func TestCompleteSendsCacheControl(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"choices":[{"message":{"content":"hi"}}],` +
+			`"usage":{"prompt_tokens":2,"completion_tokens":1,"cache_read_input_tokens":1000,"cache_creation_input_tokens":50}}`))
+	}))
+	defer server.Close()
+
+	entry := &config.ProviderConfig{ModelID: "claude-sonnet-4-20250514"}
+	_, usage, err := Complete(context.Background(), cachingDef(server.URL), entry, Prompt{Text: "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(body, `"cache_control"`) {
+		t.Errorf("request body missing cache_control: %s", body)
+	}
+	if usage.CacheReadTokens != 1000 || usage.CacheWriteTokens != 50 {
+		t.Errorf("usage = %+v", usage)
+	}
+	// 2 input + 1 output + 1000 cache reads + 50 cache writes at catalog prices.
+	want := 2*3.0/1e6 + 1*15.0/1e6 + 1000*0.3/1e6 + 50*3.75/1e6
+	if diff := usage.CostUSD - want; diff > 1e-12 || diff < -1e-12 {
+		t.Errorf("cost = %v, want %v", usage.CostUSD, want)
+	}
+}
+
+func TestCompleteOmitsCacheControlWhenDisabled(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Write([]byte(`{"choices":[{"message":{"content":"hi"}}]}`))
+	}))
+	defer server.Close()
+
+	entry := &config.ProviderConfig{
+		ModelID:     "claude-sonnet-4-20250514",
+		PromptCache: &config.PromptCacheConfig{Disabled: true},
+	}
+	if _, _, err := Complete(context.Background(), cachingDef(server.URL), entry, Prompt{Text: "hello"}); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(body, "cache_control") {
+		t.Errorf("caching disabled but request carried cache_control: %s", body)
+	}
+}
+
+func TestCacheBreakpointsValidation(t *testing.T) {
+	if _, err := cacheBreakpoints(&config.ProviderConfig{}); err != nil {
+		t.Error(err)
+	}
+	bad := &config.ProviderConfig{PromptCache: &config.PromptCacheConfig{Breakpoints: "sometimes"}}
+	if _, err := cacheBreakpoints(bad); err == nil {
+		t.Error("invalid strategy accepted")
+	}
+}
+
+func TestCachedContentStrategies(t *testing.T) {
+	marked := func(blocks []map[string]any) int {
+		n := 0
+		for _, b := range blocks {
+			if _, ok := b["cache_control"]; ok {
+				n++
+			}
+		}
+		return n
+	}
+	texts := []string{"system", "history", "question"}
+	if n := marked(cachedContent(texts, BreakpointsFinal)); n != 1 {
+		t.Errorf("final strategy marked %d blocks", n)
+	}
+	if n := marked(cachedContent(texts, BreakpointsAll)); n != 3 {
+		t.Errorf("all strategy marked %d blocks", n)
+	}
+	// The blocks must stay serializable for the request payload.
+	if _, err := json.Marshal(cachedContent(texts, BreakpointsAll)); err != nil {
+		t.Error(err)
+	}
+}
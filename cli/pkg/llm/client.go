@@ -0,0 +1,193 @@
+// Package llm is the thin completion client behind `cline ask`: it speaks the
+// OpenAI-compatible chat completions shape that every configured endpoint
+// (including gateways and local servers) exposes.
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/models"
+)
+
+const requestTimeout = 120 * time.Second
+
+// httpClient carries completion traffic so tests (and transcript tooling) can
+// install their own transport.
+var httpClient = &http.Client{}
+
+// SetHTTPTransport swaps the transport used for completions; nil restores the
+// default.
+func SetHTTPTransport(rt http.RoundTripper) {
+	httpClient.Transport = rt
+}
+
+// clientFor returns the shared client unless the entry tunes the dialer
+// (connect timeout, keepalive). A test-installed transport always wins so
+// transcript tooling captures every request.
+func clientFor(entry *config.ProviderConfig) *http.Client {
+	if httpClient.Transport == nil {
+		if t := entry.DialTransport(); t != nil {
+			return &http.Client{Transport: t}
+		}
+	}
+	return httpClient
+}
+
+// Usage reports what one completion consumed.
+type Usage struct {
+	InputTokens  int
+	OutputTokens int
+	// CacheReadTokens and CacheWriteTokens report prompt cache activity when
+	// the provider supports it; a nonzero read count is a cache hit.
+	CacheReadTokens  int
+	CacheWriteTokens int
+	// CostUSD is estimated from the registry's pricing; zero when the model is
+	// not in the static catalog.
+	CostUSD float64
+}
+
+// Prompt is one request to a provider: optional system instructions, the
+// user's text, and any image attachments.
+type Prompt struct {
+	System string
+	Text   string
+	Images []Image
+	// ForceJSON asks for JSON-mode output on providers that support the
+	// response_format parameter; elsewhere it is a no-op and the caller
+	// validates the reply text instead.
+	ForceJSON bool
+}
+
+// jsonModeProviders honor the OpenAI response_format JSON-mode parameter.
+var jsonModeProviders = map[string]bool{
+	"openai": true, "openrouter": true, "ollama": true,
+	"lmstudio": true, "litellm": true, "deepseek": true, "xai": true,
+}
+
+// Complete sends a single-turn prompt and returns the assistant's reply.
+func Complete(ctx context.Context, def *generated.ProviderDefinition, entry *config.ProviderConfig, p Prompt) (string, Usage, error) {
+	endpoint := entry.BaseURL
+	if endpoint == "" {
+		endpoint = def.BaseURL
+	}
+	if endpoint == "" {
+		return "", Usage{}, fmt.Errorf("provider %s has no endpoint configured", def.ID)
+	}
+	if len(p.Images) > 0 {
+		for _, m := range def.Models {
+			if m.ID == entry.ModelID && !m.SupportsImages {
+				return "", Usage{}, fmt.Errorf("model %s does not support image input", entry.ModelID)
+			}
+		}
+	}
+	var content any = p.Text
+	if promptCachingActive(def, entry) || len(p.Images) > 0 {
+		blocks := []map[string]any{{"type": "text", "text": p.Text}}
+		if promptCachingActive(def, entry) {
+			strategy, err := cacheBreakpoints(entry)
+			if err != nil {
+				return "", Usage{}, err
+			}
+			blocks = cachedContent([]string{p.Text}, strategy)
+		}
+		for _, img := range p.Images {
+			blocks = append(blocks, map[string]any{
+				"type":      "image_url",
+				"image_url": map[string]string{"url": img.dataURL()},
+			})
+		}
+		content = blocks
+	}
+	var messages []map[string]any
+	if p.System != "" {
+		messages = append(messages, map[string]any{"role": "system", "content": p.System})
+	}
+	messages = append(messages, map[string]any{"role": "user", "content": content})
+	body := map[string]any{
+		"model":    entry.ModelID,
+		"messages": messages,
+	}
+	if err := applyReasoning(body, def, entry); err != nil {
+		return "", Usage{}, err
+	}
+	if p.ForceJSON && jsonModeProviders[def.ID] {
+		body["response_format"] = map[string]string{"type": "json_object"}
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return "", Usage{}, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, entry.RequestTimeout(requestTimeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return "", Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if entry.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+entry.APIKey)
+	}
+	entry.ApplyHeaders(req.Header)
+	resp, err := clientFor(entry).Do(req)
+	if err != nil {
+		return "", Usage{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return "", Usage{}, &StatusError{Provider: def.ID, Code: resp.StatusCode, Status: resp.Status, Body: strings.TrimSpace(string(body))}
+	}
+	var decoded struct {
+		Choices []struct {
+			Message struct {
+				Content string `json:"content"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens        int `json:"prompt_tokens"`
+			CompletionTokens    int `json:"completion_tokens"`
+			CacheReadTokens     int `json:"cache_read_input_tokens"`
+			CacheCreationTokens int `json:"cache_creation_input_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return "", Usage{}, fmt.Errorf("decoding %s response: %w", def.ID, err)
+	}
+	if len(decoded.Choices) == 0 {
+		return "", Usage{}, fmt.Errorf("%s returned no choices", def.ID)
+	}
+	usage := Usage{
+		InputTokens:      decoded.Usage.PromptTokens,
+		OutputTokens:     decoded.Usage.CompletionTokens,
+		CacheReadTokens:  decoded.Usage.CacheReadTokens,
+		CacheWriteTokens: decoded.Usage.CacheCreationTokens,
+	}
+	if m := catalogModel(def, entry); m != nil {
+		usage.CostUSD = float64(usage.InputTokens)*m.InputPrice/1_000_000 +
+			float64(usage.OutputTokens)*m.OutputPrice/1_000_000 +
+			float64(usage.CacheReadTokens)*m.CacheReadsPrice/1_000_000 +
+			float64(usage.CacheWriteTokens)*m.CacheWritesPrice/1_000_000
+	}
+	return decoded.Choices[0].Message.Content, usage, nil
+}
+
+// catalogModel returns the configured model's static catalog entry with any
+// user overrides applied; nil when the model is not in the catalog.
+func catalogModel(def *generated.ProviderDefinition, entry *config.ProviderConfig) *generated.ModelInfo {
+	for _, m := range def.Models {
+		if m.ID == entry.ModelID {
+			models.Override(entry, &m)
+			return &m
+		}
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// Embed returns one vector per input via the provider's OpenAI-compatible
+// /embeddings endpoint, using the entry's embeddings model or the provider's
+// default. The chosen model is returned alongside the vectors.
+func Embed(ctx context.Context, def *generated.ProviderDefinition, entry *config.ProviderConfig, inputs []string) (string, [][]float64, error) {
+	model := entry.EmbeddingsModelID
+	if model == "" {
+		model = def.DefaultEmbeddingsModelID
+	}
+	if model == "" {
+		return "", nil, fmt.Errorf("provider %s has no embeddings model configured", def.ID)
+	}
+	endpoint := entry.BaseURL
+	if endpoint == "" {
+		endpoint = def.BaseURL
+	}
+	if endpoint == "" {
+		return "", nil, fmt.Errorf("provider %s has no endpoint configured", def.ID)
+	}
+	payload, err := json.Marshal(map[string]any{"model": model, "input": inputs})
+	if err != nil {
+		return "", nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, entry.RequestTimeout(requestTimeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/embeddings", bytes.NewReader(payload))
+	if err != nil {
+		return "", nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if entry.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+entry.APIKey)
+	}
+	entry.ApplyHeaders(req.Header)
+	resp, err := clientFor(entry).Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return "", nil, fmt.Errorf("%s: %s: %s", def.ID, resp.Status, strings.TrimSpace(string(body)))
+	}
+	var decoded struct {
+		Data []struct {
+			Index     int       `json:"index"`
+			Embedding []float64 `json:"embedding"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return "", nil, fmt.Errorf("decoding %s response: %w", def.ID, err)
+	}
+	vectors := make([][]float64, len(decoded.Data))
+	for _, d := range decoded.Data {
+		if d.Index < 0 || d.Index >= len(vectors) {
+			return "", nil, fmt.Errorf("%s returned out-of-range embedding index %d", def.ID, d.Index)
+		}
+		vectors[d.Index] = d.Embedding
+	}
+	return model, vectors, nil
+}
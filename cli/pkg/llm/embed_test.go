@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestEmbed(t *testing.T) {
+	var gotBody map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/embeddings" {
+			http.NotFound(w, r)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		json.Unmarshal(body, &gotBody)
+		w.Write([]byte(`{"data":[{"index":1,"embedding":[0.3,0.4]},{"index":0,"embedding":[0.1,0.2]}]}`))
+	}))
+	defer srv.Close()
+
+	def := &generated.ProviderDefinition{ID: "openai", DefaultEmbeddingsModelID: "text-embedding-3-small"}
+	entry := &config.ProviderConfig{Provider: "openai", BaseURL: srv.URL, APIKey: "sk-test"}
+	model, vectors, err := Embed(context.Background(), def, entry, []string{"a", "b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if model != "text-embedding-3-small" {
+		t.Errorf("model = %q", model)
+	}
+	if gotBody["model"] != "text-embedding-3-small" {
+		t.Errorf("request model = %v", gotBody["model"])
+	}
+	// Responses may arrive out of order; vectors must land at their index.
+	if len(vectors) != 2 || vectors[0][0] != 0.1 || vectors[1][0] != 0.3 {
+		t.Errorf("vectors = %v", vectors)
+	}
+}
+
+func TestEmbedEntryModelOverridesDefault(t *testing.T) {
+	var gotModel string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		json.NewDecoder(r.Body).Decode(&body)
+		gotModel, _ = body["model"].(string)
+		w.Write([]byte(`{"data":[{"index":0,"embedding":[1]}]}`))
+	}))
+	defer srv.Close()
+
+	def := &generated.ProviderDefinition{ID: "openai", DefaultEmbeddingsModelID: "text-embedding-3-small"}
+	entry := &config.ProviderConfig{Provider: "openai", BaseURL: srv.URL, EmbeddingsModelID: "text-embedding-3-large"}
+	if _, _, err := Embed(context.Background(), def, entry, []string{"a"}); err != nil {
+		t.Fatal(err)
+	}
+	if gotModel != "text-embedding-3-large" {
+		t.Errorf("model = %q", gotModel)
+	}
+}
+
+func TestEmbedRequiresEmbeddingsModel(t *testing.T) {
+	def := &generated.ProviderDefinition{ID: "xai", BaseURL: "https://api.x.ai/v1"}
+	_, _, err := Embed(context.Background(), def, &config.ProviderConfig{Provider: "xai"}, []string{"a"})
+	if err == nil {
+		t.Error("provider without embeddings model accepted")
+	}
+}
@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	_ "image/gif" // register decoder for attached GIFs
+)
+
+// maxImageDimension is the longest edge sent to a provider; larger images are
+// downscaled since vision models resize internally anyway and oversized
+// payloads only add cost and latency.
+const maxImageDimension = 1568
+
+// Image is one attachment ready to send.
+type Image struct {
+	MediaType string
+	Data      []byte
+}
+
+// LoadImage reads, validates and prepares an image file for sending:
+// unsupported formats are rejected and oversized images are downscaled and
+// re-encoded.
+func LoadImage(path string) (Image, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Image{}, err
+	}
+	img, format, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Image{}, fmt.Errorf("decoding %s: %w (want png, jpeg or gif)", filepath.Base(path), err)
+	}
+	bounds := img.Bounds()
+	if bounds.Dx() <= maxImageDimension && bounds.Dy() <= maxImageDimension && format != "gif" {
+		return Image{MediaType: "image/" + format, Data: data}, nil
+	}
+	// Downscale (and convert GIFs, which chat endpoints reject) by re-encoding.
+	scaled := downscale(img, maxImageDimension)
+	var buf bytes.Buffer
+	if format == "png" {
+		if err := png.Encode(&buf, scaled); err != nil {
+			return Image{}, err
+		}
+		return Image{MediaType: "image/png", Data: buf.Bytes()}, nil
+	}
+	if err := jpeg.Encode(&buf, scaled, &jpeg.Options{Quality: 90}); err != nil {
+		return Image{}, err
+	}
+	return Image{MediaType: "image/jpeg", Data: buf.Bytes()}, nil
+}
+
+// downscale resizes img so its longest edge is at most max, using
+// nearest-neighbor sampling; fidelity loss is acceptable for model input.
+func downscale(img image.Image, max int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= max && h <= max {
+		if rgba, ok := img.(*image.RGBA); ok {
+			return rgba
+		}
+		out := image.NewRGBA(image.Rect(0, 0, w, h))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				out.Set(x, y, img.At(bounds.Min.X+x, bounds.Min.Y+y))
+			}
+		}
+		return out
+	}
+	scale := float64(max) / float64(w)
+	if h > w {
+		scale = float64(max) / float64(h)
+	}
+	nw, nh := int(float64(w)*scale), int(float64(h)*scale)
+	out := image.NewRGBA(image.Rect(0, 0, nw, nh))
+	for y := 0; y < nh; y++ {
+		for x := 0; x < nw; x++ {
+			sx := bounds.Min.X + int(float64(x)/scale)
+			sy := bounds.Min.Y + int(float64(y)/scale)
+			out.Set(x, y, img.At(sx, sy))
+		}
+	}
+	return out
+}
+
+// dataURL encodes the image as the data: URL chat endpoints accept.
+func (i Image) dataURL() string {
+	var b strings.Builder
+	b.WriteString("data:")
+	b.WriteString(i.MediaType)
+	b.WriteString(";base64,")
+	b.WriteString(base64.StdEncoding.EncodeToString(i.Data))
+	return b.String()
+}
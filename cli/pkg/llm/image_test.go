@@ -0,0 +1,76 @@
+package llm
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writePNG(t *testing.T, w, h int) string {
+	t.Helper()
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "img.png")
+	if err := os.WriteFile(path, buf.Bytes(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadImageKeepsSmallImages(t *testing.T) {
+	img, err := LoadImage(writePNG(t, 100, 60))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.MediaType != "image/png" {
+		t.Errorf("media type = %q", img.MediaType)
+	}
+	if !strings.HasPrefix(img.dataURL(), "data:image/png;base64,") {
+		t.Errorf("data URL = %q...", img.dataURL()[:40])
+	}
+}
+
+func TestLoadImageDownscalesOversized(t *testing.T) {
+	img, err := LoadImage(writePNG(t, maxImageDimension+600, 200))
+	if err != nil {
+		t.Fatal(err)
+	}
+	decoded, _, err := image.Decode(bytes.NewReader(img.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := decoded.Bounds().Dx(); w != maxImageDimension {
+		t.Errorf("width after downscale = %d, want %d", w, maxImageDimension)
+	}
+}
+
+func TestLoadImageRejectsNonImages(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(path, []byte("plain text"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadImage(path); err == nil {
+		t.Error("non-image file accepted")
+	}
+}
+
+func TestDownscalePreservesAspectRatio(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	out := downscale(src, 100)
+	if out.Bounds().Dx() != 100 || out.Bounds().Dy() != 50 {
+		t.Errorf("bounds = %v", out.Bounds())
+	}
+}
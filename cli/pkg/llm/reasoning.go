@@ -0,0 +1,51 @@
+package llm
+
+import (
+	"fmt"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// minThinkingBudget is the smallest extended-thinking budget Anthropic
+// accepts.
+const minThinkingBudget = 1024
+
+// reasoningEfforts are the accepted reasoning_effort values.
+var reasoningEfforts = map[string]bool{"low": true, "medium": true, "high": true}
+
+// applyReasoning adds the reasoning effort or extended-thinking budget to the
+// request body when the entry configures one. Configuring a knob the selected
+// model does not support is an error rather than a silent no-op, since both
+// materially change cost and output.
+func applyReasoning(body map[string]any, def *generated.ProviderDefinition, entry *config.ProviderConfig) error {
+	if entry.ReasoningEffort == "" && entry.ThinkingBudgetTokens == 0 {
+		return nil
+	}
+	var model *generated.ModelInfo
+	for i := range def.Models {
+		if def.Models[i].ID == entry.ModelID {
+			model = &def.Models[i]
+			break
+		}
+	}
+	if entry.ReasoningEffort != "" {
+		if !reasoningEfforts[entry.ReasoningEffort] {
+			return fmt.Errorf("invalid reasoning effort %q (want low, medium or high)", entry.ReasoningEffort)
+		}
+		if model != nil && !model.SupportsReasoningEffort {
+			return fmt.Errorf("model %s does not support reasoning effort", entry.ModelID)
+		}
+		body["reasoning_effort"] = entry.ReasoningEffort
+	}
+	if entry.ThinkingBudgetTokens != 0 {
+		if entry.ThinkingBudgetTokens < minThinkingBudget {
+			return fmt.Errorf("thinking budget must be at least %d tokens", minThinkingBudget)
+		}
+		if model != nil && !model.SupportsExtendedThinking {
+			return fmt.Errorf("model %s does not support extended thinking", entry.ModelID)
+		}
+		body["thinking"] = map[string]any{"type": "enabled", "budget_tokens": entry.ThinkingBudgetTokens}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package llm
+
+import (
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func reasoningDef() *generated.ProviderDefinition {
+	return &generated.ProviderDefinition{
+		ID: "openai",
+		Models: []generated.ModelInfo{
+			{ID: "o3", SupportsReasoningEffort: true},
+			{ID: "gpt-4.1"},
+			{ID: "claude-sonnet-4-20250514", SupportsExtendedThinking: true},
+		},
+	}
+}
+
+func TestApplyReasoningEffort(t *testing.T) {
+	body := map[string]any{}
+	entry := &config.ProviderConfig{ModelID: "o3", ReasoningEffort: "high"}
+	if err := applyReasoning(body, reasoningDef(), entry); err != nil {
+		t.Fatal(err)
+	}
+	if body["reasoning_effort"] != "high" {
+		t.Errorf("body = %v", body)
+	}
+}
+
+func TestApplyReasoningRejectsUnsupportedModel(t *testing.T) {
+	entry := &config.ProviderConfig{ModelID: "gpt-4.1", ReasoningEffort: "low"}
+	if err := applyReasoning(map[string]any{}, reasoningDef(), entry); err == nil {
+		t.Error("unsupported model accepted reasoning effort")
+	}
+	entry = &config.ProviderConfig{ModelID: "o3", ReasoningEffort: "maximum"}
+	if err := applyReasoning(map[string]any{}, reasoningDef(), entry); err == nil {
+		t.Error("invalid effort value accepted")
+	}
+}
+
+func TestApplyThinkingBudget(t *testing.T) {
+	body := map[string]any{}
+	entry := &config.ProviderConfig{ModelID: "claude-sonnet-4-20250514", ThinkingBudgetTokens: 4096}
+	if err := applyReasoning(body, reasoningDef(), entry); err != nil {
+		t.Fatal(err)
+	}
+	thinking, ok := body["thinking"].(map[string]any)
+	if !ok || thinking["budget_tokens"] != 4096 {
+		t.Errorf("body = %v", body)
+	}
+
+	entry.ThinkingBudgetTokens = 100
+	if err := applyReasoning(map[string]any{}, reasoningDef(), entry); err == nil {
+		t.Error("budget below the provider minimum accepted")
+	}
+	entry = &config.ProviderConfig{ModelID: "gpt-4.1", ThinkingBudgetTokens: 4096}
+	if err := applyReasoning(map[string]any{}, reasoningDef(), entry); err == nil {
+		t.Error("unsupported model accepted thinking budget")
+	}
+}
+
+func TestApplyReasoningNoopWhenUnset(t *testing.T) {
+	body := map[string]any{}
+	if err := applyReasoning(body, reasoningDef(), &config.ProviderConfig{ModelID: "o3"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(body) != 0 {
+		t.Errorf("body = %v", body)
+	}
+}
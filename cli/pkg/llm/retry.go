@@ -0,0 +1,111 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// StatusError is a non-2xx provider response, kept typed so the retry layer
+// can distinguish transient failures from hard ones.
+type StatusError struct {
+	Provider string
+	Code     int
+	Status   string
+	Body     string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s: %s", e.Provider, e.Status, e.Body)
+}
+
+// Retryable reports whether err is worth retrying or falling back on:
+// rate limits (429), server errors (5xx) and transport failures qualify;
+// client errors like bad credentials do not.
+func Retryable(err error) bool {
+	if err == nil {
+		return false
+	}
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code == http.StatusTooManyRequests || se.Code >= 500
+	}
+	// No HTTP status at all means the request never completed.
+	return true
+}
+
+const (
+	retryBaseBackoff   = 250 * time.Millisecond
+	defaultMaxBackoff  = 8 * time.Second
+	defaultMaxAttempts = 1
+)
+
+// RetryPolicy is the resolved retry behavior for one provider.
+type RetryPolicy struct {
+	MaxAttempts int
+	MaxBackoff  time.Duration
+	// FallbackProvider is tried by the caller when all attempts fail
+	// retryably; the llm package itself only retries the same provider.
+	FallbackProvider string
+}
+
+// PolicyFor resolves the entry's retry config onto client defaults.
+func PolicyFor(entry *config.ProviderConfig) RetryPolicy {
+	policy := RetryPolicy{MaxAttempts: defaultMaxAttempts, MaxBackoff: defaultMaxBackoff}
+	if entry == nil || entry.Retry == nil {
+		return policy
+	}
+	if entry.Retry.MaxAttempts > 0 {
+		policy.MaxAttempts = entry.Retry.MaxAttempts
+	}
+	if entry.Retry.MaxBackoffMs > 0 {
+		policy.MaxBackoff = time.Duration(entry.Retry.MaxBackoffMs) * time.Millisecond
+	}
+	policy.FallbackProvider = entry.Retry.FallbackProvider
+	return policy
+}
+
+// Backoff returns the sleep before the given retry (1-based), doubling from
+// the base and capped by the policy.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	d := retryBaseBackoff
+	for i := 1; i < retry; i++ {
+		d *= 2
+		if d >= p.MaxBackoff {
+			return p.MaxBackoff
+		}
+	}
+	if d > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return d
+}
+
+// CompleteWithRetry runs Complete under the entry's retry policy, backing off
+// between attempts on retryable failures. Fallback to another provider is the
+// caller's job since it needs the registry and config.
+func CompleteWithRetry(ctx context.Context, def *generated.ProviderDefinition, entry *config.ProviderConfig, p Prompt) (string, Usage, error) {
+	policy := PolicyFor(entry)
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		reply, usage, err := Complete(ctx, def, entry, p)
+		if err == nil || !Retryable(err) {
+			return reply, usage, err
+		}
+		lastErr = err
+		if attempt >= policy.MaxAttempts {
+			break
+		}
+		select {
+		case <-time.After(policy.Backoff(attempt)):
+		case <-ctx.Done():
+			return "", Usage{}, ctx.Err()
+		}
+	}
+	return "", Usage{}, lastErr
+}
@@ -0,0 +1,100 @@
+package llm
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestRetryable(t *testing.T) {
+	cases := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{&StatusError{Code: http.StatusTooManyRequests}, true},
+		{&StatusError{Code: http.StatusBadGateway}, true},
+		{&StatusError{Code: http.StatusUnauthorized}, false},
+		{errors.New("connection refused"), true},
+	}
+	for _, c := range cases {
+		if got := Retryable(c.err); got != c.want {
+			t.Errorf("Retryable(%v) = %v, want %v", c.err, got, c.want)
+		}
+	}
+}
+
+func TestPolicyForDefaults(t *testing.T) {
+	policy := PolicyFor(&config.ProviderConfig{})
+	if policy.MaxAttempts != 1 || policy.FallbackProvider != "" {
+		t.Errorf("default policy = %+v", policy)
+	}
+	policy = PolicyFor(&config.ProviderConfig{Retry: &config.RetryConfig{
+		MaxAttempts: 4, MaxBackoffMs: 1000, FallbackProvider: "ollama",
+	}})
+	if policy.MaxAttempts != 4 || policy.MaxBackoff != time.Second || policy.FallbackProvider != "ollama" {
+		t.Errorf("configured policy = %+v", policy)
+	}
+}
+
+func TestBackoffDoublesAndCaps(t *testing.T) {
+	policy := RetryPolicy{MaxBackoff: 600 * time.Millisecond}
+	if d := policy.Backoff(1); d != 250*time.Millisecond {
+		t.Errorf("first backoff = %v", d)
+	}
+	if d := policy.Backoff(2); d != 500*time.Millisecond {
+		t.Errorf("second backoff = %v", d)
+	}
+	if d := policy.Backoff(10); d != 600*time.Millisecond {
+		t.Errorf("capped backoff = %v", d)
+	}
+}
+
+func TestCompleteWithRetryRecoversFromRateLimit(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"choices":[{"message":{"content":"pong"}}]}`))
+	}))
+	defer server.Close()
+
+	def := &generated.ProviderDefinition{ID: "test", BaseURL: server.URL}
+	entry := &config.ProviderConfig{Retry: &config.RetryConfig{MaxAttempts: 3, MaxBackoffMs: 1}}
+	reply, _, err := CompleteWithRetry(context.Background(), def, entry, Prompt{Text: "ping"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reply != "pong" || calls != 3 {
+		t.Errorf("reply = %q after %d calls", reply, calls)
+	}
+}
+
+func TestCompleteWithRetryFailsFastOnAuthError(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, "bad key", http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	def := &generated.ProviderDefinition{ID: "test", BaseURL: server.URL}
+	entry := &config.ProviderConfig{Retry: &config.RetryConfig{MaxAttempts: 5, MaxBackoffMs: 1}}
+	_, _, err := CompleteWithRetry(context.Background(), def, entry, Prompt{Text: "ping"})
+	var se *StatusError
+	if !errors.As(err, &se) || se.Code != http.StatusUnauthorized {
+		t.Fatalf("err = %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+}
@@ -0,0 +1,167 @@
+package llm
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// ToolSpec describes one callable tool to the provider, in the OpenAI
+// function-calling shape.
+type ToolSpec struct {
+	Name        string
+	Description string
+	// Parameters is the tool's argument schema; nil means no arguments.
+	Parameters json.RawMessage
+}
+
+// ToolCall is one tool invocation the model requested.
+type ToolCall struct {
+	ID        string
+	Name      string
+	Arguments string
+}
+
+// Message is one turn in a tool-calling conversation. Role is "user",
+// "assistant" or "tool"; assistant turns may carry ToolCalls and tool turns
+// carry the ToolCallID they answer.
+type Message struct {
+	Role       string
+	Content    string
+	ToolCalls  []ToolCall
+	ToolCallID string
+}
+
+// ChatWithTools sends a conversation with tool definitions and returns the
+// assistant's next turn, which either answers or requests tool calls.
+func ChatWithTools(ctx context.Context, def *generated.ProviderDefinition, entry *config.ProviderConfig, system string, history []Message, specs []ToolSpec) (Message, Usage, error) {
+	endpoint := entry.BaseURL
+	if endpoint == "" {
+		endpoint = def.BaseURL
+	}
+	if endpoint == "" {
+		return Message{}, Usage{}, fmt.Errorf("provider %s has no endpoint configured", def.ID)
+	}
+	var messages []map[string]any
+	if system != "" {
+		messages = append(messages, map[string]any{"role": "system", "content": system})
+	}
+	for _, m := range history {
+		messages = append(messages, encodeMessage(m))
+	}
+	var toolDefs []map[string]any
+	for _, spec := range specs {
+		params := spec.Parameters
+		if len(params) == 0 {
+			params = json.RawMessage(`{"type": "object", "properties": {}}`)
+		}
+		toolDefs = append(toolDefs, map[string]any{
+			"type": "function",
+			"function": map[string]any{
+				"name":        spec.Name,
+				"description": spec.Description,
+				"parameters":  params,
+			},
+		})
+	}
+	body := map[string]any{
+		"model":    entry.ModelID,
+		"messages": messages,
+		"tools":    toolDefs,
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return Message{}, Usage{}, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, entry.RequestTimeout(requestTimeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return Message{}, Usage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if entry.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+entry.APIKey)
+	}
+	entry.ApplyHeaders(req.Header)
+	resp, err := clientFor(entry).Do(req)
+	if err != nil {
+		return Message{}, Usage{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		errBody, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
+		return Message{}, Usage{}, &StatusError{Provider: def.ID, Code: resp.StatusCode, Status: resp.Status, Body: strings.TrimSpace(string(errBody))}
+	}
+	var decoded struct {
+		Choices []struct {
+			Message struct {
+				Content   string `json:"content"`
+				ToolCalls []struct {
+					ID       string `json:"id"`
+					Function struct {
+						Name      string `json:"name"`
+						Arguments string `json:"arguments"`
+					} `json:"function"`
+				} `json:"tool_calls"`
+			} `json:"message"`
+		} `json:"choices"`
+		Usage struct {
+			PromptTokens     int `json:"prompt_tokens"`
+			CompletionTokens int `json:"completion_tokens"`
+		} `json:"usage"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return Message{}, Usage{}, fmt.Errorf("decoding %s response: %w", def.ID, err)
+	}
+	if len(decoded.Choices) == 0 {
+		return Message{}, Usage{}, fmt.Errorf("%s returned no choices", def.ID)
+	}
+	reply := Message{Role: "assistant", Content: decoded.Choices[0].Message.Content}
+	for _, call := range decoded.Choices[0].Message.ToolCalls {
+		reply.ToolCalls = append(reply.ToolCalls, ToolCall{
+			ID:        call.ID,
+			Name:      call.Function.Name,
+			Arguments: call.Function.Arguments,
+		})
+	}
+	usage := Usage{
+		InputTokens:  decoded.Usage.PromptTokens,
+		OutputTokens: decoded.Usage.CompletionTokens,
+	}
+	if m := catalogModel(def, entry); m != nil {
+		usage.CostUSD = float64(usage.InputTokens)*m.InputPrice/1_000_000 +
+			float64(usage.OutputTokens)*m.OutputPrice/1_000_000
+	}
+	return reply, usage, nil
+}
+
+// encodeMessage renders one conversation turn in the wire shape.
+func encodeMessage(m Message) map[string]any {
+	encoded := map[string]any{"role": m.Role, "content": m.Content}
+	if m.ToolCallID != "" {
+		encoded["tool_call_id"] = m.ToolCallID
+	}
+	if len(m.ToolCalls) > 0 {
+		var calls []map[string]any
+		for _, call := range m.ToolCalls {
+			calls = append(calls, map[string]any{
+				"id":   call.ID,
+				"type": "function",
+				"function": map[string]any{
+					"name":      call.Name,
+					"arguments": call.Arguments,
+				},
+			})
+		}
+		encoded["tool_calls"] = calls
+	}
+	return encoded
+}
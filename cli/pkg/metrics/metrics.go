@@ -0,0 +1,172 @@
+// Package metrics implements a small Prometheus-compatible metrics registry
+// for the CLI's long-lived modes. It exposes counters and summaries in the
+// text exposition format; keeping it dependency-free costs little since the
+// CLI only needs Add/Observe and an HTTP handler.
+package metrics
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// series identifies one metric name plus rendered label pairs.
+type series struct {
+	name   string
+	labels string
+}
+
+// Registry accumulates metric samples.
+type Registry struct {
+	mu       sync.Mutex
+	counters map[series]float64
+	sums     map[series]float64
+	counts   map[series]uint64
+	help     map[string]string
+}
+
+// NewRegistry returns an empty metrics registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		counters: make(map[series]float64),
+		sums:     make(map[series]float64),
+		counts:   make(map[series]uint64),
+		help:     make(map[string]string),
+	}
+}
+
+// Default is the process-wide registry the request layer records into.
+var Default = NewRegistry()
+
+// Help registers the HELP text emitted for a metric name.
+func (r *Registry) Help(name, text string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.help[name] = text
+}
+
+// Add increments a counter.
+func (r *Registry) Add(name string, labels map[string]string, v float64) {
+	s := series{name, renderLabels(labels)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counters[s] += v
+}
+
+// Observe records one sample of a summary metric (exposed as _sum/_count).
+func (r *Registry) Observe(name string, labels map[string]string, v float64) {
+	s := series{name, renderLabels(labels)}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sums[s] += v
+	r.counts[s]++
+}
+
+// Handler serves the registry in Prometheus text exposition format.
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		fmt.Fprint(w, r.render())
+	})
+}
+
+func (r *Registry) render() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var b strings.Builder
+	// The format allows only one HELP/TYPE line per metric name, so emit
+	// them when a name is first seen; the sort keeps same-name series
+	// adjacent after their header.
+	emitted := make(map[string]bool)
+	emitHeader := func(name, typ string) {
+		if emitted[name] {
+			return
+		}
+		emitted[name] = true
+		if text := r.help[name]; text != "" {
+			fmt.Fprintf(&b, "# HELP %s %s\n", name, text)
+		}
+		fmt.Fprintf(&b, "# TYPE %s %s\n", name, typ)
+	}
+	for _, s := range sortedSeries(r.counters) {
+		emitHeader(s.name, "counter")
+		fmt.Fprintf(&b, "%s%s %g\n", s.name, s.labels, r.counters[s])
+	}
+	for _, s := range sortedSeries(r.sums) {
+		emitHeader(s.name, "summary")
+		fmt.Fprintf(&b, "%s_sum%s %g\n", s.name, s.labels, r.sums[s])
+		fmt.Fprintf(&b, "%s_count%s %d\n", s.name, s.labels, r.counts[s])
+	}
+	return b.String()
+}
+
+func sortedSeries[V any](m map[series]V) []series {
+	keys := make([]series, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].name != keys[j].name {
+			return keys[i].name < keys[j].name
+		}
+		return keys[i].labels < keys[j].labels
+	})
+	return keys
+}
+
+func renderLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		fmt.Fprintf(&b, "%s=%q", k, labels[k])
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+// RecordProviderRequest records one provider API call into Default with the
+// labels Grafana dashboards expect.
+func RecordProviderRequest(provider string, seconds float64, inputTokens, outputTokens int, costUSD float64, err bool) {
+	labels := map[string]string{"provider": provider}
+	Default.Add("cline_requests_total", labels, 1)
+	if err {
+		Default.Add("cline_request_errors_total", labels, 1)
+	}
+	Default.Observe("cline_request_duration_seconds", labels, seconds)
+	Default.Add("cline_tokens_total", map[string]string{"provider": provider, "direction": "input"}, float64(inputTokens))
+	Default.Add("cline_tokens_total", map[string]string{"provider": provider, "direction": "output"}, float64(outputTokens))
+	Default.Add("cline_cost_usd_total", labels, costUSD)
+}
+
+// RecordCacheUsage records prompt cache activity for one request; a read is a
+// cache hit, a write is a new cache entry.
+func RecordCacheUsage(provider string, readTokens, writeTokens int) {
+	if readTokens > 0 {
+		Default.Add("cline_cache_tokens_total", map[string]string{"provider": provider, "kind": "read"}, float64(readTokens))
+	}
+	if writeTokens > 0 {
+		Default.Add("cline_cache_tokens_total", map[string]string{"provider": provider, "kind": "write"}, float64(writeTokens))
+	}
+}
+
+func init() {
+	Default.Help("cline_requests_total", "Provider API requests made by the CLI.")
+	Default.Help("cline_request_errors_total", "Provider API requests that failed.")
+	Default.Help("cline_request_duration_seconds", "Provider API request latency.")
+	Default.Help("cline_tokens_total", "Tokens sent to and received from providers.")
+	Default.Help("cline_cost_usd_total", "Estimated provider spend in USD.")
+	Default.Help("cline_cache_tokens_total", "Prompt cache tokens read (hits) and written per provider.")
+}
@@ -0,0 +1,44 @@
+package metrics
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestRegistryRendersPrometheusText(t *testing.T) {
+	r := NewRegistry()
+	r.Help("cline_requests_total", "Provider API requests made by the CLI.")
+	r.Add("cline_requests_total", map[string]string{"provider": "openai"}, 1)
+	r.Add("cline_requests_total", map[string]string{"provider": "openai"}, 1)
+	r.Observe("cline_request_duration_seconds", map[string]string{"provider": "openai"}, 0.25)
+	r.Observe("cline_request_duration_seconds", map[string]string{"provider": "openai"}, 0.75)
+	r.Add("cline_tokens_total", map[string]string{"provider": "openai", "direction": "input"}, 10)
+	r.Add("cline_tokens_total", map[string]string{"provider": "openai", "direction": "output"}, 5)
+
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	rec := httptest.NewRecorder()
+	r.Handler().ServeHTTP(rec, req)
+	body := rec.Body.String()
+	for _, want := range []string{
+		"# HELP cline_requests_total Provider API requests made by the CLI.",
+		"# TYPE cline_requests_total counter",
+		`cline_requests_total{provider="openai"} 2`,
+		`cline_request_duration_seconds_sum{provider="openai"} 1`,
+		`cline_request_duration_seconds_count{provider="openai"} 2`,
+		`cline_tokens_total{direction="input",provider="openai"} 10`,
+		`cline_tokens_total{direction="output",provider="openai"} 5`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("exposition missing %q:\n%s", want, body)
+		}
+	}
+	// Exactly one TYPE line per metric name, no matter how many label sets
+	// it has; duplicates make expfmt consumers reject the whole scrape.
+	if n := strings.Count(body, "# TYPE cline_tokens_total counter"); n != 1 {
+		t.Errorf("cline_tokens_total TYPE lines = %d, want 1:\n%s", n, body)
+	}
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain") {
+		t.Errorf("content type = %q", ct)
+	}
+}
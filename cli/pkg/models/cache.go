@@ -0,0 +1,58 @@
+package models
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// sessionCache memoizes model lists for the lifetime of the process, so
+// re-listing models inside one wizard run (e.g. after a mis-selection) is
+// instant. Keyed by provider, base URL, and a hash of the API key so a key or
+// endpoint change invalidates the entry.
+var sessionCache = struct {
+	sync.Mutex
+	entries map[string][]generated.ModelInfo
+}{entries: make(map[string][]generated.ModelInfo)}
+
+func cacheKey(providerID string, entry *config.ProviderConfig, opts generated.ListOptions) string {
+	keyHash := ""
+	if entry.APIKey != "" {
+		sum := sha256.Sum256([]byte(entry.APIKey))
+		keyHash = hex.EncodeToString(sum[:8])
+	}
+	return fmt.Sprintf("%s\x00%s\x00%s\x00%d\x00%d", providerID, entry.BaseURL, keyHash, opts.Limit, opts.Offset)
+}
+
+// FetchCached returns the model list for a dynamic-model provider, fetching
+// at most once per provider+endpoint+key within this process.
+func FetchCached(ctx context.Context, providerID string, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	key := cacheKey(providerID, entry, opts)
+	sessionCache.Lock()
+	cached, ok := sessionCache.entries[key]
+	sessionCache.Unlock()
+	if ok {
+		return cached, nil
+	}
+	fetched, err := Fetch(ctx, providerID, entry, opts)
+	if err != nil {
+		return nil, err
+	}
+	sessionCache.Lock()
+	sessionCache.entries[key] = fetched
+	sessionCache.Unlock()
+	return fetched, nil
+}
+
+// InvalidateSessionCache drops all memoized model lists; used after a refresh
+// is explicitly requested.
+func InvalidateSessionCache() {
+	sessionCache.Lock()
+	defer sessionCache.Unlock()
+	sessionCache.entries = make(map[string][]generated.ModelInfo)
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestFetchCachedMemoizesPerKey(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprint(w, `{"models":[{"name":"llama3.3:70b"}]}`)
+	}))
+	defer srv.Close()
+	t.Cleanup(InvalidateSessionCache)
+
+	entry := &config.ProviderConfig{Provider: "ollama", BaseURL: srv.URL}
+	for i := 0; i < 3; i++ {
+		got, err := FetchCached(context.Background(), "ollama", entry, generated.ListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(got) != 1 || got[0].ID != "llama3.3:70b" {
+			t.Fatalf("unexpected models: %+v", got)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("server hit %d times, want 1", calls)
+	}
+
+	// A different key hash must miss the cache.
+	entry2 := &config.ProviderConfig{Provider: "ollama", BaseURL: srv.URL, APIKey: "other"}
+	if _, err := FetchCached(context.Background(), "ollama", entry2, generated.ListOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Errorf("server hit %d times after key change, want 2", calls)
+	}
+}
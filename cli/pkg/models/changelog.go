@@ -0,0 +1,187 @@
+package models
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// ChangeEntry is one observed catalog change: a model's price or context
+// window moving between two refreshes. The log exists so silent price hikes
+// become visible after the fact, not just at the moment of a refresh.
+type ChangeEntry struct {
+	At       time.Time `json:"at"`
+	Provider string    `json:"provider"`
+	ModelID  string    `json:"modelId"`
+	// Field is "inputPrice", "outputPrice" or "contextWindow".
+	Field string  `json:"field"`
+	Old   float64 `json:"old"`
+	New   float64 `json:"new"`
+}
+
+// String renders the change the way `cline models changelog` lists it.
+func (e ChangeEntry) String() string {
+	if e.Field == "contextWindow" {
+		return fmt.Sprintf("%s %s/%s %s %d -> %d",
+			e.At.Local().Format("2006-01-02 15:04"), e.Provider, e.ModelID, e.Field, int(e.Old), int(e.New))
+	}
+	s := fmt.Sprintf("%s %s/%s %s $%.2f -> $%.2f",
+		e.At.Local().Format("2006-01-02 15:04"), e.Provider, e.ModelID, e.Field, e.Old, e.New)
+	if e.Old > 0 {
+		s += fmt.Sprintf(" (%+.0f%%)", (e.New-e.Old)/e.Old*100)
+	}
+	return s
+}
+
+func changelogPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "models-changelog.jsonl"), nil
+}
+
+// catalogSnapshot is the per-model state the comparison runs against.
+type catalogSnapshot struct {
+	InputPrice    float64   `json:"inputPrice"`
+	OutputPrice   float64   `json:"outputPrice"`
+	ContextWindow int       `json:"contextWindow"`
+	SeenAt        time.Time `json:"seenAt"`
+}
+
+func snapshotsPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "catalog-snapshots.json"), nil
+}
+
+func loadSnapshots() map[string]catalogSnapshot {
+	path, err := snapshotsPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var snaps map[string]catalogSnapshot
+	if err := json.Unmarshal(data, &snaps); err != nil {
+		return nil
+	}
+	return snaps
+}
+
+func saveSnapshots(snaps map[string]catalogSnapshot) {
+	path, err := snapshotsPath()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(snaps, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0o700)
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// RecordCatalogChanges diffs a freshly fetched catalog against the last
+// snapshot, appends any pricing or context-window changes to the changelog,
+// and stores the new snapshot. Models seen for the first time produce no
+// entries. It returns the changes for callers that want to surface them
+// immediately.
+func RecordCatalogChanges(providerID string, list []generated.ModelInfo) []ChangeEntry {
+	snaps := loadSnapshots()
+	if snaps == nil {
+		snaps = map[string]catalogSnapshot{}
+	}
+	now := time.Now().UTC()
+	var changes []ChangeEntry
+	for _, m := range list {
+		key := providerID + "/" + m.ID
+		previous, seen := snaps[key]
+		snaps[key] = catalogSnapshot{
+			InputPrice:    m.InputPrice,
+			OutputPrice:   m.OutputPrice,
+			ContextWindow: m.ContextWindow,
+			SeenAt:        now,
+		}
+		if !seen {
+			continue
+		}
+		record := func(field string, old, current float64) {
+			if old != current {
+				changes = append(changes, ChangeEntry{
+					At: now, Provider: providerID, ModelID: m.ID,
+					Field: field, Old: old, New: current,
+				})
+			}
+		}
+		record("inputPrice", previous.InputPrice, m.InputPrice)
+		record("outputPrice", previous.OutputPrice, m.OutputPrice)
+		record("contextWindow", float64(previous.ContextWindow), float64(m.ContextWindow))
+	}
+	saveSnapshots(snaps)
+	appendChanges(changes)
+	return changes
+}
+
+// appendChanges is best effort, like the other state files.
+func appendChanges(changes []ChangeEntry) {
+	if len(changes) == 0 {
+		return
+	}
+	path, err := changelogPath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	for _, c := range changes {
+		data, err := json.Marshal(c)
+		if err != nil {
+			continue
+		}
+		f.Write(append(data, '\n'))
+	}
+}
+
+// LoadChangelog returns all recorded changes, oldest first. A missing file
+// is an empty log; unparseable lines are skipped.
+func LoadChangelog() ([]ChangeEntry, error) {
+	path, err := changelogPath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []ChangeEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e ChangeEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			continue
+		}
+		entries = append(entries, e)
+	}
+	return entries, scanner.Err()
+}
@@ -0,0 +1,214 @@
+package models
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// The catalog cache keeps the raw bytes of large model catalogs together with
+// their HTTP validators, so a refresh sends a conditional request and
+// transfers nothing when the catalog has not changed — OpenRouter's runs to
+// several MB, which matters on metered connections.
+
+// cachedCatalog is one cached catalog response on disk.
+type cachedCatalog struct {
+	URL          string `json:"url"`
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+func catalogDir() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "catalogs"), nil
+}
+
+func catalogPath(rawURL string) (string, error) {
+	dir, err := catalogDir()
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(rawURL))
+	return filepath.Join(dir, hex.EncodeToString(sum[:12])+".json"), nil
+}
+
+// loadCatalog reads the cached response for a URL; nil when absent.
+func loadCatalog(rawURL string) *cachedCatalog {
+	path, err := catalogPath(rawURL)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var c cachedCatalog
+	if json.Unmarshal(data, &c) != nil || c.URL != rawURL {
+		return nil
+	}
+	return &c
+}
+
+// storeCatalog writes a response body and its validators; best-effort, a
+// failed write just means the next refresh downloads again.
+func storeCatalog(rawURL, etag, lastModified string, body []byte) {
+	path, err := catalogPath(rawURL)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	data, err := json.Marshal(cachedCatalog{URL: rawURL, ETag: etag, LastModified: lastModified, Body: body})
+	if err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0o600)
+}
+
+// addConditional attaches If-None-Match / If-Modified-Since from the cache.
+func addConditional(req *http.Request, cached *cachedCatalog) {
+	if cached == nil {
+		return
+	}
+	if cached.ETag != "" {
+		req.Header.Set("If-None-Match", cached.ETag)
+	}
+	if cached.LastModified != "" {
+		req.Header.Set("If-Modified-Since", cached.LastModified)
+	}
+}
+
+// cacheableBody wraps a 200 response whose server sent validators: the body
+// is buffered and stored on close so the next request can be conditional.
+func cacheableBody(rawURL string, resp *http.Response) io.ReadCloser {
+	etag := resp.Header.Get("Etag")
+	lastModified := resp.Header.Get("Last-Modified")
+	if etag == "" && lastModified == "" {
+		return resp.Body
+	}
+	return &teeCloser{src: resp.Body, store: func(body []byte) {
+		storeCatalog(rawURL, etag, lastModified, body)
+	}}
+}
+
+type teeCloser struct {
+	src   io.ReadCloser
+	buf   bytes.Buffer
+	store func([]byte)
+	// failed marks a read error; a partial body must not be cached.
+	failed bool
+}
+
+func (t *teeCloser) Read(p []byte) (int, error) {
+	n, err := t.src.Read(p)
+	t.buf.Write(p[:n])
+	if err != nil && err != io.EOF {
+		t.failed = true
+	}
+	return n, err
+}
+
+func (t *teeCloser) Close() error {
+	// Drain whatever the decoder did not consume so the cached body is the
+	// complete response, then store it.
+	if _, err := io.Copy(&t.buf, t.src); err != nil {
+		t.failed = true
+	}
+	if !t.failed {
+		t.store(t.buf.Bytes())
+	}
+	return t.src.Close()
+}
+
+// CatalogDiff is what changed between two snapshots of a provider's catalog.
+type CatalogDiff struct {
+	Added   []string
+	Removed []string
+}
+
+// Empty reports whether nothing changed.
+func (d CatalogDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0
+}
+
+// DiffIDs compares model ID lists regardless of order.
+func DiffIDs(old, new []string) CatalogDiff {
+	oldSet := make(map[string]bool, len(old))
+	for _, id := range old {
+		oldSet[id] = true
+	}
+	newSet := make(map[string]bool, len(new))
+	for _, id := range new {
+		newSet[id] = true
+	}
+	var diff CatalogDiff
+	for id := range newSet {
+		if !oldSet[id] {
+			diff.Added = append(diff.Added, id)
+		}
+	}
+	for id := range oldSet {
+		if !newSet[id] {
+			diff.Removed = append(diff.Removed, id)
+		}
+	}
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	return diff
+}
+
+// snapshotPath is where a provider's last-seen model ID list lives.
+func snapshotPath(providerID string) (string, error) {
+	dir, err := catalogDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, providerID+".ids.json"), nil
+}
+
+// LoadCatalogIDs returns the model IDs seen on the previous refresh; nil when
+// there is no snapshot yet.
+func LoadCatalogIDs(providerID string) []string {
+	path, err := snapshotPath(providerID)
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var ids []string
+	if json.Unmarshal(data, &ids) != nil {
+		return nil
+	}
+	return ids
+}
+
+// SaveCatalogIDs records the current model ID list for the next diff.
+func SaveCatalogIDs(providerID string, ids []string) {
+	path, err := snapshotPath(providerID)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return
+	}
+	os.WriteFile(path, data, 0o600)
+}
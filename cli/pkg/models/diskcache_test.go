@@ -0,0 +1,65 @@
+package models
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestConditionalFetchServes304FromCache(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Etag", `"v1"`)
+		w.Write([]byte(`{"data": [{"id": "model-a"}, {"id": "model-b"}]}`))
+	}))
+	defer srv.Close()
+
+	entry := &config.ProviderConfig{BaseURL: srv.URL}
+	fetch := fetchOpenAICompatibleModels(srv.URL)
+	first, err := fetch(context.Background(), entry, generated.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := fetch(context.Background(), entry, generated.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(first) != 2 || len(second) != 2 {
+		t.Errorf("models = %d then %d", len(first), len(second))
+	}
+	if requests != 2 {
+		t.Errorf("requests = %d", requests)
+	}
+}
+
+func TestDiffIDs(t *testing.T) {
+	diff := DiffIDs([]string{"a", "b", "c"}, []string{"b", "c", "d"})
+	if len(diff.Added) != 1 || diff.Added[0] != "d" || len(diff.Removed) != 1 || diff.Removed[0] != "a" {
+		t.Errorf("diff = %+v", diff)
+	}
+	if !DiffIDs([]string{"x"}, []string{"x"}).Empty() {
+		t.Error("identical lists should diff empty")
+	}
+}
+
+func TestCatalogIDSnapshotRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	if got := LoadCatalogIDs("openrouter"); got != nil {
+		t.Errorf("fresh snapshot = %v", got)
+	}
+	SaveCatalogIDs("openrouter", []string{"m1", "m2"})
+	got := LoadCatalogIDs("openrouter")
+	if len(got) != 2 || got[0] != "m1" {
+		t.Errorf("loaded = %v", got)
+	}
+}
@@ -0,0 +1,286 @@
+// Package models fetches live model lists for dynamic-model providers
+// (OpenRouter, Ollama, LM Studio) and registers the fetchers with the
+// generated registry.
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+const fetchTimeout = 30 * time.Second
+
+// httpClient carries all catalog traffic so a transcript recorder or replayer
+// can be installed in front of it.
+var httpClient = &http.Client{}
+
+// SetHTTPTransport swaps the transport used for catalog fetches; nil restores
+// the default.
+func SetHTTPTransport(rt http.RoundTripper) {
+	httpClient.Transport = rt
+}
+
+// clientFor returns the shared client unless the entry tunes the dialer; a
+// test-installed transport always wins.
+func clientFor(entry *config.ProviderConfig) *http.Client {
+	if httpClient.Transport == nil {
+		if t := entry.DialTransport(); t != nil {
+			return &http.Client{Transport: t}
+		}
+	}
+	return httpClient
+}
+
+func init() {
+	generated.RegisterModelFetcher("openrouter", fetchOpenRouterModels)
+	generated.RegisterModelFetcher("ollama", fetchOllamaModels)
+	generated.RegisterModelFetcher("lmstudio", fetchOpenAICompatibleModels("http://localhost:1234/v1"))
+}
+
+// Fetch returns the live model list for a dynamic-model provider using its
+// registered fetcher.
+func Fetch(ctx context.Context, providerID string, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	fetcher := generated.ModelFetcherFor(providerID)
+	if fetcher == nil {
+		return nil, fmt.Errorf("provider %q has no model fetcher", providerID)
+	}
+	list, err := fetcher(ctx, entry, opts)
+	if err != nil {
+		return nil, err
+	}
+	ApplyOverrides(entry, list)
+	return list, nil
+}
+
+// get issues an authenticated GET and returns the response body for the
+// caller to stream; the caller must close it. The entry's HTTP settings
+// (request timeout, connect timeout, keepalive) are honored when set.
+func get(ctx context.Context, rawURL, apiKey string, entry *config.ProviderConfig) (io.ReadCloser, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(ctx, entry.RequestTimeout(fetchTimeout))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	if apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+apiKey)
+	}
+	entry.ApplyHeaders(req.Header)
+	cached := loadCatalog(rawURL)
+	addConditional(req, cached)
+	resp, err := clientFor(entry).Do(req)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		resp.Body.Close()
+		return io.NopCloser(bytes.NewReader(cached.Body)), cancel, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	return cacheableBody(rawURL, resp), cancel, nil
+}
+
+// streamArrayField walks a JSON object to the named top-level array and calls
+// decodeElem once per element, never buffering the whole document. Catalogs
+// like OpenRouter's run to several MB, so this keeps setup usable in
+// low-memory containers.
+func streamArrayField(r io.Reader, field string, decodeElem func(dec *json.Decoder) error) error {
+	dec := json.NewDecoder(r)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return fmt.Errorf("expected JSON object, got %v (%v)", tok, err)
+	}
+	for dec.More() {
+		keyTok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, _ := keyTok.(string)
+		if key != field {
+			var skip json.RawMessage
+			if err := dec.Decode(&skip); err != nil {
+				return err
+			}
+			continue
+		}
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+			return fmt.Errorf("field %q is not an array (%v)", field, err)
+		}
+		for dec.More() {
+			if err := decodeElem(dec); err != nil {
+				return err
+			}
+		}
+		if _, err := dec.Token(); err != nil { // closing ]
+			return err
+		}
+	}
+	return nil
+}
+
+// errStopStreaming signals that the pagination limit was reached and the rest
+// of the response can be discarded.
+var errStopStreaming = fmt.Errorf("stop streaming")
+
+// paginate wraps an element callback with offset/limit accounting.
+func paginate(opts generated.ListOptions, emit func()) func() error {
+	skipped, emitted := 0, 0
+	return func() error {
+		if skipped < opts.Offset {
+			skipped++
+			return nil
+		}
+		if opts.Limit > 0 && emitted >= opts.Limit {
+			return errStopStreaming
+		}
+		emit()
+		emitted++
+		return nil
+	}
+}
+
+func fetchOpenRouterModels(ctx context.Context, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	base := entry.BaseURL
+	if base == "" {
+		base = "https://openrouter.ai/api/v1"
+	}
+	endpoint := strings.TrimSuffix(base, "/") + "/models"
+	// Pass the limit server-side where the gateway supports it; the streaming
+	// decoder below enforces it regardless.
+	if opts.Limit > 0 {
+		endpoint += "?" + url.Values{"limit": {strconv.Itoa(opts.Limit + opts.Offset)}}.Encode()
+	}
+	body, cancel, err := get(ctx, endpoint, entry.APIKey, entry)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer body.Close()
+
+	var models []generated.ModelInfo
+	var elem openRouterModel
+	next := paginate(opts, func() {
+		models = append(models, generated.ModelInfo{
+			ID:            elem.ID,
+			Name:          elem.Name,
+			ContextWindow: elem.ContextLength,
+			InputPrice:    perTokenToPerMillion(elem.Pricing.Prompt),
+			OutputPrice:   perTokenToPerMillion(elem.Pricing.Completion),
+		})
+	})
+	err = streamArrayField(body, "data", func(dec *json.Decoder) error {
+		elem = openRouterModel{}
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		return next()
+	})
+	if err != nil && err != errStopStreaming {
+		return nil, err
+	}
+	return models, nil
+}
+
+type openRouterModel struct {
+	ID            string `json:"id"`
+	Name          string `json:"name"`
+	ContextLength int    `json:"context_length"`
+	Pricing       struct {
+		Prompt     string `json:"prompt"`
+		Completion string `json:"completion"`
+	} `json:"pricing"`
+}
+
+// perTokenToPerMillion converts OpenRouter's per-token string price to the
+// per-million convention used by ModelInfo.
+func perTokenToPerMillion(s string) float64 {
+	var v float64
+	if _, err := fmt.Sscanf(s, "%g", &v); err != nil {
+		return 0
+	}
+	return v * 1_000_000
+}
+
+func fetchOllamaModels(ctx context.Context, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	base := entry.BaseURL
+	if base == "" {
+		base = "http://localhost:11434"
+	}
+	body, cancel, err := get(ctx, strings.TrimSuffix(base, "/")+"/api/tags", "", entry)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer body.Close()
+
+	var models []generated.ModelInfo
+	var elem struct {
+		Name string `json:"name"`
+	}
+	next := paginate(opts, func() {
+		models = append(models, generated.ModelInfo{ID: elem.Name, ContextWindow: 8192})
+	})
+	err = streamArrayField(body, "models", func(dec *json.Decoder) error {
+		elem.Name = ""
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		return next()
+	})
+	if err != nil && err != errStopStreaming {
+		return nil, err
+	}
+	return models, nil
+}
+
+// fetchOpenAICompatibleModels lists models from a /v1/models endpoint, used
+// for LM Studio and other OpenAI-compatible local servers.
+func fetchOpenAICompatibleModels(defaultBase string) generated.ModelFetcher {
+	return func(ctx context.Context, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+		base := entry.BaseURL
+		if base == "" {
+			base = defaultBase
+		}
+		body, cancel, err := get(ctx, strings.TrimSuffix(base, "/")+"/models", entry.APIKey, entry)
+		if err != nil {
+			return nil, err
+		}
+		defer cancel()
+		defer body.Close()
+
+		var models []generated.ModelInfo
+		var elem struct {
+			ID string `json:"id"`
+		}
+		next := paginate(opts, func() {
+			models = append(models, generated.ModelInfo{ID: elem.ID, ContextWindow: 8192})
+		})
+		err = streamArrayField(body, "data", func(dec *json.Decoder) error {
+			elem.ID = ""
+			if err := dec.Decode(&elem); err != nil {
+				return err
+			}
+			return next()
+		})
+		if err != nil && err != errStopStreaming {
+			return nil, err
+		}
+		applyProbes(base, models)
+		return models, nil
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func openRouterCatalog(n int) string {
+	var b strings.Builder
+	b.WriteString(`{"meta":{"ignored":true},"data":[`)
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":"vendor/model-%d","name":"Model %d","context_length":128000,"pricing":{"prompt":"0.000001","completion":"0.000002"}}`, i, i)
+	}
+	b.WriteString(`]}`)
+	return b.String()
+}
+
+func TestFetchOpenRouterStreamsAndPaginates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, openRouterCatalog(50))
+	}))
+	defer srv.Close()
+	entry := &config.ProviderConfig{Provider: "openrouter", BaseURL: srv.URL}
+
+	all, err := Fetch(context.Background(), "openrouter", entry, generated.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 50 {
+		t.Fatalf("got %d models, want 50", len(all))
+	}
+	if all[0].InputPrice != 1.0 || all[0].OutputPrice != 2.0 {
+		t.Errorf("pricing conversion wrong: %+v", all[0])
+	}
+
+	page, err := Fetch(context.Background(), "openrouter", entry, generated.ListOptions{Limit: 10, Offset: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(page) != 10 {
+		t.Fatalf("got %d models, want 10", len(page))
+	}
+	if page[0].ID != "vendor/model-20" {
+		t.Errorf("offset not applied: first model %s", page[0].ID)
+	}
+}
@@ -0,0 +1,95 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func init() {
+	generated.RegisterModelFetcher("github", fetchGitHubModels)
+}
+
+// githubModel matches one element of the GitHub Models catalog endpoint.
+type githubModel struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Limits struct {
+		MaxInputTokens  int `json:"max_input_tokens"`
+		MaxOutputTokens int `json:"max_output_tokens"`
+	} `json:"limits"`
+}
+
+// fetchGitHubModels lists the GitHub Models catalog. Users already entitled
+// through GitHub need no new key: when no token is configured we fall back to
+// the gh CLI's stored login via `gh auth token`.
+func fetchGitHubModels(ctx context.Context, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	token, err := githubToken(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	base := entry.BaseURL
+	if base == "" {
+		base = "https://models.github.ai"
+	}
+	body, cancel, err := get(ctx, strings.TrimSuffix(base, "/")+"/catalog/models", token, entry)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer body.Close()
+
+	// The catalog is a bare JSON array, unlike the enveloped responses the
+	// other fetchers stream.
+	dec := json.NewDecoder(body)
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('[') {
+		return nil, fmt.Errorf("expected JSON array, got %v (%v)", tok, err)
+	}
+	var models []generated.ModelInfo
+	var elem githubModel
+	next := paginate(opts, func() {
+		models = append(models, generated.ModelInfo{
+			ID:            elem.ID,
+			Name:          elem.Name,
+			ContextWindow: elem.Limits.MaxInputTokens,
+			MaxTokens:     elem.Limits.MaxOutputTokens,
+		})
+	})
+	for dec.More() {
+		elem = githubModel{}
+		if err := dec.Decode(&elem); err != nil {
+			return nil, err
+		}
+		if err := next(); err != nil {
+			if err == errStopStreaming {
+				break
+			}
+			return nil, err
+		}
+	}
+	return models, nil
+}
+
+// githubToken resolves the token to use: the configured key, then the
+// GITHUB_TOKEN environment, then whatever the gh CLI is logged in with.
+func githubToken(ctx context.Context, entry *config.ProviderConfig) (string, error) {
+	if entry.APIKey != "" {
+		return entry.APIKey, nil
+	}
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token, nil
+	}
+	out, err := exec.CommandContext(ctx, "gh", "auth", "token").Output()
+	if err == nil {
+		if token := strings.TrimSpace(string(out)); token != "" {
+			return token, nil
+		}
+	}
+	return "", fmt.Errorf("no GitHub token available; set one with `cline auth add --provider github` or log in with `gh auth login`")
+}
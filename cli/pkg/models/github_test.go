@@ -0,0 +1,67 @@
+package models
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestFetchGitHubModels(t *testing.T) {
+	var gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/catalog/models" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		w.Write([]byte(`[
+			{"id":"openai/gpt-4.1","name":"OpenAI GPT-4.1","limits":{"max_input_tokens":1047576,"max_output_tokens":32768}},
+			{"id":"meta/llama-3.3-70b","name":"Llama 3.3 70B","limits":{"max_input_tokens":128000,"max_output_tokens":4096}}
+		]`))
+	}))
+	defer srv.Close()
+
+	entry := &config.ProviderConfig{Provider: "github", BaseURL: srv.URL, APIKey: "gho_token"}
+	models, err := fetchGitHubModels(context.Background(), entry, generated.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotAuth != "Bearer gho_token" {
+		t.Errorf("auth = %q", gotAuth)
+	}
+	if len(models) != 2 || models[0].ID != "openai/gpt-4.1" || models[0].ContextWindow != 1047576 {
+		t.Errorf("models = %+v", models)
+	}
+}
+
+func TestFetchGitHubModelsPaginates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[{"id":"a"},{"id":"b"},{"id":"c"}]`))
+	}))
+	defer srv.Close()
+
+	entry := &config.ProviderConfig{Provider: "github", BaseURL: srv.URL, APIKey: "gho_token"}
+	models, err := fetchGitHubModels(context.Background(), entry, generated.ListOptions{Limit: 1, Offset: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 1 || models[0].ID != "b" {
+		t.Errorf("models = %+v", models)
+	}
+}
+
+func TestGitHubTokenPrefersConfiguredKey(t *testing.T) {
+	t.Setenv("GITHUB_TOKEN", "env_token")
+	token, err := githubToken(context.Background(), &config.ProviderConfig{APIKey: "cfg_token"})
+	if err != nil || token != "cfg_token" {
+		t.Errorf("token = %q, %v", token, err)
+	}
+	token, err = githubToken(context.Background(), &config.ProviderConfig{})
+	if err != nil || token != "env_token" {
+		t.Errorf("token = %q, %v", token, err)
+	}
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func init() {
+	generated.RegisterModelFetcher("litellm", fetchLiteLLMModels)
+}
+
+// liteLLMModel matches one element of /model/info's data array. The gateway
+// reports the underlying provider and per-token pricing for each routed model.
+type liteLLMModel struct {
+	ModelName string `json:"model_name"`
+	ModelInfo struct {
+		Provider           string  `json:"litellm_provider"`
+		MaxInputTokens     int     `json:"max_input_tokens"`
+		MaxOutputTokens    int     `json:"max_output_tokens"`
+		InputCostPerToken  float64 `json:"input_cost_per_token"`
+		OutputCostPerToken float64 `json:"output_cost_per_token"`
+		SupportsVision     bool    `json:"supports_vision"`
+	} `json:"model_info"`
+}
+
+// fetchLiteLLMModels lists the models a LiteLLM gateway routes via its
+// /model/info endpoint. Virtual keys go in the Authorization header; a
+// configured team ID is forwarded so team-scoped keys see their own model
+// list and budgets.
+func fetchLiteLLMModels(ctx context.Context, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	if entry.BaseURL == "" {
+		return nil, fmt.Errorf("litellm requires a gateway URL; run `cline auth add --provider litellm`")
+	}
+	body, cancel, err := liteLLMGet(ctx, strings.TrimSuffix(entry.BaseURL, "/")+"/model/info", entry)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer body.Close()
+
+	var models []generated.ModelInfo
+	var elem liteLLMModel
+	next := paginate(opts, func() {
+		name := elem.ModelName
+		if elem.ModelInfo.Provider != "" {
+			name = fmt.Sprintf("%s (via %s)", elem.ModelName, elem.ModelInfo.Provider)
+		}
+		models = append(models, generated.ModelInfo{
+			ID:             elem.ModelName,
+			Name:           name,
+			ContextWindow:  elem.ModelInfo.MaxInputTokens,
+			MaxTokens:      elem.ModelInfo.MaxOutputTokens,
+			InputPrice:     elem.ModelInfo.InputCostPerToken * 1_000_000,
+			OutputPrice:    elem.ModelInfo.OutputCostPerToken * 1_000_000,
+			SupportsImages: elem.ModelInfo.SupportsVision,
+		})
+	})
+	err = streamArrayField(body, "data", func(dec *json.Decoder) error {
+		elem = liteLLMModel{}
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		return next()
+	})
+	if err != nil && err != errStopStreaming {
+		return nil, err
+	}
+	return models, nil
+}
+
+// liteLLMGet mirrors get but adds the gateway's team header when configured.
+func liteLLMGet(ctx context.Context, rawURL string, entry *config.ProviderConfig) (io.ReadCloser, context.CancelFunc, error) {
+	ctx, cancel := context.WithTimeout(ctx, entry.RequestTimeout(fetchTimeout))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	if entry.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+entry.APIKey)
+	}
+	if teamID := entry.Field("teamId"); teamID != "" {
+		req.Header.Set("x-litellm-team-id", teamID)
+	}
+	entry.ApplyHeaders(req.Header)
+	resp, err := clientFor(entry).Do(req)
+	if err != nil {
+		cancel()
+		return nil, nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		cancel()
+		return nil, nil, fmt.Errorf("GET %s: %s", rawURL, resp.Status)
+	}
+	return resp.Body, cancel, nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestFetchLiteLLMModels(t *testing.T) {
+	var gotAuth, gotTeam string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/model/info" {
+			http.NotFound(w, r)
+			return
+		}
+		gotAuth = r.Header.Get("Authorization")
+		gotTeam = r.Header.Get("x-litellm-team-id")
+		w.Write([]byte(`{"data":[
+			{"model_name":"gpt-4.1","model_info":{"litellm_provider":"openai","max_input_tokens":1047576,"max_output_tokens":32768,"input_cost_per_token":0.000002,"output_cost_per_token":0.000008,"supports_vision":true}},
+			{"model_name":"claude-sonnet-4","model_info":{"litellm_provider":"anthropic","max_input_tokens":200000,"input_cost_per_token":0.000003,"output_cost_per_token":0.000015}}
+		]}`))
+	}))
+	defer srv.Close()
+
+	entry := &config.ProviderConfig{
+		Provider: "litellm",
+		BaseURL:  srv.URL,
+		APIKey:   "sk-virtual-key",
+	}
+	entry.SetField("teamId", "eng-platform")
+	models, err := fetchLiteLLMModels(context.Background(), entry, generated.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotAuth != "Bearer sk-virtual-key" || gotTeam != "eng-platform" {
+		t.Errorf("auth=%q team=%q", gotAuth, gotTeam)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %+v", models)
+	}
+	m := models[0]
+	if m.ID != "gpt-4.1" || m.Name != "gpt-4.1 (via openai)" {
+		t.Errorf("model = %+v", m)
+	}
+	if m.InputPrice != 2.0 || m.OutputPrice != 8.0 {
+		t.Errorf("pricing = %v/%v per Mtok", m.InputPrice, m.OutputPrice)
+	}
+	if !m.SupportsImages || m.ContextWindow != 1047576 {
+		t.Errorf("model = %+v", m)
+	}
+}
+
+func TestFetchLiteLLMModelsRequiresBaseURL(t *testing.T) {
+	_, err := fetchLiteLLMModels(context.Background(), &config.ProviderConfig{Provider: "litellm"}, generated.ListOptions{})
+	if err == nil {
+		t.Error("missing gateway URL accepted")
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func init() {
+	generated.RegisterModelFetcher("vllm", fetchVLLMModels)
+	generated.RegisterModelFetcher("tgi", fetchTGIModels)
+}
+
+// fetchVLLMModels lists a vLLM server's models. vLLM extends the standard
+// /models response with max_model_len, which gives us the real context window
+// instead of a guess.
+func fetchVLLMModels(ctx context.Context, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	base := entry.BaseURL
+	if base == "" {
+		base = "http://localhost:8000/v1"
+	}
+	body, cancel, err := get(ctx, strings.TrimSuffix(base, "/")+"/models", entry.APIKey, entry)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer body.Close()
+
+	var models []generated.ModelInfo
+	var elem struct {
+		ID          string `json:"id"`
+		MaxModelLen int    `json:"max_model_len"`
+	}
+	next := paginate(opts, func() {
+		contextWindow := elem.MaxModelLen
+		if contextWindow == 0 {
+			contextWindow = 8192
+		}
+		models = append(models, generated.ModelInfo{ID: elem.ID, ContextWindow: contextWindow})
+	})
+	err = streamArrayField(body, "data", func(dec *json.Decoder) error {
+		elem = struct {
+			ID          string `json:"id"`
+			MaxModelLen int    `json:"max_model_len"`
+		}{}
+		if err := dec.Decode(&elem); err != nil {
+			return err
+		}
+		return next()
+	})
+	if err != nil && err != errStopStreaming {
+		return nil, err
+	}
+	return models, nil
+}
+
+// fetchTGIModels reads a Text Generation Inference server's /info endpoint,
+// which serves exactly one model along with its token limits. The endpoint
+// lives at the server root, not under the /v1 prefix the chat API uses.
+func fetchTGIModels(ctx context.Context, entry *config.ProviderConfig, opts generated.ListOptions) ([]generated.ModelInfo, error) {
+	base := entry.BaseURL
+	if base == "" {
+		base = "http://localhost:8080/v1"
+	}
+	root := strings.TrimSuffix(strings.TrimSuffix(base, "/"), "/v1")
+	body, cancel, err := get(ctx, root+"/info", "", entry)
+	if err != nil {
+		return nil, err
+	}
+	defer cancel()
+	defer body.Close()
+
+	var info struct {
+		ModelID        string `json:"model_id"`
+		MaxInputTokens int    `json:"max_input_tokens"`
+		MaxTotalTokens int    `json:"max_total_tokens"`
+	}
+	if err := json.NewDecoder(body).Decode(&info); err != nil {
+		return nil, err
+	}
+	model := generated.ModelInfo{ID: info.ModelID, ContextWindow: info.MaxTotalTokens}
+	if model.ContextWindow == 0 {
+		model.ContextWindow = 8192
+	}
+	if info.MaxTotalTokens > info.MaxInputTokens && info.MaxInputTokens > 0 {
+		model.MaxTokens = info.MaxTotalTokens - info.MaxInputTokens
+	}
+	return []generated.ModelInfo{model}, nil
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestFetchVLLMModelsReadsMaxModelLen(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/models" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"object": "list", "data": [
+			{"id": "meta-llama/Llama-3-8B", "max_model_len": 131072},
+			{"id": "mistralai/Mistral-7B"}
+		]}`))
+	}))
+	defer srv.Close()
+
+	models, err := fetchVLLMModels(context.Background(), &config.ProviderConfig{BaseURL: srv.URL + "/v1"}, generated.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 2 {
+		t.Fatalf("models = %+v", models)
+	}
+	if models[0].ContextWindow != 131072 {
+		t.Errorf("max_model_len not used: %+v", models[0])
+	}
+	if models[1].ContextWindow != 8192 {
+		t.Errorf("missing max_model_len should fall back: %+v", models[1])
+	}
+}
+
+func TestFetchTGIModelsReadsInfo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/info" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"model_id": "bigscience/bloom", "max_input_tokens": 4000, "max_total_tokens": 4096}`))
+	}))
+	defer srv.Close()
+
+	models, err := fetchTGIModels(context.Background(), &config.ProviderConfig{BaseURL: srv.URL + "/v1"}, generated.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(models) != 1 || models[0].ID != "bigscience/bloom" {
+		t.Fatalf("models = %+v", models)
+	}
+	if models[0].ContextWindow != 4096 || models[0].MaxTokens != 96 {
+		t.Errorf("token limits = %+v", models[0])
+	}
+}
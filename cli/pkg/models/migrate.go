@@ -0,0 +1,43 @@
+package models
+
+import "strings"
+
+// Migration records one configured model that is no longer offered by its
+// provider, with the suggested replacement.
+type Migration struct {
+	Provider   string
+	OldModelID string
+	NewModelID string
+}
+
+// SuggestReplacement picks the closest current model for a retired ID. Model
+// families keep stable ID prefixes across revisions (claude-3-5-sonnet-20240620
+// → claude-3-5-sonnet-20241022), so the candidate sharing the longest common
+// prefix wins; ties go to the lexicographically greatest ID, which for dated
+// suffixes is the newest. When nothing shares the leading family segment the
+// provider's default model is returned instead.
+func SuggestReplacement(old string, available []string, fallback string) string {
+	family, _, _ := strings.Cut(old, "-")
+	best, bestLen := "", 0
+	for _, id := range available {
+		if id == old {
+			continue
+		}
+		n := commonPrefixLen(old, id)
+		if n > bestLen || (n == bestLen && id > best) {
+			best, bestLen = id, n
+		}
+	}
+	if bestLen <= len(family) {
+		return fallback
+	}
+	return best
+}
+
+func commonPrefixLen(a, b string) int {
+	n := 0
+	for n < len(a) && n < len(b) && a[n] == b[n] {
+		n++
+	}
+	return n
+}
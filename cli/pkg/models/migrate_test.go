@@ -0,0 +1,29 @@
+package models
+
+import "testing"
+
+func TestSuggestReplacementPrefersFamilyAndNewestDate(t *testing.T) {
+	available := []string{
+		"claude-3-5-sonnet-20241022",
+		"claude-3-5-haiku-20241022",
+		"claude-sonnet-4-20250514",
+	}
+	got := SuggestReplacement("claude-3-5-sonnet-20240620", available, "claude-sonnet-4-20250514")
+	if got != "claude-3-5-sonnet-20241022" {
+		t.Errorf("suggestion = %q", got)
+	}
+}
+
+func TestSuggestReplacementTieBreaksNewest(t *testing.T) {
+	available := []string{"gpt-4o-2024-05-13", "gpt-4o-2024-11-20"}
+	if got := SuggestReplacement("gpt-4o-mini", available, "gpt-4.1"); got != "gpt-4o-2024-11-20" {
+		t.Errorf("suggestion = %q", got)
+	}
+}
+
+func TestSuggestReplacementFallsBackOutsideFamily(t *testing.T) {
+	available := []string{"gemini-2.5-pro", "gemini-2.5-flash"}
+	if got := SuggestReplacement("claude-3-opus-20240229", available, "gemini-2.5-pro"); got != "gemini-2.5-pro" {
+		t.Errorf("suggestion = %q", got)
+	}
+}
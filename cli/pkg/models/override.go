@@ -0,0 +1,36 @@
+package models
+
+import (
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// Override applies the entry's user override for m's ID in place and reports
+// whether one exists. Catalogs and definitions stay untouched; overrides are
+// applied at read time so they survive refreshes.
+func Override(entry *config.ProviderConfig, m *generated.ModelInfo) bool {
+	o := entry.OverrideFor(m.ID)
+	if o == nil {
+		return false
+	}
+	if o.ContextWindow != nil {
+		m.ContextWindow = *o.ContextWindow
+	}
+	if o.MaxTokens != nil {
+		m.MaxTokens = *o.MaxTokens
+	}
+	if o.InputPrice != nil {
+		m.InputPrice = *o.InputPrice
+	}
+	if o.OutputPrice != nil {
+		m.OutputPrice = *o.OutputPrice
+	}
+	return true
+}
+
+// ApplyOverrides applies the entry's overrides to every model in the list.
+func ApplyOverrides(entry *config.ProviderConfig, list []generated.ModelInfo) {
+	for i := range list {
+		Override(entry, &list[i])
+	}
+}
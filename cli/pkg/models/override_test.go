@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestOverride(t *testing.T) {
+	ctx := 32768
+	price := 0.5
+	entry := &config.ProviderConfig{
+		Provider: "ollama",
+		ModelOverrides: map[string]*config.ModelOverride{
+			"llama3": {ContextWindow: &ctx, InputPrice: &price},
+		},
+	}
+	list := []generated.ModelInfo{
+		{ID: "llama3", ContextWindow: 8192, MaxTokens: 4096, InputPrice: 3.0},
+		{ID: "mistral", ContextWindow: 8192},
+	}
+	ApplyOverrides(entry, list)
+	if list[0].ContextWindow != 32768 || list[0].InputPrice != 0.5 {
+		t.Errorf("override not applied: %+v", list[0])
+	}
+	if list[0].MaxTokens != 4096 {
+		t.Errorf("unset field changed: %+v", list[0])
+	}
+	if list[1].ContextWindow != 8192 {
+		t.Errorf("unrelated model changed: %+v", list[1])
+	}
+	if !Override(entry, &list[0]) || Override(entry, &list[1]) {
+		t.Error("Override reported the wrong models as overridden")
+	}
+}
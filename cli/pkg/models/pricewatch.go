@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// PriceSnapshot is the last pricing seen for one model, kept so a background
+// refresh can tell the user when their default model got cheaper or more
+// expensive since they last looked.
+type PriceSnapshot struct {
+	InputPrice  float64   `json:"inputPrice"`
+	OutputPrice float64   `json:"outputPrice"`
+	SeenAt      time.Time `json:"seenAt"`
+}
+
+func pricesPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "prices.json"), nil
+}
+
+func loadPrices() map[string]PriceSnapshot {
+	path, err := pricesPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var prices map[string]PriceSnapshot
+	if err := json.Unmarshal(data, &prices); err != nil {
+		return nil
+	}
+	return prices
+}
+
+// WatchPrice compares a model's current pricing against the stored snapshot,
+// records the new one, and returns a human-readable notice when either price
+// moved. The first sighting records silently: there is nothing to compare.
+func WatchPrice(providerID string, m generated.ModelInfo) string {
+	key := providerID + "/" + m.ID
+	prices := loadPrices()
+	previous, seen := prices[key]
+	if prices == nil {
+		prices = map[string]PriceSnapshot{}
+	}
+	prices[key] = PriceSnapshot{InputPrice: m.InputPrice, OutputPrice: m.OutputPrice, SeenAt: time.Now().UTC()}
+	savePrices(prices)
+	if !seen || (previous.InputPrice == m.InputPrice && previous.OutputPrice == m.OutputPrice) {
+		return ""
+	}
+	return fmt.Sprintf("pricing changed for %s: input %s, output %s (per Mtok)",
+		key,
+		describePriceMove(previous.InputPrice, m.InputPrice),
+		describePriceMove(previous.OutputPrice, m.OutputPrice))
+}
+
+// describePriceMove renders one price transition, with the relative change
+// when the old price allows computing one.
+func describePriceMove(old, current float64) string {
+	if old == current {
+		return fmt.Sprintf("$%.2f (unchanged)", current)
+	}
+	if old == 0 {
+		return fmt.Sprintf("$%.2f -> $%.2f", old, current)
+	}
+	return fmt.Sprintf("$%.2f -> $%.2f (%+.0f%%)", old, current, (current-old)/old*100)
+}
+
+// savePrices is best effort, like the other state files.
+func savePrices(prices map[string]PriceSnapshot) {
+	path, err := pricesPath()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(prices, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0o700)
+	_ = os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestWatchPrice(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	m := generated.ModelInfo{ID: "gpt-4o", InputPrice: 2.5, OutputPrice: 10}
+
+	if notice := WatchPrice("openai", m); notice != "" {
+		t.Errorf("first sighting produced a notice: %q", notice)
+	}
+	if notice := WatchPrice("openai", m); notice != "" {
+		t.Errorf("unchanged pricing produced a notice: %q", notice)
+	}
+
+	m.InputPrice = 3.0
+	notice := WatchPrice("openai", m)
+	if notice == "" {
+		t.Fatal("price change produced no notice")
+	}
+	for _, want := range []string{"openai/gpt-4o", "$2.50 -> $3.00", "+20%", "$10.00 (unchanged)"} {
+		if !strings.Contains(notice, want) {
+			t.Errorf("notice %q missing %q", notice, want)
+		}
+	}
+
+	// The new price becomes the baseline.
+	if notice := WatchPrice("openai", m); notice != "" {
+		t.Errorf("repeated run after a change produced a notice: %q", notice)
+	}
+}
@@ -0,0 +1,241 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// Capabilities is what probing learned about one model on one endpoint.
+// Generic OpenAI-compatible servers advertise almost nothing through
+// /models, so instead of guessing from model-name substrings we ask the
+// server directly and remember its answers.
+type Capabilities struct {
+	ContextWindow  int       `json:"contextWindow,omitempty"`
+	SupportsImages bool      `json:"supportsImages"`
+	SupportsTools  bool      `json:"supportsTools"`
+	ProbedAt       time.Time `json:"probedAt"`
+}
+
+// probeMaxTokens is deliberately impossible; servers reject it with an error
+// that states their real context length.
+const probeMaxTokens = 100_000_000
+
+// tinyPNG is a 1x1 pixel image, the cheapest possible vision request.
+const tinyPNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mNkYPhfDwAChwGA60e6kgAAAABJRU5ErkJggg=="
+
+// Probe asks the endpoint what modelID can do: the context window is parsed
+// from the error a too-large max_tokens provokes, vision and tool support
+// from whether a minimal request using each is accepted. Results are cached
+// per endpoint and model; transport failures abort the probe so an offline
+// server is not recorded as capability-free.
+func Probe(ctx context.Context, entry *config.ProviderConfig, base, modelID string) (*Capabilities, error) {
+	caps := &Capabilities{ProbedAt: time.Now().UTC()}
+
+	status, body, err := postChat(ctx, entry, base, map[string]any{
+		"model":      modelID,
+		"messages":   []map[string]any{{"role": "user", "content": "hi"}},
+		"max_tokens": probeMaxTokens,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		caps.ContextWindow = parseContextWindow(errorMessage(body))
+	}
+
+	status, _, err = postChat(ctx, entry, base, map[string]any{
+		"model": modelID,
+		"messages": []map[string]any{{
+			"role": "user",
+			"content": []map[string]any{
+				{"type": "text", "text": "hi"},
+				{"type": "image_url", "image_url": map[string]any{"url": "data:image/png;base64," + tinyPNG}},
+			},
+		}},
+		"max_tokens": 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	caps.SupportsImages = status == http.StatusOK
+
+	status, _, err = postChat(ctx, entry, base, map[string]any{
+		"model":    modelID,
+		"messages": []map[string]any{{"role": "user", "content": "hi"}},
+		"tools": []map[string]any{{
+			"type": "function",
+			"function": map[string]any{
+				"name":       "ping",
+				"parameters": map[string]any{"type": "object"},
+			},
+		}},
+		"max_tokens": 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	caps.SupportsTools = status == http.StatusOK
+
+	saveProbe(base, modelID, caps)
+	return caps, nil
+}
+
+// postChat sends one chat completion request and returns the status and
+// body; only transport failures are errors, rejections are answers.
+func postChat(ctx context.Context, entry *config.ProviderConfig, base string, payload map[string]any) (int, []byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return 0, nil, err
+	}
+	url := strings.TrimSuffix(base, "/") + "/chat/completions"
+	ctx, cancel := context.WithTimeout(ctx, entry.RequestTimeout(fetchTimeout))
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if entry.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+entry.APIKey)
+	}
+	entry.ApplyHeaders(req.Header)
+	resp, err := clientFor(entry).Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, nil, err
+	}
+	return resp.StatusCode, body, nil
+}
+
+// errorMessage digs the human-readable message out of an OpenAI-style error
+// body, falling back to the raw body for servers that send plain text.
+func errorMessage(body []byte) string {
+	var parsed struct {
+		Error struct {
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.Unmarshal(body, &parsed); err == nil && parsed.Error.Message != "" {
+		return parsed.Error.Message
+	}
+	return string(body)
+}
+
+// parseContextWindow extracts the context length from an error like
+// "maximum context length is 8192 tokens": the largest number that is
+// plausible as a window and is not the absurd max_tokens we sent.
+func parseContextWindow(message string) int {
+	best := 0
+	n := 0
+	flush := func() {
+		if n >= 1024 && n < probeMaxTokens && n > best {
+			best = n
+		}
+		n = 0
+	}
+	for _, r := range message {
+		if r >= '0' && r <= '9' {
+			if n < probeMaxTokens {
+				n = n*10 + int(r-'0')
+			}
+			continue
+		}
+		flush()
+	}
+	flush()
+	return best
+}
+
+// probeKey identifies one model on one endpoint in the probe cache.
+func probeKey(base, modelID string) string {
+	return strings.TrimSuffix(base, "/") + " " + modelID
+}
+
+func probesPath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "probes.json"), nil
+}
+
+// loadProbes reads the probe cache; a missing or broken file is an empty
+// cache.
+func loadProbes() map[string]Capabilities {
+	path, err := probesPath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var probes map[string]Capabilities
+	if err := json.Unmarshal(data, &probes); err != nil {
+		return nil
+	}
+	return probes
+}
+
+// CachedCapabilities returns the stored probe results for one model, if it
+// was ever probed.
+func CachedCapabilities(base, modelID string) (Capabilities, bool) {
+	caps, ok := loadProbes()[probeKey(base, modelID)]
+	return caps, ok
+}
+
+// saveProbe is best effort, like the catalog cache: an unwritable data dir
+// just means probing again next time.
+func saveProbe(base, modelID string, caps *Capabilities) {
+	probes := loadProbes()
+	if probes == nil {
+		probes = map[string]Capabilities{}
+	}
+	probes[probeKey(base, modelID)] = *caps
+	path, err := probesPath()
+	if err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(probes, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.MkdirAll(filepath.Dir(path), 0o700)
+	_ = os.WriteFile(path, data, 0o600)
+}
+
+// applyProbes overlays cached probe results onto a fetched model list, so
+// generic servers whose /models response carries no metadata still list the
+// real context window and vision support once probed.
+func applyProbes(base string, models []generated.ModelInfo) {
+	probes := loadProbes()
+	if len(probes) == 0 {
+		return
+	}
+	for i := range models {
+		caps, ok := probes[probeKey(base, models[i].ID)]
+		if !ok {
+			continue
+		}
+		if caps.ContextWindow > 0 {
+			models[i].ContextWindow = caps.ContextWindow
+		}
+		if caps.SupportsImages {
+			models[i].SupportsImages = true
+		}
+	}
+}
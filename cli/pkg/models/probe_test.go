@@ -0,0 +1,98 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// probeServer imitates an OpenAI-compatible server with a 16k context that
+// rejects images but accepts tools.
+func probeServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		var req struct {
+			MaxTokens int             `json:"max_tokens"`
+			Messages  json.RawMessage `json:"messages"`
+			Tools     json.RawMessage `json:"tools"`
+		}
+		if err := json.Unmarshal(body, &req); err != nil {
+			t.Errorf("bad request body: %v", err)
+		}
+		switch {
+		case req.MaxTokens > 16384:
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"This model's maximum context length is 16384 tokens. However, you requested 100000007 tokens."}}`)
+		case strings.Contains(string(req.Messages), "image_url"):
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"image input is not supported"}}`)
+		default:
+			fmt.Fprint(w, `{"choices":[{"message":{"content":"ok"}}]}`)
+		}
+	}))
+}
+
+func TestProbeAndCache(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	srv := probeServer(t)
+	defer srv.Close()
+	entry := &config.ProviderConfig{Provider: "lmstudio", BaseURL: srv.URL}
+
+	caps, err := Probe(context.Background(), entry, srv.URL, "local-model")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if caps.ContextWindow != 16384 {
+		t.Errorf("ContextWindow = %d, want 16384", caps.ContextWindow)
+	}
+	if caps.SupportsImages {
+		t.Error("SupportsImages = true for a server that rejects images")
+	}
+	if !caps.SupportsTools {
+		t.Error("SupportsTools = false for a server that accepts tools")
+	}
+
+	cached, ok := CachedCapabilities(srv.URL, "local-model")
+	if !ok {
+		t.Fatal("probe result was not cached")
+	}
+	if cached.ContextWindow != 16384 {
+		t.Errorf("cached ContextWindow = %d", cached.ContextWindow)
+	}
+
+	listed := []generated.ModelInfo{{ID: "local-model", ContextWindow: 8192}, {ID: "other", ContextWindow: 8192}}
+	applyProbes(srv.URL, listed)
+	if listed[0].ContextWindow != 16384 {
+		t.Errorf("applyProbes did not overlay the probed window: %d", listed[0].ContextWindow)
+	}
+	if listed[1].ContextWindow != 8192 {
+		t.Errorf("applyProbes touched an unprobed model: %d", listed[1].ContextWindow)
+	}
+}
+
+func TestParseContextWindow(t *testing.T) {
+	cases := []struct {
+		message string
+		want    int
+	}{
+		{"This model's maximum context length is 16384 tokens. However, you requested 100000007 tokens.", 16384},
+		{"max_tokens must be <= 4096", 4096},
+		{"context window of 8192 exceeded (got 100000000)", 8192},
+		{"something went wrong", 0},
+		{"requested 100000007 tokens", 0},
+	}
+	for _, tc := range cases {
+		if got := parseContextWindow(tc.message); got != tc.want {
+			t.Errorf("parseContextWindow(%q) = %d, want %d", tc.message, got, tc.want)
+		}
+	}
+}
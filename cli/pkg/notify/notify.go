@@ -0,0 +1,64 @@
+// Package notify raises the user's attention when a long-running task needs
+// input or finishes, so agents are not left blocked in a background window.
+// The default is the terminal bell; desktop mode adds a notification through
+// the platform's notifier.
+package notify
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+	"runtime"
+)
+
+// SettingMode is the settings key selecting how to notify: "bell" (the
+// default), "desktop" (bell plus a desktop notification), or "off".
+const SettingMode = "notify.mode"
+
+// desktopTools maps an OS to the command that raises a desktop notification.
+var desktopTools = map[string]func(title, message string) *exec.Cmd{
+	"linux": func(title, message string) *exec.Cmd {
+		return exec.Command("notify-send", "--app-name", "cline", title, message)
+	},
+	"darwin": func(title, message string) *exec.Cmd {
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		return exec.Command("osascript", "-e", script)
+	},
+	"windows": func(title, message string) *exec.Cmd {
+		return exec.Command("msg", "*", "/time:5", title+": "+message)
+	},
+}
+
+// lookPath and goos are swapped in tests.
+var (
+	lookPath = exec.LookPath
+	goos     = runtime.GOOS
+)
+
+// Notify rings the terminal bell and, in desktop mode, raises a desktop
+// notification. It is best-effort: a missing notifier or a failed command
+// never interferes with the task.
+func Notify(out io.Writer, settings map[string]string, title, message string) {
+	mode := settings[SettingMode]
+	switch mode {
+	case "off":
+		return
+	case "", "bell", "desktop":
+	default:
+		// An unknown mode is treated as off rather than guessed at.
+		return
+	}
+	fmt.Fprint(out, "\a")
+	if mode != "desktop" {
+		return
+	}
+	build, ok := desktopTools[goos]
+	if !ok {
+		return
+	}
+	cmd := build(title, message)
+	if _, err := lookPath(cmd.Args[0]); err != nil {
+		return
+	}
+	cmd.Run()
+}
@@ -0,0 +1,44 @@
+package notify
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNotifyModes(t *testing.T) {
+	restore := lookPath
+	lookPath = func(string) (string, error) { return "", errors.New("not installed") }
+	defer func() { lookPath = restore }()
+
+	cases := []struct {
+		mode string
+		want string
+	}{
+		{"", "\a"},
+		{"bell", "\a"},
+		{"desktop", "\a"},
+		{"off", ""},
+		{"shout", ""},
+	}
+	for _, c := range cases {
+		var out strings.Builder
+		Notify(&out, map[string]string{SettingMode: c.mode}, "cline", "task finished")
+		if out.String() != c.want {
+			t.Errorf("mode %q wrote %q, want %q", c.mode, out.String(), c.want)
+		}
+	}
+}
+
+func TestDesktopToolsCoverMajorPlatforms(t *testing.T) {
+	for _, os := range []string{"linux", "darwin", "windows"} {
+		build, ok := desktopTools[os]
+		if !ok {
+			t.Errorf("no desktop tool for %s", os)
+			continue
+		}
+		if cmd := build("t", "m"); len(cmd.Args) == 0 {
+			t.Errorf("empty command for %s", os)
+		}
+	}
+}
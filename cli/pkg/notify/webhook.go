@@ -0,0 +1,96 @@
+// Package notify posts task lifecycle events to a user-configured webhook,
+// so tasks kicked off on remote instances can report into Slack or custom
+// endpoints.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Event types sent over the webhook.
+const (
+	TaskStarted   = "started"
+	NeedsApproval = "needs-approval"
+	TaskCompleted = "completed"
+	TaskFailed    = "failed"
+)
+
+// Event is one task lifecycle notification.
+type Event struct {
+	Type      string    `json:"type"`
+	TaskID    string    `json:"taskId,omitempty"`
+	Provider  string    `json:"provider,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	CostUSD   float64   `json:"costUsd,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// Notifier delivers events to one webhook URL.
+type Notifier struct {
+	URL string
+	// SlackFormat wraps events in a Slack-compatible {"text": ...} payload.
+	SlackFormat bool
+	// Client defaults to http.DefaultClient; injectable for tests.
+	Client *http.Client
+}
+
+// Settings keys read by FromSettings.
+const (
+	SettingWebhook = "notifications.webhook"
+	SettingFormat  = "notifications.format"
+)
+
+// FromSettings builds a notifier from the settings map, or nil when no
+// webhook is configured.
+func FromSettings(settings map[string]string) *Notifier {
+	url := settings[SettingWebhook]
+	if url == "" {
+		return nil
+	}
+	return &Notifier{URL: url, SlackFormat: settings[SettingFormat] == "slack"}
+}
+
+// Send posts one event. Failures are returned, but callers in the task path
+// are expected to log and continue; a flaky webhook must not fail a task.
+func (n *Notifier) Send(ctx context.Context, ev Event) error {
+	if ev.Timestamp.IsZero() {
+		ev.Timestamp = time.Now().UTC()
+	}
+	var payload any = ev
+	if n.SlackFormat {
+		text := fmt.Sprintf("cline task %s: %s", ev.Type, ev.Message)
+		if ev.CostUSD > 0 {
+			text += fmt.Sprintf(" ($%.4f)", ev.CostUSD)
+		}
+		payload = map[string]string{"text": text}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	client := n.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s: %s", n.URL, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSendJSONPayload(t *testing.T) {
+	var received []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	n := &Notifier{URL: srv.URL}
+	err := n.Send(context.Background(), Event{Type: TaskCompleted, TaskID: "t1", CostUSD: 0.42, Message: "done"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ev Event
+	if err := json.Unmarshal(received, &ev); err != nil {
+		t.Fatal(err)
+	}
+	if ev.Type != TaskCompleted || ev.TaskID != "t1" || ev.CostUSD != 0.42 {
+		t.Errorf("payload = %+v", ev)
+	}
+	if ev.Timestamp.IsZero() {
+		t.Error("timestamp not filled in")
+	}
+}
+
+func TestSendSlackFormat(t *testing.T) {
+	var received []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	n := &Notifier{URL: srv.URL, SlackFormat: true}
+	if err := n.Send(context.Background(), Event{Type: NeedsApproval, Message: "approve `rm -rf build`"}); err != nil {
+		t.Fatal(err)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(received, &payload); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(payload["text"], "needs-approval") {
+		t.Errorf("slack text = %q", payload["text"])
+	}
+}
+
+func TestFromSettings(t *testing.T) {
+	if FromSettings(map[string]string{}) != nil {
+		t.Error("notifier built without webhook setting")
+	}
+	n := FromSettings(map[string]string{SettingWebhook: "https://hooks.example/x", SettingFormat: "slack"})
+	if n == nil || !n.SlackFormat {
+		t.Errorf("notifier = %+v", n)
+	}
+}
+
+func TestSendReportsHTTPError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "nope", http.StatusForbidden)
+	}))
+	defer srv.Close()
+	n := &Notifier{URL: srv.URL}
+	if err := n.Send(context.Background(), Event{Type: TaskFailed}); err == nil {
+		t.Error("403 response did not surface as error")
+	}
+}
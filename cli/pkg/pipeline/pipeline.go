@@ -0,0 +1,153 @@
+// Package pipeline parses small named pipelines whose steps are shell
+// commands or model prompts, so light automations can live next to the
+// provider config instead of in ad-hoc bash. Pipeline files are YAML
+// restricted to the same small subset eval suites and the repository policy
+// use.
+package pipeline
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Pipeline is one parsed pipeline file: an ordered list of steps, each
+// feeding its output to later steps through {{name}} placeholders.
+type Pipeline struct {
+	Steps []Step
+}
+
+// Step is one pipeline step. Exactly one of Run and Prompt is set: Run is a
+// shell command, Prompt is text sent to a model. Both may reference earlier
+// steps' outputs as {{name}}.
+type Step struct {
+	Name string
+	// Run is a shell command executed with `sh -c`.
+	Run string
+	// Prompt is sent to a model; Model picks which one.
+	Prompt string
+	// Model is an alias, "provider/model", or bare model ID, resolved like
+	// `cline compare --models`; empty uses the default provider and model.
+	Model string
+}
+
+var placeholderPattern = regexp.MustCompile(`\{\{\s*([^{}]*?)\s*\}\}`)
+
+// Parse reads a pipeline file: a `steps` list of name/run/prompt/model
+// entries. Placeholders are checked here so a typo fails before any step has
+// run.
+func Parse(data []byte) (*Pipeline, error) {
+	p := &Pipeline{}
+	inSteps := false
+	var current *Step
+	for lineNo, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "-") {
+			key, _, found := strings.Cut(trimmed, ":")
+			if !found {
+				return nil, fmt.Errorf("line %d: expected `key:`", lineNo+1)
+			}
+			if strings.TrimSpace(key) != "steps" {
+				return nil, fmt.Errorf("line %d: unknown pipeline key %q", lineNo+1, key)
+			}
+			inSteps = true
+			current = nil
+			continue
+		}
+		switch {
+		case inSteps && strings.HasPrefix(trimmed, "- "):
+			p.Steps = append(p.Steps, Step{})
+			current = &p.Steps[len(p.Steps)-1]
+			if rest := strings.TrimSpace(strings.TrimPrefix(trimmed, "- ")); rest != "" {
+				if err := setStepField(current, rest, lineNo); err != nil {
+					return nil, err
+				}
+			}
+		case inSteps && current != nil:
+			if err := setStepField(current, trimmed, lineNo); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("line %d: %q outside the steps list", lineNo+1, trimmed)
+		}
+	}
+	if len(p.Steps) == 0 {
+		return nil, fmt.Errorf("pipeline has no steps")
+	}
+	known := map[string]bool{}
+	for i := range p.Steps {
+		s := &p.Steps[i]
+		if s.Name == "" {
+			s.Name = fmt.Sprintf("step-%d", i+1)
+		}
+		if known[s.Name] {
+			return nil, fmt.Errorf("duplicate step name %q", s.Name)
+		}
+		if (s.Run == "") == (s.Prompt == "") {
+			return nil, fmt.Errorf("step %q needs exactly one of run or prompt", s.Name)
+		}
+		if s.Model != "" && s.Prompt == "" {
+			return nil, fmt.Errorf("step %q sets model but has no prompt", s.Name)
+		}
+		for _, ref := range placeholders(s.Run + s.Prompt) {
+			if !known[ref] {
+				return nil, fmt.Errorf("step %q references {{%s}}, which is not an earlier step", s.Name, ref)
+			}
+		}
+		known[s.Name] = true
+	}
+	return p, nil
+}
+
+func setStepField(s *Step, field string, lineNo int) error {
+	key, value, found := strings.Cut(field, ":")
+	if !found {
+		return fmt.Errorf("line %d: expected `key: value`", lineNo+1)
+	}
+	value = strings.Trim(strings.TrimSpace(value), `"'`)
+	switch strings.TrimSpace(key) {
+	case "name":
+		s.Name = value
+	case "run":
+		s.Run = value
+	case "prompt":
+		s.Prompt = value
+	case "model":
+		s.Model = value
+	default:
+		return fmt.Errorf("line %d: unknown step key %q", lineNo+1, key)
+	}
+	return nil
+}
+
+// Expand substitutes {{name}} placeholders with earlier steps' outputs.
+// Parse already verified every reference, so a miss here means the caller
+// skipped a step.
+func Expand(text string, outputs map[string]string) (string, error) {
+	var missing string
+	expanded := placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := strings.TrimSpace(placeholderPattern.FindStringSubmatch(match)[1])
+		out, ok := outputs[name]
+		if !ok {
+			missing = name
+			return match
+		}
+		return out
+	})
+	if missing != "" {
+		return "", fmt.Errorf("no output for {{%s}}", missing)
+	}
+	return expanded, nil
+}
+
+func placeholders(text string) []string {
+	var refs []string
+	for _, match := range placeholderPattern.FindAllStringSubmatch(text, -1) {
+		refs = append(refs, strings.TrimSpace(match[1]))
+	}
+	return refs
+}
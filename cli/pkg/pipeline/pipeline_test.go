@@ -0,0 +1,77 @@
+package pipeline
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParsePipeline(t *testing.T) {
+	p, err := Parse([]byte(`# release notes
+steps:
+  - name: log
+    run: git log --oneline -20
+  - name: notes
+    prompt: "Write release notes from: {{log}}"
+    model: openai/gpt-4o
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Steps) != 2 {
+		t.Fatalf("Steps = %d, want 2", len(p.Steps))
+	}
+	if p.Steps[0].Name != "log" || p.Steps[0].Run != "git log --oneline -20" {
+		t.Errorf("step 0 = %+v", p.Steps[0])
+	}
+	if p.Steps[1].Prompt != "Write release notes from: {{log}}" || p.Steps[1].Model != "openai/gpt-4o" {
+		t.Errorf("step 1 = %+v", p.Steps[1])
+	}
+}
+
+func TestParsePipelineDefaultsNames(t *testing.T) {
+	p, err := Parse([]byte("steps:\n  - run: true\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Steps[0].Name != "step-1" {
+		t.Errorf("Name = %q, want step-1", p.Steps[0].Name)
+	}
+}
+
+func TestParsePipelineRejectsBadSteps(t *testing.T) {
+	cases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"empty", "steps:\n", "no steps"},
+		{"both", "steps:\n  - run: true\n    prompt: hi\n", "exactly one of run or prompt"},
+		{"neither", "steps:\n  - name: x\n", "exactly one of run or prompt"},
+		{"model without prompt", "steps:\n  - run: true\n    model: gpt-4o\n", "sets model but has no prompt"},
+		{"duplicate", "steps:\n  - name: a\n    run: true\n  - name: a\n    run: true\n", "duplicate step name"},
+		{"forward reference", "steps:\n  - name: a\n    run: echo {{b}}\n  - name: b\n    run: true\n", "not an earlier step"},
+		{"self reference", "steps:\n  - name: a\n    run: echo {{a}}\n", "not an earlier step"},
+		{"unknown key", "steps:\n  - runs: true\n", "unknown step key"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Parse([]byte(tc.in))
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("err = %v, want containing %q", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	out, err := Expand("notes for {{ log }} and {{log}}", map[string]string{"log": "abc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "notes for abc and abc" {
+		t.Errorf("Expand = %q", out)
+	}
+	if _, err := Expand("{{missing}}", nil); err == nil {
+		t.Error("Expand with unknown placeholder should fail")
+	}
+}
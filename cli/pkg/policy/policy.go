@@ -0,0 +1,164 @@
+// Package policy loads the committed per-repository policy file
+// (.cline/policy.yaml) that pins which providers and models a project
+// allows. The file carries no secrets and is meant to be reviewed and
+// committed like any other config in the repo.
+package policy
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the policy path relative to the repository root.
+const FileName = ".cline/policy.yaml"
+
+// Policy is a repository's provider/model pinning. Empty lists mean
+// "no restriction".
+type Policy struct {
+	AllowedProviders []string
+	AllowedModels    []string
+	DefaultProvider  string
+	DefaultModel     string
+	// RequireRetentionAck makes the CLI refuse to send prompts to a provider
+	// until its data-retention notice has been acknowledged.
+	RequireRetentionAck bool
+	// Role assigns what this machine's user may do: "admin" and "developer"
+	// are unrestricted, "restricted" blocks commands that add providers or
+	// weaken safety settings. Empty means unrestricted.
+	Role string
+	// WipeKeysOnRevoke makes the CLI wipe locally stored provider keys when
+	// the account session has been revoked server-side (offboarding).
+	WipeKeysOnRevoke bool
+	// Path is where the policy was loaded from.
+	Path string
+}
+
+// Find walks from dir upward to the repository root (marked by .git) looking
+// for a policy file. It returns nil without error when none exists.
+func Find(dir string) (*Policy, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		path := filepath.Join(dir, filepath.FromSlash(FileName))
+		if data, err := os.ReadFile(path); err == nil {
+			p, err := parse(data)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", path, err)
+			}
+			p.Path = path
+			return p, nil
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// parse reads the small YAML subset the policy file uses: scalar keys and
+// string lists.
+func parse(data []byte) (*Policy, error) {
+	p := &Policy{}
+	var currentList *[]string
+	for lineNo, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") {
+			if currentList == nil {
+				return nil, fmt.Errorf("line %d: list item outside a list", lineNo+1)
+			}
+			*currentList = append(*currentList, unquote(strings.TrimPrefix(trimmed, "- ")))
+			continue
+		}
+		key, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected `key: value`", lineNo+1)
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(strings.TrimSpace(value))
+		currentList = nil
+		switch key {
+		case "allowed-providers":
+			currentList = &p.AllowedProviders
+		case "allowed-models":
+			currentList = &p.AllowedModels
+		case "default-provider":
+			p.DefaultProvider = value
+		case "default-model":
+			p.DefaultModel = value
+		case "require-retention-ack":
+			p.RequireRetentionAck = value == "true"
+		case "wipe-keys-on-revoke":
+			p.WipeKeysOnRevoke = value == "true"
+		case "role":
+			switch value {
+			case RoleAdmin, RoleDeveloper, RoleRestricted:
+				p.Role = value
+			default:
+				return nil, fmt.Errorf("line %d: unknown role %q (admin, developer or restricted)", lineNo+1, value)
+			}
+		default:
+			return nil, fmt.Errorf("line %d: unknown policy key %q", lineNo+1, key)
+		}
+		if currentList != nil && value != "" {
+			// Inline form: `allowed-providers: [a, b]`.
+			value = strings.Trim(value, "[]")
+			for _, item := range strings.Split(value, ",") {
+				if item = unquote(strings.TrimSpace(item)); item != "" {
+					*currentList = append(*currentList, item)
+				}
+			}
+			currentList = nil
+		}
+	}
+	return p, nil
+}
+
+func unquote(s string) string {
+	return strings.Trim(s, `"'`)
+}
+
+// The roles a policy may assign.
+const (
+	RoleAdmin      = "admin"
+	RoleDeveloper  = "developer"
+	RoleRestricted = "restricted"
+)
+
+// Restricted reports whether the policy assigns the restricted role.
+func (p *Policy) Restricted() bool {
+	return p.Role == RoleRestricted
+}
+
+// AllowsProvider reports whether the policy permits a provider.
+func (p *Policy) AllowsProvider(id string) bool {
+	return allows(p.AllowedProviders, id)
+}
+
+// AllowsModel reports whether the policy permits a model.
+func (p *Policy) AllowsModel(id string) bool {
+	return allows(p.AllowedModels, id)
+}
+
+func allows(list []string, id string) bool {
+	if len(list) == 0 {
+		return true
+	}
+	for _, item := range list {
+		if item == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseListsAndScalars(t *testing.T) {
+	p, err := parse([]byte(`# pinned for CI
+allowed-providers:
+  - anthropic
+  - openai
+allowed-models: ["claude-sonnet-4-20250514", gpt-4.1]
+default-provider: anthropic
+default-model: "claude-sonnet-4-20250514"
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.AllowedProviders) != 2 || p.AllowedProviders[1] != "openai" {
+		t.Errorf("AllowedProviders = %v", p.AllowedProviders)
+	}
+	if len(p.AllowedModels) != 2 || p.AllowedModels[1] != "gpt-4.1" {
+		t.Errorf("AllowedModels = %v", p.AllowedModels)
+	}
+	if p.DefaultProvider != "anthropic" || p.DefaultModel != "claude-sonnet-4-20250514" {
+		t.Errorf("defaults = %q / %q", p.DefaultProvider, p.DefaultModel)
+	}
+}
+
+func TestParseRole(t *testing.T) {
+	p, err := parse([]byte("role: restricted\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Restricted() {
+		t.Errorf("Role = %q", p.Role)
+	}
+	if _, err := parse([]byte("role: superuser\n")); err == nil {
+		t.Error("unknown role accepted")
+	}
+}
+
+func TestParseRejectsUnknownKey(t *testing.T) {
+	if _, err := parse([]byte("allowed-provider: anthropic\n")); err == nil {
+		t.Error("unknown key accepted")
+	}
+}
+
+func TestFindWalksUpToRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".cline"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".cline", "policy.yaml"), []byte("default-provider: openai\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Find(nested)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p == nil || p.DefaultProvider != "openai" {
+		t.Fatalf("policy = %+v", p)
+	}
+	if p.Path != filepath.Join(root, ".cline", "policy.yaml") {
+		t.Errorf("Path = %q", p.Path)
+	}
+}
+
+func TestFindStopsAtRepoRoot(t *testing.T) {
+	root := t.TempDir()
+	inner := filepath.Join(root, "repo")
+	if err := os.MkdirAll(filepath.Join(inner, ".git"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// Policy above the repo root must not leak in.
+	if err := os.MkdirAll(filepath.Join(root, ".cline"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".cline", "policy.yaml"), []byte("default-provider: openai\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Find(inner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p != nil {
+		t.Errorf("policy outside repo root was picked up: %+v", p)
+	}
+}
+
+func TestAllowsEmptyListMeansAll(t *testing.T) {
+	p := &Policy{AllowedProviders: []string{"anthropic"}}
+	if !p.AllowsProvider("anthropic") || p.AllowsProvider("openai") {
+		t.Error("AllowsProvider ignores allowed-providers")
+	}
+	if !p.AllowsModel("anything") {
+		t.Error("empty allowed-models should allow all")
+	}
+}
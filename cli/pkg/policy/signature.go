@@ -0,0 +1,92 @@
+package policy
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Policies distributed by a company rather than committed to the repo need
+// tamper protection: a detached Ed25519 signature next to the policy file,
+// verified against a public key either baked into the build or configured.
+// Without a trusted key, signatures are not required and nothing changes.
+
+// SignatureSuffix names the detached signature file: the base64 Ed25519
+// signature of the policy file's exact bytes.
+const SignatureSuffix = ".sig"
+
+// BuiltinPublicKey is the base64 Ed25519 public key trusted by this build;
+// empty in stock builds. Companies set it when compiling their distribution:
+//
+//	go build -ldflags "-X github.com/cline/cline/cli/pkg/policy.BuiltinPublicKey=<base64>"
+var BuiltinPublicKey string
+
+// SettingPublicKey is the settings key holding a configured trusted public
+// key; it takes precedence over the built-in one.
+const SettingPublicKey = "policy.publicKey"
+
+// TrustedKey picks the public key to verify policies against: the configured
+// one first, then the built-in. Empty means signatures are not enforced.
+func TrustedKey(settings map[string]string) string {
+	if key := settings[SettingPublicKey]; key != "" {
+		return key
+	}
+	return BuiltinPublicKey
+}
+
+// VerifyFile checks the policy file at path against its detached signature
+// using the given base64 public key. A missing signature file, a malformed
+// key, or a signature that does not match the file's current bytes are all
+// errors.
+func VerifyFile(path, publicKeyB64 string) error {
+	key, err := base64.StdEncoding.DecodeString(publicKeyB64)
+	if err != nil || len(key) != ed25519.PublicKeySize {
+		return fmt.Errorf("trusted policy key is not a base64 Ed25519 public key")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sigB64, err := os.ReadFile(path + SignatureSuffix)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s is not signed (missing %s%s) but this build requires signed policies", path, path, SignatureSuffix)
+		}
+		return err
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(sigB64)))
+	if err != nil || len(sig) != ed25519.SignatureSize {
+		return fmt.Errorf("%s%s is not a base64 Ed25519 signature", path, SignatureSuffix)
+	}
+	if !ed25519.Verify(ed25519.PublicKey(key), data, sig) {
+		return fmt.Errorf("%s does not match its signature; the policy was modified after signing", path)
+	}
+	return nil
+}
+
+// SignFile writes the detached signature for the policy file at path using a
+// base64 Ed25519 private key.
+func SignFile(path, privateKeyB64 string) error {
+	key, err := base64.StdEncoding.DecodeString(privateKeyB64)
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return fmt.Errorf("signing key is not a base64 Ed25519 private key")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	sig := ed25519.Sign(ed25519.PrivateKey(key), data)
+	return os.WriteFile(path+SignatureSuffix, []byte(base64.StdEncoding.EncodeToString(sig)+"\n"), 0o644)
+}
+
+// GenerateKeyPair returns a fresh base64 Ed25519 key pair for policy signing.
+func GenerateKeyPair() (publicB64, privateB64 string, err error) {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(pub), base64.StdEncoding.EncodeToString(priv), nil
+}
@@ -0,0 +1,58 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSignAndVerifyFile(t *testing.T) {
+	pub, priv, err := GenerateKeyPair()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(path, []byte("allowed-providers: [openai]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := VerifyFile(path, pub); err == nil || !strings.Contains(err.Error(), "not signed") {
+		t.Errorf("unsigned policy: err = %v", err)
+	}
+	if err := SignFile(path, priv); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyFile(path, pub); err != nil {
+		t.Errorf("signed policy rejected: %v", err)
+	}
+
+	// Any edit after signing must invalidate the signature.
+	if err := os.WriteFile(path, []byte("allowed-providers: [other]\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := VerifyFile(path, pub); err == nil || !strings.Contains(err.Error(), "modified after signing") {
+		t.Errorf("tampered policy: err = %v", err)
+	}
+
+	if err := VerifyFile(path, "not-base64!"); err == nil {
+		t.Error("malformed key accepted")
+	}
+}
+
+func TestTrustedKeyPrecedence(t *testing.T) {
+	old := BuiltinPublicKey
+	defer func() { BuiltinPublicKey = old }()
+
+	BuiltinPublicKey = ""
+	if got := TrustedKey(nil); got != "" {
+		t.Errorf("no key: %q", got)
+	}
+	BuiltinPublicKey = "builtin"
+	if got := TrustedKey(nil); got != "builtin" {
+		t.Errorf("builtin: %q", got)
+	}
+	if got := TrustedKey(map[string]string{SettingPublicKey: "configured"}); got != "configured" {
+		t.Errorf("configured should win: %q", got)
+	}
+}
@@ -0,0 +1,162 @@
+// Package prompts implements the shared template library: parametrized
+// Go text/template prompts stored under ~/.cline/prompts/*.tmpl, with an
+// optional header pinning a provider or model per template.
+package prompts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// Template is one parsed prompt template.
+type Template struct {
+	Name string
+	// Provider and ModelID pin where the template runs; empty falls back to
+	// the invocation's flags and then the configured defaults.
+	Provider string
+	ModelID  string
+	Body     string
+}
+
+// Dir returns the template directory.
+func Dir() (string, error) {
+	dir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "prompts"), nil
+}
+
+// List returns the saved template names, sorted.
+func List() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".tmpl") {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(e.Name(), ".tmpl"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Load reads and parses a named template. An optional header before a "---"
+// line pins the provider and model:
+//
+//	provider: openai
+//	model: gpt-4.1
+//	---
+//	Write a commit message for:
+//	{{file .diff}}
+func Load(name string) (*Template, error) {
+	if name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("invalid template name %q", name)
+	}
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".tmpl"))
+	if os.IsNotExist(err) {
+		names, _ := List()
+		if len(names) == 0 {
+			return nil, fmt.Errorf("no prompt template %q (directory %s is empty)", name, dir)
+		}
+		return nil, fmt.Errorf("no prompt template %q (have: %s)", name, strings.Join(names, ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parse(name, string(data))
+}
+
+// parse splits the optional header from the body.
+func parse(name, text string) (*Template, error) {
+	t := &Template{Name: name, Body: text}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "---" {
+			for _, header := range lines[:i] {
+				header = strings.TrimSpace(header)
+				if header == "" || strings.HasPrefix(header, "#") {
+					continue
+				}
+				key, value, ok := strings.Cut(header, ":")
+				if !ok {
+					return nil, fmt.Errorf("template %s: bad header line %q", name, header)
+				}
+				switch strings.TrimSpace(key) {
+				case "provider":
+					t.Provider = strings.TrimSpace(value)
+				case "model":
+					t.ModelID = strings.TrimSpace(value)
+				default:
+					return nil, fmt.Errorf("template %s: unknown header key %q", name, strings.TrimSpace(key))
+				}
+			}
+			t.Body = strings.Join(lines[i+1:], "\n")
+			return t, nil
+		}
+		// Headers are simple key: value lines; anything else means the file
+		// has no header at all.
+		trimmed := strings.TrimSpace(line)
+		if trimmed != "" && !strings.HasPrefix(trimmed, "#") && !strings.Contains(trimmed, ":") {
+			break
+		}
+	}
+	return t, nil
+}
+
+// Render executes the template body with the given variables. Templates
+// reference variables as {{.name}} and can pull files in with {{file "path"}}
+// or {{file .name}}.
+func (t *Template) Render(vars map[string]string) (string, error) {
+	funcs := template.FuncMap{
+		"file": func(path string) (string, error) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+			return strings.TrimRight(string(data), "\n"), nil
+		},
+	}
+	tmpl, err := template.New(t.Name).Funcs(funcs).Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, vars); err != nil {
+		return "", fmt.Errorf("template %s: %w", t.Name, err)
+	}
+	return strings.TrimSpace(b.String()), nil
+}
+
+// ParseVars turns repeated --var name=value flags into the render data.
+func ParseVars(pairs []string) (map[string]string, error) {
+	vars := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("bad --var %q (want name=value)", pair)
+		}
+		vars[name] = value
+	}
+	return vars, nil
+}
@@ -0,0 +1,107 @@
+package prompts
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func saveTemplate(t *testing.T, name, text string) {
+	t.Helper()
+	dir, err := Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name+".tmpl"), []byte(text), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadWithHeaderPinsModel(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saveTemplate(t, "commit-msg", "provider: openai\nmodel: gpt-4.1-mini\n---\nWrite a commit message for:\n{{.diff}}\n")
+	tmpl, err := Load("commit-msg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Provider != "openai" || tmpl.ModelID != "gpt-4.1-mini" {
+		t.Errorf("pins = %q/%q", tmpl.Provider, tmpl.ModelID)
+	}
+	out, err := tmpl.Render(map[string]string{"diff": "- old\n+ new"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "+ new") || strings.Contains(out, "provider:") {
+		t.Errorf("rendered = %q", out)
+	}
+}
+
+func TestLoadWithoutHeader(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saveTemplate(t, "plain", "Summarize {{.topic}} briefly.")
+	tmpl, err := Load("plain")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tmpl.Provider != "" || tmpl.ModelID != "" {
+		t.Errorf("unexpected pins %q/%q", tmpl.Provider, tmpl.ModelID)
+	}
+	out, err := tmpl.Render(map[string]string{"topic": "goroutines"})
+	if err != nil || out != "Summarize goroutines briefly." {
+		t.Errorf("out = %q, err = %v", out, err)
+	}
+}
+
+func TestRenderFileInterpolation(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "diff.patch")
+	if err := os.WriteFile(path, []byte("+added line\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	saveTemplate(t, "review", "Review this:\n{{file .patch}}")
+	tmpl, err := Load("review")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := tmpl.Render(map[string]string{"patch": path})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, "+added line") {
+		t.Errorf("out = %q", out)
+	}
+}
+
+func TestRenderMissingVariableErrors(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saveTemplate(t, "strict", "Hello {{.name}}")
+	tmpl, err := Load("strict")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tmpl.Render(map[string]string{}); err == nil {
+		t.Error("missing variable did not error")
+	}
+}
+
+func TestLoadUnknownHeaderKey(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	saveTemplate(t, "bad", "temperature: 2\n---\nbody")
+	if _, err := Load("bad"); err == nil {
+		t.Error("unknown header key accepted")
+	}
+}
+
+func TestParseVars(t *testing.T) {
+	vars, err := ParseVars([]string{"name=Ada", "lang=Go"})
+	if err != nil || vars["name"] != "Ada" || vars["lang"] != "Go" {
+		t.Errorf("vars = %v, err = %v", vars, err)
+	}
+	if _, err := ParseVars([]string{"oops"}); err == nil {
+		t.Error("malformed pair accepted")
+	}
+}
@@ -0,0 +1,151 @@
+// Package recents tracks which provider/model pairs the user actually uses
+// and which they starred, so selection lists can float the likely choice to
+// the top. The state lives in its own small file, not the config: it is
+// derived convenience data and losing it must never break a setup.
+package recents
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// maxRecents caps how many entries the file keeps.
+const maxRecents = 50
+
+// Entry is one used provider/model pair.
+type Entry struct {
+	Provider string    `json:"provider"`
+	ModelID  string    `json:"modelId"`
+	LastUsed time.Time `json:"lastUsed"`
+	Count    int       `json:"count"`
+}
+
+// State is the on-disk favorites and recents file.
+type State struct {
+	// Favorites holds "provider/modelId" refs the user starred.
+	Favorites []string `json:"favorites,omitempty"`
+	Recents   []Entry  `json:"recents,omitempty"`
+}
+
+func path() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "recents.json"), nil
+}
+
+// Load reads the state; a missing or unreadable file is an empty state.
+func Load() *State {
+	p, err := path()
+	if err != nil {
+		return &State{}
+	}
+	data, err := os.ReadFile(p)
+	if err != nil {
+		return &State{}
+	}
+	var s State
+	if json.Unmarshal(data, &s) != nil {
+		return &State{}
+	}
+	return &s
+}
+
+// Save writes the state; best-effort like the stats file.
+func (s *State) Save() {
+	p, err := path()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o700); err != nil {
+		return
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return
+	}
+	os.WriteFile(p, data, 0o600)
+}
+
+// RecordUse bumps a pair's recency and count; called after successful
+// completions, so the list reflects real use rather than browsing.
+func RecordUse(provider, modelID string) {
+	if provider == "" || modelID == "" {
+		return
+	}
+	s := Load()
+	for i := range s.Recents {
+		if s.Recents[i].Provider == provider && s.Recents[i].ModelID == modelID {
+			s.Recents[i].LastUsed = time.Now()
+			s.Recents[i].Count++
+			s.Save()
+			return
+		}
+	}
+	s.Recents = append(s.Recents, Entry{Provider: provider, ModelID: modelID, LastUsed: time.Now(), Count: 1})
+	if len(s.Recents) > maxRecents {
+		sort.Slice(s.Recents, func(i, j int) bool { return s.Recents[i].LastUsed.After(s.Recents[j].LastUsed) })
+		s.Recents = s.Recents[:maxRecents]
+	}
+	s.Save()
+}
+
+func ref(provider, modelID string) string {
+	return provider + "/" + modelID
+}
+
+// IsFavorite reports whether the pair is starred.
+func (s *State) IsFavorite(provider, modelID string) bool {
+	for _, f := range s.Favorites {
+		if f == ref(provider, modelID) {
+			return true
+		}
+	}
+	return false
+}
+
+// ToggleFavorite stars or unstars a pair and reports the new state.
+func (s *State) ToggleFavorite(provider, modelID string) bool {
+	r := ref(provider, modelID)
+	for i, f := range s.Favorites {
+		if f == r {
+			s.Favorites = append(s.Favorites[:i], s.Favorites[i+1:]...)
+			return false
+		}
+	}
+	s.Favorites = append(s.Favorites, r)
+	return true
+}
+
+// rank orders a pair for selection lists: favorites first, then recents by
+// last use, then everything else in the caller's order.
+func (s *State) rank(provider, modelID string) (int, time.Time) {
+	if s.IsFavorite(provider, modelID) {
+		return 0, time.Time{}
+	}
+	for _, e := range s.Recents {
+		if e.Provider == provider && e.ModelID == modelID {
+			return 1, e.LastUsed
+		}
+	}
+	return 2, time.Time{}
+}
+
+// SortModelIDs reorders model IDs in place: favorites, then recents (most
+// recent first), then the rest in their existing order.
+func (s *State) SortModelIDs(provider string, ids []string) {
+	sort.SliceStable(ids, func(i, j int) bool {
+		ri, ti := s.rank(provider, ids[i])
+		rj, tj := s.rank(provider, ids[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return ti.After(tj)
+	})
+}
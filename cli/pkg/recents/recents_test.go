@@ -0,0 +1,53 @@
+package recents
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordUseRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	RecordUse("openai", "gpt-4.1")
+	RecordUse("openai", "gpt-4.1")
+	RecordUse("anthropic", "claude-sonnet-4-20250514")
+	s := Load()
+	if len(s.Recents) != 2 {
+		t.Fatalf("recents = %+v", s.Recents)
+	}
+	for _, e := range s.Recents {
+		if e.Provider == "openai" && e.Count != 2 {
+			t.Errorf("count = %d", e.Count)
+		}
+	}
+}
+
+func TestToggleFavorite(t *testing.T) {
+	s := &State{}
+	if !s.ToggleFavorite("openai", "o3") {
+		t.Error("first toggle should star")
+	}
+	if !s.IsFavorite("openai", "o3") {
+		t.Error("not starred")
+	}
+	if s.ToggleFavorite("openai", "o3") {
+		t.Error("second toggle should unstar")
+	}
+}
+
+func TestSortModelIDs(t *testing.T) {
+	s := &State{
+		Favorites: []string{"openai/o3"},
+		Recents: []Entry{
+			{Provider: "openai", ModelID: "gpt-4.1-mini", LastUsed: time.Now().Add(-time.Hour)},
+			{Provider: "openai", ModelID: "gpt-4.1", LastUsed: time.Now()},
+		},
+	}
+	ids := []string{"o4-mini", "gpt-4.1-mini", "gpt-4.1", "o3"}
+	s.SortModelIDs("openai", ids)
+	want := []string{"o3", "gpt-4.1", "gpt-4.1-mini", "o4-mini"}
+	for i := range want {
+		if ids[i] != want[i] {
+			t.Fatalf("ids = %v, want %v", ids, want)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+// Package redact applies content redaction rules to text before it leaves the
+// machine for a provider. Built-in scanners cover common secret shapes; teams
+// add their own regexes through settings to cover customer data formats.
+package redact
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// settingPrefix namespaces custom rules in the settings map:
+// `cline settings set redaction.rule.ticket-id 'CUST-[0-9]+'`.
+const settingPrefix = "redaction.rule."
+
+// Rule is one named redaction pattern.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// Match is one redacted span, reported for previewing.
+type Match struct {
+	Rule string
+	Line int
+	// Text is the redacted form of the span, safe to display.
+	Text string
+}
+
+// builtinRules catch secret material regardless of configuration.
+var builtinRules = []Rule{
+	{Name: "aws-access-key", Pattern: regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)},
+	{Name: "private-key-block", Pattern: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+	{Name: "bearer-token", Pattern: regexp.MustCompile(`\bBearer\s+[A-Za-z0-9._~+/-]{16,}`)},
+	{Name: "api-key", Pattern: regexp.MustCompile(`\b(sk|pk|ghp|gho|xoxb)[-_][A-Za-z0-9_-]{16,}\b`)},
+}
+
+// Redactor applies an ordered rule set to outbound text.
+type Redactor struct {
+	rules []Rule
+}
+
+// FromSettings builds a redactor from the built-in scanners plus any custom
+// rules configured under redaction.rule.*. Invalid custom patterns are
+// rejected rather than silently skipped: a typo must not open a data hole.
+func FromSettings(settings map[string]string) (*Redactor, error) {
+	rules := make([]Rule, len(builtinRules))
+	copy(rules, builtinRules)
+	names := make([]string, 0)
+	for key := range settings {
+		if strings.HasPrefix(key, settingPrefix) {
+			names = append(names, key)
+		}
+	}
+	sort.Strings(names)
+	for _, key := range names {
+		pattern, err := regexp.Compile(settings[key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid redaction rule %s: %w", key, err)
+		}
+		rules = append(rules, Rule{Name: strings.TrimPrefix(key, settingPrefix), Pattern: pattern})
+	}
+	return &Redactor{rules: rules}, nil
+}
+
+// Apply redacts text, replacing each match with a [REDACTED:<rule>] marker,
+// and reports what was matched for preview output.
+func (r *Redactor) Apply(text string) (string, []Match) {
+	var matches []Match
+	for _, rule := range r.rules {
+		text = rule.Pattern.ReplaceAllStringFunc(text, func(found string) string {
+			marker := fmt.Sprintf("[REDACTED:%s]", rule.Name)
+			matches = append(matches, Match{
+				Rule: rule.Name,
+				Line: lineOf(text, found),
+				Text: marker,
+			})
+			return marker
+		})
+	}
+	return text, matches
+}
+
+// lineOf reports the 1-based line of the first occurrence of found; good
+// enough for preview output.
+func lineOf(text, found string) int {
+	idx := strings.Index(text, found)
+	if idx < 0 {
+		return 0
+	}
+	return 1 + strings.Count(text[:idx], "\n")
+}
@@ -0,0 +1,125 @@
+// Package respcache is an opt-in cache of model replies keyed on the model
+// and a normalized prompt, so scripts and CI runs that repeat the same
+// prompts stop paying for them. Caching is best effort: a broken cache file
+// behaves like an empty one.
+package respcache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// SettingEnabled turns the cache on when set to "true"; replies are never
+// cached by default.
+const SettingEnabled = "cache.responses"
+
+// SettingTTL overrides how long cached replies are served, as a Go duration
+// ("1h", "30m").
+const SettingTTL = "cache.ttl"
+
+const defaultTTL = 24 * time.Hour
+
+// entry is one cached reply; expired entries are dropped on the next write.
+type entry struct {
+	Reply string    `json:"reply"`
+	At    time.Time `json:"at"`
+}
+
+// Enabled reports whether the user opted in via settings.
+func Enabled(settings map[string]string) bool {
+	return settings[SettingEnabled] == "true"
+}
+
+// TTL returns the configured time-to-live, defaulting to 24h; malformed or
+// non-positive values fall back to the default.
+func TTL(settings map[string]string) time.Duration {
+	if d, err := time.ParseDuration(settings[SettingTTL]); err == nil && d > 0 {
+		return d
+	}
+	return defaultTTL
+}
+
+// Key derives the cache key for one prompt. The prompt is normalized first
+// so incidental whitespace differences between script runs still hit.
+func Key(providerID, modelID, system, prompt string) string {
+	h := sha256.New()
+	h.Write([]byte(providerID + "\x00" + modelID + "\x00" + normalize(system) + "\x00" + normalize(prompt)))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// normalize collapses whitespace runs and trims the ends; prompt content is
+// otherwise left alone, since case and punctuation change replies.
+func normalize(prompt string) string {
+	return strings.Join(strings.Fields(prompt), " ")
+}
+
+// Get returns the cached reply for a key if one exists and is younger than
+// ttl.
+func Get(key string, ttl time.Duration) (string, bool) {
+	e, ok := load()[key]
+	if !ok || time.Since(e.At) > ttl {
+		return "", false
+	}
+	return e.Reply, true
+}
+
+// Put records a reply, dropping entries already past ttl while it is there.
+func Put(key, reply string, ttl time.Duration) {
+	entries := load()
+	if entries == nil {
+		entries = map[string]entry{}
+	}
+	for k, e := range entries {
+		if time.Since(e.At) > ttl {
+			delete(entries, k)
+		}
+	}
+	entries[key] = entry{Reply: reply, At: time.Now().UTC()}
+	save(entries)
+}
+
+func cachePath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "response-cache.json"), nil
+}
+
+func load() map[string]entry {
+	path, err := cachePath()
+	if err != nil {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entries map[string]entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil
+	}
+	return entries
+}
+
+func save(entries map[string]entry) {
+	path, err := cachePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return
+	}
+	_ = os.WriteFile(path, data, 0o600)
+}
@@ -0,0 +1,54 @@
+package respcache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCacheRoundTrip(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	key := Key("openai", "gpt-4o", "", "summarize this")
+	if _, ok := Get(key, time.Hour); ok {
+		t.Fatal("empty cache should miss")
+	}
+	Put(key, "a summary", time.Hour)
+	reply, ok := Get(key, time.Hour)
+	if !ok || reply != "a summary" {
+		t.Fatalf("Get = %q, %v", reply, ok)
+	}
+	if _, ok := Get(key, -time.Second); ok {
+		t.Error("expired entry should miss")
+	}
+}
+
+func TestKeyNormalizesWhitespace(t *testing.T) {
+	a := Key("openai", "gpt-4o", "", "summarize   this\n")
+	b := Key("openai", "gpt-4o", "", " summarize this")
+	if a != b {
+		t.Error("whitespace differences should not change the key")
+	}
+	if a == Key("openai", "gpt-4o", "", "Summarize this") {
+		t.Error("case differences should change the key")
+	}
+	if a == Key("openai", "gpt-4o-mini", "", "summarize this") {
+		t.Error("model differences should change the key")
+	}
+}
+
+func TestSettings(t *testing.T) {
+	if Enabled(nil) || Enabled(map[string]string{SettingEnabled: "false"}) {
+		t.Error("cache should be off by default")
+	}
+	if !Enabled(map[string]string{SettingEnabled: "true"}) {
+		t.Error("cache.responses=true should enable")
+	}
+	if TTL(nil) != 24*time.Hour {
+		t.Errorf("default TTL = %v", TTL(nil))
+	}
+	if TTL(map[string]string{SettingTTL: "30m"}) != 30*time.Minute {
+		t.Error("cache.ttl should override the TTL")
+	}
+	if TTL(map[string]string{SettingTTL: "nope"}) != 24*time.Hour {
+		t.Error("malformed cache.ttl should fall back to the default")
+	}
+}
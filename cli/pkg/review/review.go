@@ -0,0 +1,153 @@
+// Package review implements diff review support: splitting a unified diff
+// into context-window-sized chunks, the structured comment schema requested
+// from the model, and renderers for markdown and GitHub review payloads.
+package review
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/guardrails"
+)
+
+// Chunk is one reviewable slice of a diff, kept within a token budget.
+type Chunk struct {
+	Files []string
+	Diff  string
+}
+
+// Tokens estimates the chunk's input size.
+func (c Chunk) Tokens() int {
+	return guardrails.EstimateTokens(c.Diff)
+}
+
+// SplitDiff packs a unified diff into chunks of at most budgetTokens each,
+// never splitting a single file across chunks. A file larger than the budget
+// gets a chunk of its own and is truncated by the guardrails layer later.
+func SplitDiff(diff string, budgetTokens int) []Chunk {
+	files := splitFiles(diff)
+	var chunks []Chunk
+	var current Chunk
+	for _, f := range files {
+		tokens := guardrails.EstimateTokens(f.diff)
+		if current.Diff != "" && current.Tokens()+tokens > budgetTokens {
+			chunks = append(chunks, current)
+			current = Chunk{}
+		}
+		current.Files = append(current.Files, f.name)
+		current.Diff += f.diff
+		if tokens > budgetTokens {
+			chunks = append(chunks, current)
+			current = Chunk{}
+		}
+	}
+	if current.Diff != "" {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+type fileDiff struct {
+	name string
+	diff string
+}
+
+// splitFiles cuts a unified diff at "diff --git" boundaries.
+func splitFiles(diff string) []fileDiff {
+	var files []fileDiff
+	var current *fileDiff
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			if current != nil {
+				files = append(files, *current)
+			}
+			name := ""
+			if parts := strings.Fields(line); len(parts) >= 4 {
+				name = strings.TrimPrefix(parts[3], "b/")
+			}
+			current = &fileDiff{name: name}
+		}
+		if current != nil {
+			current.diff += line
+		}
+	}
+	if current != nil {
+		files = append(files, *current)
+	}
+	return files
+}
+
+// Comment is one structured review finding.
+type Comment struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Severity string `json:"severity"`
+	Comment  string `json:"comment"`
+}
+
+// Schema is embedded in the review prompt so every provider returns the same
+// shape regardless of native structured-output support.
+const Schema = `[{"file": "path/to/file.go", "line": 42, "severity": "issue|nit|question", "comment": "..."}]`
+
+// ParseComments extracts the JSON comment array from a model reply, tolerating
+// surrounding prose and markdown code fences.
+func ParseComments(reply string) ([]Comment, error) {
+	start := strings.Index(reply, "[")
+	end := strings.LastIndex(reply, "]")
+	if start == -1 || end <= start {
+		return nil, fmt.Errorf("no JSON comment array in reply")
+	}
+	var comments []Comment
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &comments); err != nil {
+		return nil, fmt.Errorf("parsing review comments: %w", err)
+	}
+	return comments, nil
+}
+
+// RenderMarkdown groups comments per file for human reading.
+func RenderMarkdown(comments []Comment) string {
+	if len(comments) == 0 {
+		return "No review comments.\n"
+	}
+	byFile := make(map[string][]Comment)
+	for _, c := range comments {
+		byFile[c.File] = append(byFile[c.File], c)
+	}
+	files := make([]string, 0, len(byFile))
+	for f := range byFile {
+		files = append(files, f)
+	}
+	sort.Strings(files)
+	var b strings.Builder
+	for _, f := range files {
+		fmt.Fprintf(&b, "### %s\n\n", f)
+		for _, c := range byFile[f] {
+			fmt.Fprintf(&b, "- **L%d** (%s): %s\n", c.Line, c.Severity, c.Comment)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// RenderGitHub produces the JSON body for GitHub's create-review API.
+func RenderGitHub(comments []Comment) ([]byte, error) {
+	type ghComment struct {
+		Path string `json:"path"`
+		Line int    `json:"line"`
+		Body string `json:"body"`
+	}
+	payload := struct {
+		Event    string      `json:"event"`
+		Comments []ghComment `json:"comments"`
+	}{Event: "COMMENT"}
+	for _, c := range comments {
+		payload.Comments = append(payload.Comments, ghComment{
+			Path: c.File,
+			Line: c.Line,
+			Body: fmt.Sprintf("[%s] %s", c.Severity, c.Comment),
+		})
+	}
+	return json.MarshalIndent(payload, "", "  ")
+}
@@ -0,0 +1,98 @@
+package review
+
+import (
+	"strings"
+	"testing"
+)
+
+func fileDiffFor(name string, bodyLines int) string {
+	var b strings.Builder
+	b.WriteString("diff --git a/" + name + " b/" + name + "\n")
+	b.WriteString("--- a/" + name + "\n+++ b/" + name + "\n@@ -1,1 +1,1 @@\n")
+	for i := 0; i < bodyLines; i++ {
+		b.WriteString("+some changed line padding the diff out\n")
+	}
+	return b.String()
+}
+
+func TestSplitDiffPacksFiles(t *testing.T) {
+	diff := fileDiffFor("a.go", 10) + fileDiffFor("b.go", 10) + fileDiffFor("c.go", 10)
+	chunks := SplitDiff(diff, 1_000_000)
+	if len(chunks) != 1 {
+		t.Fatalf("chunks = %d", len(chunks))
+	}
+	if got := chunks[0].Files; len(got) != 3 || got[0] != "a.go" || got[2] != "c.go" {
+		t.Errorf("files = %v", got)
+	}
+}
+
+func TestSplitDiffRespectsBudget(t *testing.T) {
+	diff := fileDiffFor("a.go", 40) + fileDiffFor("b.go", 40)
+	one := SplitDiff(fileDiffFor("a.go", 40), 1_000_000)
+	perFile := one[0].Tokens()
+	chunks := SplitDiff(diff, perFile+1)
+	if len(chunks) != 2 {
+		t.Fatalf("chunks = %d, want one per file", len(chunks))
+	}
+	for _, c := range chunks {
+		if len(c.Files) != 1 {
+			t.Errorf("chunk files = %v", c.Files)
+		}
+	}
+}
+
+func TestSplitDiffOversizedFileGetsOwnChunk(t *testing.T) {
+	diff := fileDiffFor("small.go", 2) + fileDiffFor("huge.go", 500) + fileDiffFor("tail.go", 2)
+	chunks := SplitDiff(diff, 100)
+	for _, c := range chunks {
+		for _, f := range c.Files {
+			if f == "huge.go" && len(c.Files) != 1 {
+				t.Errorf("oversized file shares a chunk: %v", c.Files)
+			}
+		}
+	}
+}
+
+func TestParseCommentsToleratesFences(t *testing.T) {
+	reply := "Here are my findings:\n```json\n" +
+		`[{"file": "a.go", "line": 3, "severity": "issue", "comment": "nil deref"}]` +
+		"\n```\nLet me know!"
+	comments, err := ParseComments(reply)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0].File != "a.go" || comments[0].Line != 3 {
+		t.Errorf("comments = %+v", comments)
+	}
+}
+
+func TestParseCommentsRejectsProse(t *testing.T) {
+	if _, err := ParseComments("Everything looks great to me!"); err == nil {
+		t.Error("prose reply accepted")
+	}
+}
+
+func TestRenderMarkdownGroupsByFile(t *testing.T) {
+	out := RenderMarkdown([]Comment{
+		{File: "b.go", Line: 1, Severity: "nit", Comment: "rename"},
+		{File: "a.go", Line: 9, Severity: "issue", Comment: "leak"},
+	})
+	if !strings.Contains(out, "### a.go") || !strings.Contains(out, "**L9** (issue): leak") {
+		t.Errorf("markdown = %q", out)
+	}
+	if strings.Index(out, "### a.go") > strings.Index(out, "### b.go") {
+		t.Error("files not sorted")
+	}
+}
+
+func TestRenderGitHubPayload(t *testing.T) {
+	payload, err := RenderGitHub([]Comment{{File: "a.go", Line: 2, Severity: "issue", Comment: "bug"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"event": "COMMENT"`, `"path": "a.go"`, `"[issue] bug"`} {
+		if !strings.Contains(string(payload), want) {
+			t.Errorf("payload missing %s: %s", want, payload)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Package schema implements the small JSON Schema subset the CLI validates
+// structured model output against: types, object properties and required
+// lists, array items, and enums. That covers what scripts actually pin down
+// without pulling in a full draft implementation.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Schema is one parsed (sub)schema.
+type Schema struct {
+	Type       string             `json:"type,omitempty"`
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	Required   []string           `json:"required,omitempty"`
+	Items      *Schema            `json:"items,omitempty"`
+	Enum       []any              `json:"enum,omitempty"`
+}
+
+// Parse reads a schema document.
+func Parse(data []byte) (*Schema, error) {
+	var s Schema
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing schema: %w", err)
+	}
+	return &s, nil
+}
+
+// Validate checks a decoded JSON value and returns human-readable problems;
+// empty means the value conforms.
+func (s *Schema) Validate(value any) []string {
+	return s.validate(value, "$")
+}
+
+func (s *Schema) validate(value any, path string) []string {
+	var problems []string
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			if equalJSON(value, allowed) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			problems = append(problems, fmt.Sprintf("%s: value not in enum", path))
+		}
+	}
+	if s.Type != "" && !typeMatches(s.Type, value) {
+		return append(problems, fmt.Sprintf("%s: expected %s", path, s.Type))
+	}
+	switch v := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				problems = append(problems, fmt.Sprintf("%s: missing required property %q", path, name))
+			}
+		}
+		for name, sub := range s.Properties {
+			if child, ok := v[name]; ok {
+				problems = append(problems, sub.validate(child, path+"."+name)...)
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				problems = append(problems, s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i))...)
+			}
+		}
+	}
+	return problems
+}
+
+// typeMatches maps JSON Schema type names onto decoded Go values.
+func typeMatches(typ string, value any) bool {
+	switch typ {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		f, ok := value.(float64)
+		return ok && f == math.Trunc(f)
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+// equalJSON compares decoded JSON values structurally.
+func equalJSON(a, b any) bool {
+	aj, err1 := json.Marshal(a)
+	bj, err2 := json.Marshal(b)
+	return err1 == nil && err2 == nil && string(aj) == string(bj)
+}
+
+// ExtractJSON pulls the first JSON document out of a model reply, tolerating
+// surrounding prose and markdown code fences.
+func ExtractJSON(reply string) (string, error) {
+	objStart := strings.Index(reply, "{")
+	arrStart := strings.Index(reply, "[")
+	start := objStart
+	end := strings.LastIndex(reply, "}")
+	if start == -1 || (arrStart != -1 && arrStart < objStart) {
+		start = arrStart
+		end = strings.LastIndex(reply, "]")
+	}
+	if start == -1 || end <= start {
+		return "", fmt.Errorf("no JSON document in reply")
+	}
+	doc := reply[start : end+1]
+	if !json.Valid([]byte(doc)) {
+		return "", fmt.Errorf("reply contains malformed JSON")
+	}
+	return doc, nil
+}
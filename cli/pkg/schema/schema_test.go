@@ -0,0 +1,80 @@
+package schema
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const personSchema = `{
+  "type": "object",
+  "required": ["name", "age"],
+  "properties": {
+    "name": {"type": "string"},
+    "age": {"type": "integer"},
+    "role": {"enum": ["admin", "user"]},
+    "tags": {"type": "array", "items": {"type": "string"}}
+  }
+}`
+
+func validateDoc(t *testing.T, doc string) []string {
+	t.Helper()
+	s, err := Parse([]byte(personSchema))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var value any
+	if err := json.Unmarshal([]byte(doc), &value); err != nil {
+		t.Fatal(err)
+	}
+	return s.Validate(value)
+}
+
+func TestValidateConformingDocument(t *testing.T) {
+	problems := validateDoc(t, `{"name": "Ada", "age": 36, "role": "admin", "tags": ["x"]}`)
+	if len(problems) != 0 {
+		t.Errorf("problems = %v", problems)
+	}
+}
+
+func TestValidateReportsProblems(t *testing.T) {
+	problems := validateDoc(t, `{"name": 7, "role": "root", "tags": ["ok", 5]}`)
+	want := map[string]bool{
+		"$.name: expected string":            false,
+		`$: missing required property "age"`: false,
+		"$.role: value not in enum":          false,
+		"$.tags[1]: expected string":         false,
+	}
+	for _, p := range problems {
+		if _, ok := want[p]; ok {
+			want[p] = true
+		}
+	}
+	for msg, seen := range want {
+		if !seen {
+			t.Errorf("missing problem %q in %v", msg, problems)
+		}
+	}
+}
+
+func TestValidateIntegerVsNumber(t *testing.T) {
+	if problems := validateDoc(t, `{"name": "x", "age": 3.5}`); len(problems) != 1 {
+		t.Errorf("problems = %v", problems)
+	}
+}
+
+func TestExtractJSON(t *testing.T) {
+	doc, err := ExtractJSON("Sure! Here you go:\n```json\n{\"ok\": true}\n```")
+	if err != nil || doc != `{"ok": true}` {
+		t.Errorf("doc = %q, err = %v", doc, err)
+	}
+	if _, err := ExtractJSON("no json here"); err == nil {
+		t.Error("prose accepted")
+	}
+	if _, err := ExtractJSON(`{"broken": `); err == nil {
+		t.Error("malformed JSON accepted")
+	}
+	doc, err = ExtractJSON(`[1, 2, 3]`)
+	if err != nil || doc != `[1, 2, 3]` {
+		t.Errorf("array doc = %q, err = %v", doc, err)
+	}
+}
@@ -0,0 +1,119 @@
+// Package secrets scans local shell artifacts (history files) for configured
+// API keys that were passed on a command line. Matching is done on SHA-256
+// token hashes so plaintext keys never appear in findings or output.
+package secrets
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+)
+
+// minTokenLen skips short tokens that could never be an API key, keeping the
+// hash comparisons cheap on large history files.
+const minTokenLen = 8
+
+// Leak is one history line that contains a configured key. HashPrefix
+// identifies the key without revealing it.
+type Leak struct {
+	Provider   string
+	File       string
+	Line       int
+	HashPrefix string
+}
+
+// HistoryFiles returns the shell history paths to scan under home, existing
+// ones only.
+func HistoryFiles(home string) []string {
+	candidates := []string{
+		filepath.Join(home, ".bash_history"),
+		filepath.Join(home, ".zsh_history"),
+		filepath.Join(home, ".histfile"),
+		filepath.Join(home, ".local", "share", "fish", "fish_history"),
+	}
+	var files []string
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			files = append(files, path)
+		}
+	}
+	return files
+}
+
+// Scan reads each file line by line and reports lines containing any of the
+// given keys, which map provider ID to key material. Unreadable files are
+// skipped: history is best-effort evidence, not a hard requirement.
+func Scan(files []string, keys map[string]string) []Leak {
+	hashes := make(map[string]string, len(keys)) // full hash -> provider
+	for provider, key := range keys {
+		if len(key) >= minTokenLen {
+			hashes[hashToken(key)] = provider
+		}
+	}
+	var leaks []Leak
+	for _, file := range files {
+		f, err := os.Open(file)
+		if err != nil {
+			continue
+		}
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+		for lineNo := 1; scanner.Scan(); lineNo++ {
+			for _, token := range tokenize(scanner.Text()) {
+				hash := hashToken(token)
+				if provider, ok := hashes[hash]; ok {
+					leaks = append(leaks, Leak{
+						Provider:   provider,
+						File:       file,
+						Line:       lineNo,
+						HashPrefix: hash[:8],
+					})
+					break
+				}
+			}
+		}
+		f.Close()
+	}
+	return leaks
+}
+
+// tokenize splits a command line into key-shaped tokens: maximal runs of the
+// characters API keys are made of. This catches both bare arguments and
+// --flag=value forms.
+func tokenize(line string) []string {
+	var tokens []string
+	start := -1
+	for i, r := range line {
+		if isKeyChar(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+		if start >= 0 && i-start >= minTokenLen {
+			tokens = append(tokens, line[start:i])
+		}
+		start = -1
+	}
+	if start >= 0 && len(line)-start >= minTokenLen {
+		tokens = append(tokens, line[start:])
+	}
+	return tokens
+}
+
+func isKeyChar(r rune) bool {
+	switch {
+	case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		return true
+	case r == '-', r == '_', r == '.':
+		return true
+	}
+	return false
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,130 @@
+// Package sharebox seals small documents to a recipient's public key, so a
+// provider setup can travel over chat or email without exposing credentials.
+// It is a sealed box over X25519 and AES-256-GCM: an ephemeral key pair is
+// generated per message, so only the recipient's private key can open it and
+// the sender keeps nothing to protect afterwards.
+package sharebox
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+)
+
+// magic versions the blob format, like cryptobox's header.
+const magic = "sbox1"
+
+const (
+	keySize   = 32
+	nonceSize = 12
+)
+
+// GenerateIdentity returns a new X25519 key pair, base64-encoded. The
+// public key is what teammates pass to `cline config share --to`.
+func GenerateIdentity() (publicKey, privateKey string, err error) {
+	priv, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	return base64.StdEncoding.EncodeToString(priv.PublicKey().Bytes()),
+		base64.StdEncoding.EncodeToString(priv.Bytes()), nil
+}
+
+// PublicKeyOf re-derives the public half of a stored private key.
+func PublicKeyOf(privateKey string) (string, error) {
+	privBytes, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil || len(privBytes) != keySize {
+		return "", fmt.Errorf("malformed private key")
+	}
+	priv, err := ecdh.X25519().NewPrivateKey(privBytes)
+	if err != nil {
+		return "", fmt.Errorf("malformed private key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(priv.PublicKey().Bytes()), nil
+}
+
+// Seal encrypts plaintext so only the holder of recipientPublicKey can read
+// it. The blob is magic || ephemeral public key || nonce || ciphertext.
+func Seal(recipientPublicKey string, plaintext []byte) ([]byte, error) {
+	pubBytes, err := base64.StdEncoding.DecodeString(recipientPublicKey)
+	if err != nil || len(pubBytes) != keySize {
+		return nil, fmt.Errorf("malformed recipient public key")
+	}
+	recipient, err := ecdh.X25519().NewPublicKey(pubBytes)
+	if err != nil {
+		return nil, fmt.Errorf("malformed recipient public key: %w", err)
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := aeadFor(ephemeral, recipient, ephemeral.PublicKey().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, nonceSize)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	blob := make([]byte, 0, len(magic)+keySize+nonceSize+len(plaintext)+aead.Overhead())
+	blob = append(blob, magic...)
+	blob = append(blob, ephemeral.PublicKey().Bytes()...)
+	blob = append(blob, nonce...)
+	return aead.Seal(blob, nonce, plaintext, aad(ephemeral.PublicKey().Bytes())), nil
+}
+
+// Open decrypts a sealed blob with the recipient's private key.
+func Open(privateKey string, blob []byte) ([]byte, error) {
+	privBytes, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil || len(privBytes) != keySize {
+		return nil, fmt.Errorf("malformed private key")
+	}
+	priv, err := ecdh.X25519().NewPrivateKey(privBytes)
+	if err != nil {
+		return nil, fmt.Errorf("malformed private key: %w", err)
+	}
+	if len(blob) < len(magic)+keySize+nonceSize || string(blob[:len(magic)]) != magic {
+		return nil, fmt.Errorf("not a shared config blob")
+	}
+	rest := blob[len(magic):]
+	ephemeralPub, err := ecdh.X25519().NewPublicKey(rest[:keySize])
+	if err != nil {
+		return nil, fmt.Errorf("corrupted blob: %w", err)
+	}
+	nonce := rest[keySize : keySize+nonceSize]
+	aead, err := aeadFor(priv, ephemeralPub, ephemeralPub.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, rest[keySize+nonceSize:], aad(ephemeralPub.Bytes()))
+	if err != nil {
+		return nil, fmt.Errorf("cannot decrypt: wrong recipient key or corrupted blob")
+	}
+	return plaintext, nil
+}
+
+// aeadFor derives the AES-256-GCM cipher from the X25519 shared secret,
+// bound to the ephemeral public key so a swapped key fails authentication.
+func aeadFor(priv *ecdh.PrivateKey, pub *ecdh.PublicKey, ephemeralPub []byte) (cipher.AEAD, error) {
+	shared, err := priv.ECDH(pub)
+	if err != nil {
+		return nil, err
+	}
+	h := sha256.New()
+	h.Write([]byte(magic))
+	h.Write(shared)
+	h.Write(ephemeralPub)
+	block, err := aes.NewCipher(h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func aad(ephemeralPub []byte) []byte {
+	return append([]byte(magic), ephemeralPub...)
+}
@@ -0,0 +1,70 @@
+package sharebox
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestSealAndOpen(t *testing.T) {
+	pub, priv, err := GenerateIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	plaintext := []byte(`{"providers":{"openai":{"apiKey":"sk-secret"}}}`)
+	blob, err := Seal(pub, plaintext)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(blob, []byte("sk-secret")) {
+		t.Fatal("plaintext visible in sealed blob")
+	}
+	opened, err := Open(priv, blob)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(opened, plaintext) {
+		t.Errorf("round trip mismatch: %q", opened)
+	}
+}
+
+func TestOpenRejectsWrongKey(t *testing.T) {
+	pub, _, err := GenerateIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, otherPriv, err := GenerateIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := Seal(pub, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open(otherPriv, blob); err == nil {
+		t.Error("opened with the wrong private key")
+	}
+}
+
+func TestOpenRejectsTampering(t *testing.T) {
+	pub, priv, err := GenerateIdentity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := Seal(pub, []byte("hello"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob[len(blob)-1] ^= 0x01
+	if _, err := Open(priv, blob); err == nil {
+		t.Error("opened a tampered blob")
+	}
+	if _, err := Open(priv, []byte("garbage")); err == nil {
+		t.Error("opened garbage")
+	}
+}
+
+func TestSealRejectsMalformedKey(t *testing.T) {
+	if _, err := Seal("not-base64!!", []byte("hi")); err == nil {
+		t.Error("sealed to a malformed key")
+	}
+}
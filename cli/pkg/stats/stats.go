@@ -0,0 +1,143 @@
+// Package stats persists per-provider latency and error samples so the CLI
+// can report historical percentiles. Samples are appended to a JSONL file
+// under ~/.cline; recording is best-effort and must never fail a request.
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// Sample is one observed request.
+type Sample struct {
+	Time     time.Time `json:"time"`
+	Provider string    `json:"provider"`
+	Seconds  float64   `json:"seconds"`
+	Error    bool      `json:"error,omitempty"`
+}
+
+// filePath returns the samples file location.
+func filePath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "stats.jsonl"), nil
+}
+
+// Record appends one sample. Errors are swallowed: statistics are advisory
+// and must not interfere with the request path.
+func Record(provider string, seconds float64, errored bool) {
+	path, err := filePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	data, err := json.Marshal(Sample{Time: time.Now().UTC(), Provider: provider, Seconds: seconds, Error: errored})
+	if err != nil {
+		return
+	}
+	f.Write(append(data, '\n'))
+}
+
+// Load returns all samples recorded at or after since. Malformed lines (from
+// interrupted writes) are skipped.
+func Load(since time.Time) ([]Sample, error) {
+	path, err := filePath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var samples []Sample
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var s Sample
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			continue
+		}
+		if !s.Time.Before(since) {
+			samples = append(samples, s)
+		}
+	}
+	return samples, scanner.Err()
+}
+
+// ProviderStats summarizes one provider's samples.
+type ProviderStats struct {
+	Provider  string
+	Count     int
+	ErrorRate float64
+	P50       float64
+	P95       float64
+}
+
+// Summarize groups samples per provider and computes percentiles over the
+// successful requests, sorted by provider ID.
+func Summarize(samples []Sample) []ProviderStats {
+	byProvider := make(map[string][]Sample)
+	for _, s := range samples {
+		byProvider[s.Provider] = append(byProvider[s.Provider], s)
+	}
+	ids := make([]string, 0, len(byProvider))
+	for id := range byProvider {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	out := make([]ProviderStats, 0, len(ids))
+	for _, id := range ids {
+		group := byProvider[id]
+		var latencies []float64
+		errors := 0
+		for _, s := range group {
+			if s.Error {
+				errors++
+				continue
+			}
+			latencies = append(latencies, s.Seconds)
+		}
+		sort.Float64s(latencies)
+		out = append(out, ProviderStats{
+			Provider:  id,
+			Count:     len(group),
+			ErrorRate: float64(errors) / float64(len(group)),
+			P50:       percentile(latencies, 0.50),
+			P95:       percentile(latencies, 0.95),
+		})
+	}
+	return out
+}
+
+// percentile uses nearest-rank on a sorted slice.
+func percentile(sorted []float64, p float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,54 @@
+package stats
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordAndLoad(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	Record("openai", 0.8, false)
+	Record("openai", 1.2, false)
+	Record("anthropic", 0.5, true)
+
+	samples, err := Load(time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(samples) != 3 {
+		t.Fatalf("samples = %+v", samples)
+	}
+	if none, err := Load(time.Now().Add(time.Hour)); err != nil || len(none) != 0 {
+		t.Errorf("future window returned %+v, %v", none, err)
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	var samples []Sample
+	for i := 1; i <= 100; i++ {
+		samples = append(samples, Sample{Provider: "openai", Seconds: float64(i) / 100})
+	}
+	samples = append(samples,
+		Sample{Provider: "anthropic", Seconds: 0.4},
+		Sample{Provider: "anthropic", Error: true},
+	)
+	stats := Summarize(samples)
+	if len(stats) != 2 || stats[0].Provider != "anthropic" {
+		t.Fatalf("stats = %+v", stats)
+	}
+	if stats[0].ErrorRate != 0.5 || stats[0].P50 != 0.4 {
+		t.Errorf("anthropic = %+v", stats[0])
+	}
+	openai := stats[1]
+	if openai.Count != 100 || openai.P50 != 0.5 || openai.P95 != 0.95 {
+		t.Errorf("openai = %+v", openai)
+	}
+}
+
+func TestLoadMissingFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	samples, err := Load(time.Time{})
+	if err != nil || samples != nil {
+		t.Errorf("samples = %v, err = %v", samples, err)
+	}
+}
@@ -0,0 +1,89 @@
+package task
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"strings"
+)
+
+// Export renders the task in the requested format: json, markdown or html.
+func Export(t *Task, format string) ([]byte, error) {
+	switch format {
+	case "json":
+		return json.MarshalIndent(t, "", "  ")
+	case "markdown":
+		return exportMarkdown(t), nil
+	case "html":
+		return exportHTML(t)
+	}
+	return nil, fmt.Errorf("unsupported export format %q (json, markdown, html)", format)
+}
+
+func exportMarkdown(t *Task) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Task %s\n\n", t.ID)
+	fmt.Fprintf(&b, "- Started: %s\n", t.StartedAt.Format("2006-01-02 15:04:05 MST"))
+	fmt.Fprintf(&b, "- Provider: %s\n", t.Provider)
+	if t.ModelID != "" {
+		fmt.Fprintf(&b, "- Model: %s\n", t.ModelID)
+	}
+	fmt.Fprintf(&b, "- Total cost: $%.4f\n\n", t.CostUSD())
+	for _, e := range t.Events {
+		switch e.Role {
+		case "tool":
+			fmt.Fprintf(&b, "## Tool: %s\n\n```\n%s\n```\n\n", e.Tool, e.Content)
+		default:
+			fmt.Fprintf(&b, "## %s\n\n%s\n\n", capitalize(e.Role), e.Content)
+		}
+		if e.CostUSD > 0 {
+			fmt.Fprintf(&b, "_cost: $%.4f_\n\n", e.CostUSD)
+		}
+	}
+	return []byte(b.String())
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
+
+var htmlTemplate = template.Must(template.New("task").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Task {{.Task.ID}}</title>
+<style>
+body { font-family: sans-serif; max-width: 52rem; margin: 2rem auto; }
+.event { border: 1px solid #ddd; border-radius: 6px; padding: 0.75rem 1rem; margin: 1rem 0; }
+.role { font-weight: bold; text-transform: capitalize; }
+.cost { color: #666; font-size: 0.85rem; }
+pre { white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<h1>Task {{.Task.ID}}</h1>
+<p>Started {{.Task.StartedAt.Format "2006-01-02 15:04:05 MST"}} &middot; {{.Task.Provider}}{{if .Task.ModelID}} / {{.Task.ModelID}}{{end}} &middot; total ${{printf "%.4f" .Cost}}</p>
+{{range .Task.Events}}<div class="event">
+<div class="role">{{if eq .Role "tool"}}Tool: {{.Tool}}{{else}}{{.Role}}{{end}}</div>
+<pre>{{.Content}}</pre>
+{{if .CostUSD}}<div class="cost">${{printf "%.4f" .CostUSD}}</div>{{end}}
+</div>
+{{end}}</body>
+</html>
+`))
+
+func exportHTML(t *Task) ([]byte, error) {
+	var buf bytes.Buffer
+	err := htmlTemplate.Execute(&buf, struct {
+		Task *Task
+		Cost float64
+	}{t, t.CostUSD()})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func sampleTask() *Task {
+	return &Task{
+		ID:        "20260829-120000-abcd1234",
+		StartedAt: time.Date(2026, 8, 29, 12, 0, 0, 0, time.UTC),
+		Provider:  "openai",
+		ModelID:   "gpt-4.1",
+		Events: []Event{
+			{Role: "user", Content: "rename the helper"},
+			{Role: "tool", Tool: "apply_diff", Content: "--- a/x.go\n+++ b/x.go"},
+			{Role: "assistant", Content: "Done.", CostUSD: 0.0123},
+		},
+	}
+}
+
+func TestExportMarkdown(t *testing.T) {
+	out, err := Export(sampleTask(), "markdown")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := string(out)
+	for _, want := range []string{"# Task 20260829", "gpt-4.1", "Tool: apply_diff", "$0.0123", "Total cost: $0.0123"} {
+		if !strings.Contains(s, want) {
+			t.Errorf("markdown missing %q:\n%s", want, s)
+		}
+	}
+}
+
+func TestExportJSONRoundTrips(t *testing.T) {
+	out, err := Export(sampleTask(), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Task
+	if err := json.Unmarshal(out, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.ID != sampleTask().ID || len(decoded.Events) != 3 {
+		t.Errorf("decoded = %+v", decoded)
+	}
+}
+
+func TestExportHTMLEscapes(t *testing.T) {
+	tk := sampleTask()
+	tk.Events[0].Content = "<script>alert(1)</script>"
+	out, err := Export(tk, "html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(out), "<script>alert") {
+		t.Error("HTML export did not escape content")
+	}
+	if !strings.Contains(string(out), "apply_diff") {
+		t.Errorf("html missing tool call:\n%s", out)
+	}
+}
+
+func TestExportUnknownFormat(t *testing.T) {
+	if _, err := Export(sampleTask(), "pdf"); err == nil {
+		t.Error("unknown format accepted")
+	}
+}
+
+func TestSaveLoadList(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	tk := sampleTask()
+	if err := Save(tk); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(tk.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Provider != "openai" || len(loaded.Events) != 3 {
+		t.Errorf("loaded = %+v", loaded)
+	}
+	ids, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 1 || ids[0] != tk.ID {
+		t.Errorf("ids = %v", ids)
+	}
+}
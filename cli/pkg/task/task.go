@@ -0,0 +1,157 @@
+// Package task persists conversation transcripts under ~/.cline/tasks so that
+// sessions can be audited and exported after the fact. Each task is one JSON
+// file named by its ID.
+package task
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// Event is one step in a task: a user prompt, an assistant reply, or a tool
+// invocation.
+type Event struct {
+	Time    time.Time `json:"time"`
+	Role    string    `json:"role"` // user, assistant, tool
+	Content string    `json:"content,omitempty"`
+	// Tool names the tool for role "tool" events.
+	Tool    string  `json:"tool,omitempty"`
+	CostUSD float64 `json:"costUsd,omitempty"`
+}
+
+// Task statuses. Tasks recorded after the fact (completed asks) carry
+// StatusCompleted; queued work moves pending -> running -> completed/failed,
+// or to cancelled.
+const (
+	StatusPending   = "pending"
+	StatusRunning   = "running"
+	StatusCompleted = "completed"
+	StatusFailed    = "failed"
+	StatusCancelled = "cancelled"
+)
+
+// Task is one recorded session.
+type Task struct {
+	ID        string    `json:"id"`
+	StartedAt time.Time `json:"startedAt"`
+	Provider  string    `json:"provider"`
+	ModelID   string    `json:"modelId,omitempty"`
+	// Status is empty for legacy records, which are treated as completed.
+	Status string `json:"status,omitempty"`
+	// Instructions is the system prompt resolved when the task was created.
+	Instructions string  `json:"instructions,omitempty"`
+	Events       []Event `json:"events"`
+}
+
+// Active reports whether the task is still in the queue (pending or running).
+func (t *Task) Active() bool {
+	return t.Status == StatusPending || t.Status == StatusRunning
+}
+
+// CostUSD totals the cost of all events.
+func (t *Task) CostUSD() float64 {
+	var total float64
+	for _, e := range t.Events {
+		total += e.CostUSD
+	}
+	return total
+}
+
+// NewID returns a sortable unique task ID: a UTC timestamp plus random suffix.
+func NewID() string {
+	var b [4]byte
+	rand.Read(b[:])
+	return time.Now().UTC().Format("20060102-150405") + "-" + hex.EncodeToString(b[:])
+}
+
+// Dir returns the task storage directory.
+func Dir() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tasks"), nil
+}
+
+// Save writes the task to its file, creating the directory if needed.
+func Save(t *Task) error {
+	dir, err := Dir()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, t.ID+".json"), data, 0o600)
+}
+
+// Load reads one task by ID.
+func Load(id string) (*Task, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(dir, id+".json"))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("no task %q; see `cline task list`", id)
+		}
+		return nil, err
+	}
+	t := &Task{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Queue returns all pending and running tasks, oldest first, so the queue
+// view and the runner see work in submission order across CLI instances.
+func Queue() ([]*Task, error) {
+	ids, err := List()
+	if err != nil {
+		return nil, err
+	}
+	var queued []*Task
+	for i := len(ids) - 1; i >= 0; i-- {
+		t, err := Load(ids[i])
+		if err != nil {
+			continue
+		}
+		if t.Active() {
+			queued = append(queued, t)
+		}
+	}
+	return queued, nil
+}
+
+// List returns all stored task IDs, newest first.
+func List() ([]string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	names, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, 0, len(names))
+	for _, name := range names {
+		ids = append(ids, strings.TrimSuffix(filepath.Base(name), ".json"))
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(ids)))
+	return ids, nil
+}
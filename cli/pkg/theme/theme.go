@@ -0,0 +1,71 @@
+// Package theme centralizes terminal coloring so every surface colors the
+// same things the same way, and so NO_COLOR and CLICOLOR_FORCE are honored
+// in exactly one place.
+package theme
+
+import "os"
+
+// SettingTheme is the settings key selecting a theme by name: "default",
+// "dark", "light", or "none".
+const SettingTheme = "theme"
+
+// Theme holds the SGR codes for the CLI's few semantic roles. Empty codes
+// render text unchanged, which is how the "none" theme disables color.
+type Theme struct {
+	success  string
+	failure  string
+	emphasis string
+	dim      string
+}
+
+var themes = map[string]*Theme{
+	"default": {success: "32", failure: "1;31", emphasis: "1", dim: "2"},
+	"dark":    {success: "92", failure: "1;91", emphasis: "1;97", dim: "90"},
+	"light":   {success: "32", failure: "31", emphasis: "34", dim: "2"},
+	"none":    {},
+}
+
+// FromSettings resolves the active theme. NO_COLOR always wins, then an
+// explicit "none" setting; a non-terminal stdout disables color unless
+// CLICOLOR_FORCE asks for it anyway.
+func FromSettings(settings map[string]string, isTerminal bool) *Theme {
+	if os.Getenv("NO_COLOR") != "" {
+		return themes["none"]
+	}
+	name := settings[SettingTheme]
+	if name == "none" {
+		return themes["none"]
+	}
+	if force := os.Getenv("CLICOLOR_FORCE"); !isTerminal && (force == "" || force == "0") {
+		return themes["none"]
+	}
+	if t, ok := themes[name]; ok {
+		return t
+	}
+	return themes["default"]
+}
+
+// None returns the colorless theme, the safe choice when no settings are at
+// hand.
+func None() *Theme {
+	return themes["none"]
+}
+
+func (t *Theme) paint(code, s string) string {
+	if code == "" {
+		return s
+	}
+	return "\033[" + code + "m" + s + "\033[0m"
+}
+
+// Success colors text reporting that something passed or worked.
+func (t *Theme) Success(s string) string { return t.paint(t.success, s) }
+
+// Failure colors text reporting that something failed.
+func (t *Theme) Failure(s string) string { return t.paint(t.failure, s) }
+
+// Emphasis colors headings and other text that should stand out.
+func (t *Theme) Emphasis(s string) string { return t.paint(t.emphasis, s) }
+
+// Dim colors secondary detail.
+func (t *Theme) Dim(s string) string { return t.paint(t.dim, s) }
@@ -0,0 +1,43 @@
+package theme
+
+import "testing"
+
+func TestFromSettings(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("CLICOLOR_FORCE", "")
+
+	if got := FromSettings(nil, true); got != themes["default"] {
+		t.Errorf("terminal default = %+v", got)
+	}
+	if got := FromSettings(map[string]string{SettingTheme: "dark"}, true); got != themes["dark"] {
+		t.Errorf("dark = %+v", got)
+	}
+	if got := FromSettings(map[string]string{SettingTheme: "unheard-of"}, true); got != themes["default"] {
+		t.Errorf("unknown theme = %+v", got)
+	}
+	if got := FromSettings(nil, false); got != themes["none"] {
+		t.Errorf("non-terminal = %+v", got)
+	}
+	if got := FromSettings(map[string]string{SettingTheme: "none"}, true); got != themes["none"] {
+		t.Errorf("none setting = %+v", got)
+	}
+
+	t.Setenv("CLICOLOR_FORCE", "1")
+	if got := FromSettings(nil, false); got != themes["default"] {
+		t.Errorf("forced color = %+v", got)
+	}
+
+	t.Setenv("NO_COLOR", "1")
+	if got := FromSettings(map[string]string{SettingTheme: "dark"}, true); got != themes["none"] {
+		t.Errorf("NO_COLOR = %+v", got)
+	}
+}
+
+func TestPaint(t *testing.T) {
+	if got := themes["default"].Success("ok"); got != "\033[32mok\033[0m" {
+		t.Errorf("colored = %q", got)
+	}
+	if got := None().Failure("FAIL"); got != "FAIL" {
+		t.Errorf("none theme = %q", got)
+	}
+}
@@ -0,0 +1,124 @@
+// Package tools loads user-defined tool definitions for `cline ask --tools`
+// and executes them as local commands when the model calls them. The
+// definitions file is a JSON array; each entry names the tool, describes it
+// for the model, and gives the shell command to run.
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// runTimeout bounds one tool invocation.
+const runTimeout = 60 * time.Second
+
+// maxOutputBytes caps what one tool run feeds back into the conversation.
+const maxOutputBytes = 16 * 1024
+
+// Definition is one entry in a tools.json file.
+type Definition struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Command is run through the shell. The model's arguments arrive as JSON
+	// on stdin and scalar arguments are additionally exported as
+	// CLINE_ARG_<NAME> environment variables.
+	Command string `json:"command"`
+	// Parameters is the JSON schema for the tool's arguments, passed to the
+	// provider verbatim. Empty means the tool takes no arguments.
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+	// AutoApprove runs the tool without asking. Leave false for anything that
+	// writes or costs money; the approvals policy can still auto-approve
+	// read-only commands.
+	AutoApprove bool `json:"autoApprove,omitempty"`
+}
+
+// Load reads and validates a tool definitions file.
+func Load(path string) ([]Definition, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var defs []Definition
+	if err := json.Unmarshal(data, &defs); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if len(defs) == 0 {
+		return nil, fmt.Errorf("%s defines no tools", path)
+	}
+	seen := make(map[string]bool)
+	for i, def := range defs {
+		if def.Name == "" {
+			return nil, fmt.Errorf("%s: tool %d has no name", path, i+1)
+		}
+		if def.Command == "" {
+			return nil, fmt.Errorf("%s: tool %q has no command", path, def.Name)
+		}
+		if seen[def.Name] {
+			return nil, fmt.Errorf("%s: duplicate tool %q", path, def.Name)
+		}
+		seen[def.Name] = true
+	}
+	return defs, nil
+}
+
+// Run executes a tool with the model's JSON arguments and returns the output
+// the model should see. Failures are returned as text too, so the model can
+// react instead of the whole ask aborting.
+func Run(ctx context.Context, def Definition, argsJSON string) string {
+	ctx, cancel := context.WithTimeout(ctx, runTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "sh", "-c", def.Command)
+	cmd.Stdin = strings.NewReader(argsJSON)
+	cmd.Env = append(os.Environ(), argEnv(argsJSON)...)
+	out, err := cmd.CombinedOutput()
+	if len(out) > maxOutputBytes {
+		out = append(out[:maxOutputBytes], []byte("\n[output truncated]")...)
+	}
+	if err != nil {
+		return fmt.Sprintf("%stool failed: %v", ensureNewline(string(out)), err)
+	}
+	return string(out)
+}
+
+// argEnv exports scalar arguments as CLINE_ARG_<NAME> variables so simple
+// commands can avoid parsing JSON.
+func argEnv(argsJSON string) []string {
+	var args map[string]any
+	if json.Unmarshal([]byte(argsJSON), &args) != nil {
+		return nil
+	}
+	var env []string
+	for name, value := range args {
+		switch v := value.(type) {
+		case string:
+			env = append(env, envVar(name, v))
+		case float64:
+			env = append(env, envVar(name, strings.TrimSuffix(fmt.Sprintf("%f", v), ".000000")))
+		case bool:
+			env = append(env, envVar(name, fmt.Sprintf("%t", v)))
+		}
+	}
+	return env
+}
+
+func envVar(name, value string) string {
+	clean := strings.Map(func(r rune) rune {
+		if r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(name))
+	return "CLINE_ARG_" + clean + "=" + value
+}
+
+func ensureNewline(s string) string {
+	if s == "" || strings.HasSuffix(s, "\n") {
+		return s
+	}
+	return s + "\n"
+}
@@ -0,0 +1,53 @@
+package tools
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeTools(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tools.json")
+	if err := os.WriteFile(path, []byte(body), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadValidates(t *testing.T) {
+	defs, err := Load(writeTools(t, `[{"name": "date", "description": "today", "command": "date +%F"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(defs) != 1 || defs[0].Name != "date" {
+		t.Errorf("defs = %+v", defs)
+	}
+	for _, bad := range []string{
+		`[]`,
+		`[{"name": "", "command": "true"}]`,
+		`[{"name": "x", "command": ""}]`,
+		`[{"name": "x", "command": "true"}, {"name": "x", "command": "true"}]`,
+	} {
+		if _, err := Load(writeTools(t, bad)); err == nil {
+			t.Errorf("accepted %s", bad)
+		}
+	}
+}
+
+func TestRunPassesArguments(t *testing.T) {
+	def := Definition{Name: "echo", Command: `printf '%s|' "$CLINE_ARG_WHO"; cat`}
+	out := Run(context.Background(), def, `{"who": "ada"}`)
+	if out != `ada|{"who": "ada"}` {
+		t.Errorf("out = %q", out)
+	}
+}
+
+func TestRunReportsFailure(t *testing.T) {
+	out := Run(context.Background(), Definition{Name: "boom", Command: "echo oops >&2; exit 3"}, "{}")
+	if !strings.Contains(out, "oops") || !strings.Contains(out, "tool failed") {
+		t.Errorf("out = %q", out)
+	}
+}
@@ -0,0 +1,189 @@
+// Package transcript records sanitized HTTP request/response pairs to disk and
+// replays them later. Recording is opt-in via `--record <dir>`; replay backs
+// tests so provider integration bugs reproduce without network access. Secret
+// headers and query parameters are redacted before anything touches disk.
+package transcript
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Entry is one recorded exchange as stored on disk.
+type Entry struct {
+	Request struct {
+		Method string              `json:"method"`
+		URL    string              `json:"url"`
+		Header map[string][]string `json:"header,omitempty"`
+		Body   string              `json:"body,omitempty"`
+	} `json:"request"`
+	Response struct {
+		Status int                 `json:"status"`
+		Header map[string][]string `json:"header,omitempty"`
+		Body   string              `json:"body,omitempty"`
+	} `json:"response"`
+}
+
+// secretHeaders are replaced wholesale in transcripts.
+var secretHeaders = []string{"Authorization", "X-Api-Key", "Api-Key", "X-Goog-Api-Key"}
+
+// secretParams are query parameters whose values are replaced in transcripts.
+var secretParams = []string{"key", "api_key", "apikey", "token"}
+
+const redacted = "REDACTED"
+
+// Recorder is an http.RoundTripper that forwards to a base transport and
+// writes each exchange to a numbered JSON file in dir.
+type Recorder struct {
+	dir  string
+	base http.RoundTripper
+
+	mu  sync.Mutex
+	seq int
+}
+
+// NewRecorder records through base (http.DefaultTransport when nil) into dir,
+// creating it if needed.
+func NewRecorder(dir string, base http.RoundTripper) (*Recorder, error) {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Recorder{dir: dir, base: base}, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		reqBody, _ = io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(reqBody))
+	}
+	resp, err := r.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	respBody, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(respBody))
+
+	var entry Entry
+	entry.Request.Method = req.Method
+	entry.Request.URL = redactURL(req.URL)
+	entry.Request.Header = redactHeader(req.Header)
+	entry.Request.Body = string(reqBody)
+	entry.Response.Status = resp.StatusCode
+	entry.Response.Header = redactHeader(resp.Header)
+	entry.Response.Body = string(respBody)
+
+	r.mu.Lock()
+	r.seq++
+	path := filepath.Join(r.dir, fmt.Sprintf("%04d.json", r.seq))
+	r.mu.Unlock()
+	data, merr := json.MarshalIndent(entry, "", "  ")
+	if merr == nil {
+		merr = os.WriteFile(path, data, 0o600)
+	}
+	if merr != nil {
+		return nil, fmt.Errorf("recording transcript: %w", merr)
+	}
+	return resp, nil
+}
+
+// Replayer is an http.RoundTripper that serves responses from a recorded
+// transcript directory. Each entry is consumed once, matched by method and
+// sanitized URL.
+type Replayer struct {
+	mu      sync.Mutex
+	entries []*Entry
+}
+
+// NewReplayer loads every transcript file from dir in recording order.
+func NewReplayer(dir string) (*Replayer, error) {
+	names, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+	rp := &Replayer{}
+	for _, name := range names {
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		entry := &Entry{}
+		if err := json.Unmarshal(data, entry); err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		rp.entries = append(rp.entries, entry)
+	}
+	return rp, nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	want := redactURL(req.URL)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, entry := range r.entries {
+		if entry.Request.Method != req.Method || entry.Request.URL != want {
+			continue
+		}
+		r.entries = append(r.entries[:i], r.entries[i+1:]...)
+		resp := &http.Response{
+			StatusCode: entry.Response.Status,
+			Status:     fmt.Sprintf("%d %s", entry.Response.Status, http.StatusText(entry.Response.Status)),
+			Header:     http.Header(entry.Response.Header),
+			Body:       io.NopCloser(strings.NewReader(entry.Response.Body)),
+			Request:    req,
+		}
+		if resp.Header == nil {
+			resp.Header = make(http.Header)
+		}
+		return resp, nil
+	}
+	return nil, fmt.Errorf("no recorded transcript for %s %s", req.Method, want)
+}
+
+func redactHeader(h http.Header) map[string][]string {
+	if len(h) == 0 {
+		return nil
+	}
+	out := make(map[string][]string, len(h))
+	for k, v := range h {
+		out[k] = v
+	}
+	for _, name := range secretHeaders {
+		if _, ok := out[name]; ok {
+			out[name] = []string{redacted}
+		}
+	}
+	return out
+}
+
+func redactURL(u *url.URL) string {
+	clean := *u
+	q := clean.Query()
+	changed := false
+	for _, name := range secretParams {
+		if q.Has(name) {
+			q.Set(name, redacted)
+			changed = true
+		}
+	}
+	if changed {
+		clean.RawQuery = q.Encode()
+	}
+	return clean.String()
+}
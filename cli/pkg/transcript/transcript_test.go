@@ -0,0 +1,88 @@
+package transcript
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRedactsSecrets(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"id":"m1"}]}`))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec}
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/models?api_key=sk-secret-123456", nil)
+	req.Header.Set("Authorization", "Bearer sk-secret-123456")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "m1") {
+		t.Errorf("body = %q", body)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("files = %v", files)
+	}
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "sk-secret-123456") {
+		t.Error("transcript contains plaintext secret")
+	}
+	if !strings.Contains(string(data), "REDACTED") {
+		t.Error("transcript missing redaction marker")
+	}
+}
+
+func TestReplayerServesRecordedExchange(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[{"id":"m1"}]}`))
+	}))
+
+	dir := t.TempDir()
+	rec, err := NewRecorder(dir, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := &http.Client{Transport: rec}
+	if _, err := client.Get(srv.URL + "/models"); err != nil {
+		t.Fatal(err)
+	}
+	srv.Close() // replay must not need the server
+
+	rp, err := NewReplayer(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = &http.Client{Transport: rp}
+	resp, err := client.Get(srv.URL + "/models")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if !strings.Contains(string(body), "m1") {
+		t.Errorf("replayed body = %q", body)
+	}
+
+	// The single entry is consumed; a second request has nothing to match.
+	if _, err := client.Get(srv.URL + "/models"); err == nil {
+		t.Error("second replay unexpectedly succeeded")
+	}
+}
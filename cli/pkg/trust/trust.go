@@ -0,0 +1,66 @@
+// Package trust implements per-directory trust levels. Task and chat commands
+// consult the level of the working directory before operating with file or
+// shell access there; levels are stored in the config keyed by absolute path.
+package trust
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Level is a directory's trust classification.
+type Level string
+
+const (
+	// Trusted directories allow full task operation.
+	Trusted Level = "trusted"
+	// Restricted directories allow tasks but every action needs approval.
+	// This is the default for unlisted directories.
+	Restricted Level = "restricted"
+	// Blocked directories refuse task and chat commands entirely.
+	Blocked Level = "blocked"
+)
+
+// ParseLevel validates a trust level name.
+func ParseLevel(s string) (Level, error) {
+	switch Level(s) {
+	case Trusted, Restricted, Blocked:
+		return Level(s), nil
+	}
+	return "", fmt.Errorf("unknown trust level %q (want trusted, restricted or blocked)", s)
+}
+
+// Lookup resolves the trust level for dir against the configured path map.
+// The deepest matching ancestor wins, so a blocked subdirectory inside a
+// trusted tree stays blocked. Unlisted directories are Restricted.
+func Lookup(levels map[string]string, dir string) Level {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return Restricted
+	}
+	bestLen := -1
+	best := Restricted
+	for path, level := range levels {
+		root, err := filepath.Abs(path)
+		if err != nil {
+			continue
+		}
+		if !contains(root, abs) || len(root) <= bestLen {
+			continue
+		}
+		if parsed, err := ParseLevel(level); err == nil {
+			best, bestLen = parsed, len(root)
+		}
+	}
+	return best
+}
+
+// contains reports whether dir equals root or lies below it.
+func contains(root, dir string) bool {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil {
+		return false
+	}
+	return rel == "." || (rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)))
+}
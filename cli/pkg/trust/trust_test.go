@@ -0,0 +1,43 @@
+package trust
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupDeepestAncestorWins(t *testing.T) {
+	root := t.TempDir()
+	levels := map[string]string{
+		root:                          string(Trusted),
+		filepath.Join(root, "vendor"): string(Blocked),
+	}
+	if got := Lookup(levels, filepath.Join(root, "src")); got != Trusted {
+		t.Errorf("src = %v", got)
+	}
+	if got := Lookup(levels, filepath.Join(root, "vendor", "lib")); got != Blocked {
+		t.Errorf("vendor/lib = %v", got)
+	}
+}
+
+func TestLookupDefaultsToRestricted(t *testing.T) {
+	if got := Lookup(nil, t.TempDir()); got != Restricted {
+		t.Errorf("unlisted dir = %v", got)
+	}
+}
+
+func TestLookupSiblingPrefixNotContained(t *testing.T) {
+	root := t.TempDir()
+	levels := map[string]string{filepath.Join(root, "app"): string(Trusted)}
+	if got := Lookup(levels, filepath.Join(root, "app-data")); got != Restricted {
+		t.Errorf("sibling with shared name prefix = %v", got)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if _, err := ParseLevel("blocked"); err != nil {
+		t.Error(err)
+	}
+	if _, err := ParseLevel("sandbox"); err == nil {
+		t.Error("invalid level accepted")
+	}
+}
@@ -0,0 +1,76 @@
+package usage
+
+import (
+	"encoding/csv"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Export column schema, stable across releases so BI pipelines can rely on
+// it. Columns appear in this order in both formats:
+//
+//	time          RFC 3339 timestamp in UTC
+//	command       cline subcommand that made the request
+//	provider      provider ID
+//	model         model ID (may be empty)
+//	input_tokens  prompt tokens
+//	output_tokens completion tokens
+//	cost_usd      cost normalized to US dollars at record time
+//	tags          key=value pairs sorted by key, joined with ";"
+//
+// New columns are only ever appended; existing names, order, and meaning do
+// not change.
+var exportColumns = []string{
+	"time", "command", "provider", "model",
+	"input_tokens", "output_tokens", "cost_usd", "tags",
+}
+
+// exportRow renders one record in the stable column order.
+func exportRow(r Record) []string {
+	return []string{
+		r.Time.UTC().Format("2006-01-02T15:04:05Z07:00"),
+		r.Command,
+		r.Provider,
+		r.ModelID,
+		strconv.Itoa(r.InputTokens),
+		strconv.Itoa(r.OutputTokens),
+		strconv.FormatFloat(r.CostUSD, 'f', -1, 64),
+		tagString(r.Tags),
+	}
+}
+
+// tagString flattens tags to "k1=v1;k2=v2" with keys sorted, so the same
+// tag set always exports identically.
+func tagString(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	pairs := make([]string, 0, len(keys))
+	for _, k := range keys {
+		pairs = append(pairs, k+"="+tags[k])
+	}
+	return strings.Join(pairs, ";")
+}
+
+// WriteCSV writes the records as CSV with a header row, using the stable
+// export schema above.
+func WriteCSV(w io.Writer, records []Record) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(exportColumns); err != nil {
+		return err
+	}
+	for _, r := range records {
+		if err := cw.Write(exportRow(r)); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
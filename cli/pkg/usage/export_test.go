@@ -0,0 +1,70 @@
+package usage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/csv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func exportFixtures() []Record {
+	return []Record{
+		{Time: time.Date(2026, 8, 1, 10, 0, 0, 0, time.UTC), Command: "ask", Provider: "openai", ModelID: "gpt-4o",
+			InputTokens: 100, OutputTokens: 50, CostUSD: 0.015,
+			Tags: map[string]string{"ticket": "ENG-1234", "project": "checkout"}},
+		{Time: time.Date(2026, 8, 2, 11, 0, 0, 0, time.UTC), Command: "batch", Provider: "anthropic", ModelID: "claude",
+			CostUSD: 0.10},
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, exportFixtures()); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("rows = %d, want header + 2", len(rows))
+	}
+	if got := strings.Join(rows[0], ","); got != "time,command,provider,model,input_tokens,output_tokens,cost_usd,tags" {
+		t.Errorf("header = %q", got)
+	}
+	want := []string{"2026-08-01T10:00:00Z", "ask", "openai", "gpt-4o", "100", "50", "0.015", "project=checkout;ticket=ENG-1234"}
+	for i, cell := range want {
+		if rows[1][i] != cell {
+			t.Errorf("row 1 col %d = %q, want %q", i, rows[1][i], cell)
+		}
+	}
+	if rows[2][7] != "" {
+		t.Errorf("untagged row tags = %q, want empty", rows[2][7])
+	}
+}
+
+func TestWriteParquet(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteParquet(&buf, exportFixtures()); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+	if !bytes.HasPrefix(data, []byte("PAR1")) || !bytes.HasSuffix(data, []byte("PAR1")) {
+		t.Fatal("missing PAR1 magic")
+	}
+	metaLen := int(binary.LittleEndian.Uint32(data[len(data)-8 : len(data)-4]))
+	if metaLen <= 0 || 4+metaLen+8 > len(data) {
+		t.Fatalf("footer length %d does not fit in %d-byte file", metaLen, len(data))
+	}
+	footer := data[len(data)-8-metaLen : len(data)-8]
+	for _, col := range exportColumns {
+		if !bytes.Contains(footer, []byte(col)) {
+			t.Errorf("footer missing column %q", col)
+		}
+	}
+	if !bytes.Contains(data, []byte("project=checkout;ticket=ENG-1234")) {
+		t.Error("data pages missing tag values")
+	}
+}
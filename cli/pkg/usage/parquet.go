@@ -0,0 +1,247 @@
+package usage
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math"
+	"strconv"
+)
+
+// Minimal Parquet writer for the export schema: one row group, one
+// uncompressed PLAIN-encoded data page per column, all fields required.
+// That subset keeps us dependency-free (the footer is hand-encoded
+// compact-Thrift, like the hand-rolled YAML parsers elsewhere in this
+// tree) while producing files every mainstream Parquet reader accepts.
+
+// Parquet physical types and footer enum values, from parquet.thrift.
+const (
+	parquetInt64     = 2
+	parquetDouble    = 5
+	parquetByteArray = 6
+
+	parquetUTF8         = 0 // ConvertedType
+	parquetRequired     = 0 // FieldRepetitionType
+	parquetPlain        = 0 // Encoding
+	parquetRLE          = 3 // Encoding, for the (absent) level streams
+	parquetUncompressed = 0 // CompressionCodec
+	parquetDataPage     = 0 // PageType
+)
+
+// parquetColumn is one leaf column: its schema entry plus the PLAIN-encoded
+// value stream.
+type parquetColumn struct {
+	name      string
+	typ       int32
+	converted bool // annotate BYTE_ARRAY as UTF8
+	data      []byte
+}
+
+// WriteParquet writes the records as a Parquet file using the stable export
+// schema (see exportColumns).
+func WriteParquet(w io.Writer, records []Record) error {
+	columns := make([]parquetColumn, len(exportColumns))
+	for i, name := range exportColumns {
+		columns[i] = parquetColumn{name: name, typ: parquetByteArray, converted: true}
+	}
+	columns[4].typ, columns[4].converted = parquetInt64, false
+	columns[5].typ, columns[5].converted = parquetInt64, false
+	columns[6].typ, columns[6].converted = parquetDouble, false
+	for _, r := range records {
+		row := exportRow(r)
+		for i := range columns {
+			switch columns[i].typ {
+			case parquetInt64:
+				n, _ := strconv.ParseInt(row[i], 10, 64)
+				columns[i].data = binary.LittleEndian.AppendUint64(columns[i].data, uint64(n))
+			case parquetDouble:
+				f, _ := strconv.ParseFloat(row[i], 64)
+				columns[i].data = binary.LittleEndian.AppendUint64(columns[i].data, math.Float64bits(f))
+			default:
+				columns[i].data = binary.LittleEndian.AppendUint32(columns[i].data, uint32(len(row[i])))
+				columns[i].data = append(columns[i].data, row[i]...)
+			}
+		}
+	}
+	return writeParquetFile(w, columns, int64(len(records)))
+}
+
+// chunkInfo locates one column chunk within the file.
+type chunkInfo struct {
+	offset int64
+	size   int64
+}
+
+func writeParquetFile(w io.Writer, columns []parquetColumn, numRows int64) error {
+	var buf bytes.Buffer
+	buf.WriteString("PAR1")
+
+	chunks := make([]chunkInfo, len(columns))
+	for i, col := range columns {
+		header := encodeParquetPageHeader(numRows, len(col.data))
+		chunks[i] = chunkInfo{offset: int64(buf.Len()), size: int64(len(header) + len(col.data))}
+		buf.Write(header)
+		buf.Write(col.data)
+	}
+
+	meta := encodeParquetFooter(columns, chunks, numRows)
+	buf.Write(meta)
+	buf.Write(binary.LittleEndian.AppendUint32(nil, uint32(len(meta))))
+	buf.WriteString("PAR1")
+	_, err := w.Write(buf.Bytes())
+	return err
+}
+
+func encodeParquetPageHeader(numValues int64, dataSize int) []byte {
+	e := newThriftEncoder()
+	e.i32Field(1, parquetDataPage)
+	e.i32Field(2, int64(dataSize))
+	e.i32Field(3, int64(dataSize))
+	e.structField(5) // DataPageHeader
+	e.i32Field(1, numValues)
+	e.i32Field(2, parquetPlain)
+	e.i32Field(3, parquetRLE)
+	e.i32Field(4, parquetRLE)
+	e.closeStruct()
+	e.closeStruct()
+	return e.bytes()
+}
+
+func encodeParquetFooter(columns []parquetColumn, chunks []chunkInfo, numRows int64) []byte {
+	e := newThriftEncoder()
+	e.i32Field(1, 1) // format version
+	e.listField(2, thriftStruct, len(columns)+1)
+	e.openStruct() // root SchemaElement
+	e.stringField(4, "schema")
+	e.i32Field(5, int64(len(columns)))
+	e.closeStruct()
+	for _, col := range columns {
+		e.openStruct()
+		e.i32Field(1, int64(col.typ))
+		e.i32Field(3, parquetRequired)
+		e.stringField(4, col.name)
+		if col.converted {
+			e.i32Field(6, parquetUTF8)
+		}
+		e.closeStruct()
+	}
+	e.i64Field(3, numRows)
+	e.listField(4, thriftStruct, 1)
+	e.openStruct() // RowGroup
+	e.listField(1, thriftStruct, len(columns))
+	var totalSize int64
+	for i, col := range columns {
+		e.openStruct() // ColumnChunk
+		e.i64Field(2, chunks[i].offset)
+		e.structField(3) // ColumnMetaData
+		e.i32Field(1, int64(col.typ))
+		e.listField(2, thriftI32, 1)
+		e.zigzag(parquetPlain)
+		e.listField(3, thriftBinary, 1)
+		e.str(col.name)
+		e.i32Field(4, parquetUncompressed)
+		e.i64Field(5, numRows)
+		e.i64Field(6, chunks[i].size)
+		e.i64Field(7, chunks[i].size)
+		e.i64Field(9, chunks[i].offset)
+		e.closeStruct()
+		e.closeStruct()
+		totalSize += chunks[i].size
+	}
+	e.i64Field(2, totalSize)
+	e.i64Field(3, numRows)
+	e.closeStruct()
+	e.stringField(6, "cline")
+	e.closeStruct()
+	return e.bytes()
+}
+
+// thriftEncoder emits the compact-Thrift subset the Parquet footer needs:
+// i32/i64, string, list, and struct fields.
+const (
+	thriftI32    = 5
+	thriftI64    = 6
+	thriftBinary = 8
+	thriftList   = 9
+	thriftStruct = 12
+)
+
+type thriftEncoder struct {
+	buf  bytes.Buffer
+	last []int16 // previous field ID per open struct, for delta encoding
+}
+
+func newThriftEncoder() *thriftEncoder {
+	e := &thriftEncoder{}
+	e.last = append(e.last, 0)
+	return e
+}
+
+func (e *thriftEncoder) bytes() []byte { return e.buf.Bytes() }
+
+func (e *thriftEncoder) openStruct() { e.last = append(e.last, 0) }
+
+func (e *thriftEncoder) closeStruct() {
+	e.buf.WriteByte(0) // field stop
+	e.last = e.last[:len(e.last)-1]
+}
+
+func (e *thriftEncoder) fieldHeader(id int16, typ byte) {
+	delta := id - e.last[len(e.last)-1]
+	if delta >= 1 && delta <= 15 {
+		e.buf.WriteByte(byte(delta)<<4 | typ)
+	} else {
+		e.buf.WriteByte(typ)
+		e.zigzag(int64(id))
+	}
+	e.last[len(e.last)-1] = id
+}
+
+func (e *thriftEncoder) varint(v uint64) {
+	for v >= 0x80 {
+		e.buf.WriteByte(byte(v) | 0x80)
+		v >>= 7
+	}
+	e.buf.WriteByte(byte(v))
+}
+
+func (e *thriftEncoder) zigzag(v int64) {
+	e.varint(uint64((v << 1) ^ (v >> 63)))
+}
+
+func (e *thriftEncoder) str(s string) {
+	e.varint(uint64(len(s)))
+	e.buf.WriteString(s)
+}
+
+func (e *thriftEncoder) i32Field(id int16, v int64) {
+	e.fieldHeader(id, thriftI32)
+	e.zigzag(v)
+}
+
+func (e *thriftEncoder) i64Field(id int16, v int64) {
+	e.fieldHeader(id, thriftI64)
+	e.zigzag(v)
+}
+
+func (e *thriftEncoder) stringField(id int16, s string) {
+	e.fieldHeader(id, thriftBinary)
+	e.str(s)
+}
+
+func (e *thriftEncoder) listField(id int16, elemType byte, size int) {
+	e.fieldHeader(id, thriftList)
+	if size < 15 {
+		e.buf.WriteByte(byte(size)<<4 | elemType)
+	} else {
+		e.buf.WriteByte(0xF0 | elemType)
+		e.varint(uint64(size))
+	}
+}
+
+// structField opens a nested struct value for field id; pair with
+// closeStruct. Struct elements inside a list use openStruct directly.
+func (e *thriftEncoder) structField(id int16) {
+	e.fieldHeader(id, thriftStruct)
+	e.openStruct()
+}
@@ -0,0 +1,163 @@
+// Package usage keeps a persistent ledger of what invocations cost — tokens
+// and dollars per provider, model, command, and user-supplied tags — so
+// spend can be reported and charged back per project. Entries are appended
+// to a JSONL file under the data directory; recording is best-effort and
+// must never fail a request.
+package usage
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+// Record is one ledger entry. Tags are free-form key=value labels the user
+// attached with --tag.
+type Record struct {
+	Time         time.Time         `json:"time"`
+	Command      string            `json:"command"`
+	Provider     string            `json:"provider"`
+	ModelID      string            `json:"modelId,omitempty"`
+	InputTokens  int               `json:"inputTokens,omitempty"`
+	OutputTokens int               `json:"outputTokens,omitempty"`
+	CostUSD      float64           `json:"costUsd,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+func filePath() (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "usage.jsonl"), nil
+}
+
+// Add appends one entry, filling Time when unset. Errors are swallowed: the
+// ledger is advisory and must not interfere with the request path.
+func Add(r Record) {
+	if r.Time.IsZero() {
+		r.Time = time.Now().UTC()
+	}
+	path, err := filePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	data, err := json.Marshal(r)
+	if err != nil {
+		return
+	}
+	f.Write(append(data, '\n'))
+}
+
+// Load returns all entries recorded at or after since. Malformed lines are
+// skipped.
+func Load(since time.Time) ([]Record, error) {
+	path, err := filePath()
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		if !r.Time.Before(since) {
+			records = append(records, r)
+		}
+	}
+	return records, scanner.Err()
+}
+
+// Total is one aggregated report line.
+type Total struct {
+	Key          string
+	Requests     int
+	InputTokens  int
+	OutputTokens int
+	CostUSD      float64
+}
+
+// GroupBy aggregates records by "provider", "model", "command", "day", or
+// "tag". Grouping by tag produces one line per key=value pair, so an entry
+// carrying several tags contributes its full cost to each — chargeback per
+// tag, not a partition of total spend. Untagged entries group under
+// "(untagged)".
+func GroupBy(records []Record, by string) ([]Total, error) {
+	keys := func(r Record) []string {
+		switch by {
+		case "provider":
+			return []string{r.Provider}
+		case "model":
+			return []string{r.Provider + "/" + r.ModelID}
+		case "command":
+			return []string{r.Command}
+		case "day":
+			return []string{r.Time.Format("2006-01-02")}
+		case "tag":
+			if len(r.Tags) == 0 {
+				return []string{"(untagged)"}
+			}
+			var out []string
+			for k, v := range r.Tags {
+				out = append(out, k+"="+v)
+			}
+			return out
+		}
+		return nil
+	}
+	switch by {
+	case "provider", "model", "command", "day", "tag":
+	default:
+		return nil, fmt.Errorf("cannot group by %q (provider, model, command, day, or tag)", by)
+	}
+	totals := map[string]*Total{}
+	for _, r := range records {
+		for _, key := range keys(r) {
+			t := totals[key]
+			if t == nil {
+				t = &Total{Key: key}
+				totals[key] = t
+			}
+			t.Requests++
+			t.InputTokens += r.InputTokens
+			t.OutputTokens += r.OutputTokens
+			t.CostUSD += r.CostUSD
+		}
+	}
+	var out []Total
+	for _, t := range totals {
+		out = append(out, *t)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].CostUSD != out[j].CostUSD {
+			return out[i].CostUSD > out[j].CostUSD
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out, nil
+}
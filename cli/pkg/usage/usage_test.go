@@ -0,0 +1,59 @@
+package usage
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAddLoadGroup(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	Add(Record{Command: "ask", Provider: "openai", ModelID: "gpt-4o", InputTokens: 100, OutputTokens: 50, CostUSD: 0.01,
+		Tags: map[string]string{"project": "checkout"}})
+	Add(Record{Command: "ask", Provider: "openai", ModelID: "gpt-4o", InputTokens: 200, OutputTokens: 80, CostUSD: 0.03,
+		Tags: map[string]string{"project": "checkout", "ticket": "ENG-1234"}})
+	Add(Record{Command: "batch", Provider: "anthropic", ModelID: "claude", CostUSD: 0.10})
+
+	records, err := Load(time.Time{})
+	if err != nil || len(records) != 3 {
+		t.Fatalf("Load = %d records, %v", len(records), err)
+	}
+
+	byTag, err := GroupBy(records, "tag")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"(untagged)": 0.10, "project=checkout": 0.04, "ticket=ENG-1234": 0.03}
+	if len(byTag) != len(want) {
+		t.Fatalf("byTag = %+v", byTag)
+	}
+	for _, total := range byTag {
+		if diff := total.CostUSD - want[total.Key]; diff > 1e-9 || diff < -1e-9 {
+			t.Errorf("%s = $%f, want $%f", total.Key, total.CostUSD, want[total.Key])
+		}
+	}
+
+	byProvider, err := GroupBy(records, "provider")
+	if err != nil || len(byProvider) != 2 {
+		t.Fatalf("byProvider = %+v, %v", byProvider, err)
+	}
+	if byProvider[0].Key != "anthropic" || byProvider[0].CostUSD != 0.10 {
+		t.Errorf("highest spend first, got %+v", byProvider[0])
+	}
+	if byProvider[1].Requests != 2 || byProvider[1].InputTokens != 300 {
+		t.Errorf("openai totals = %+v", byProvider[1])
+	}
+
+	if _, err := GroupBy(records, "nope"); err == nil {
+		t.Error("unknown grouping should error")
+	}
+}
+
+func TestLoadSince(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	Add(Record{Time: time.Now().UTC().Add(-48 * time.Hour), Command: "ask", Provider: "openai", CostUSD: 0.01})
+	Add(Record{Command: "ask", Provider: "openai", CostUSD: 0.02})
+	records, err := Load(time.Now().Add(-24 * time.Hour))
+	if err != nil || len(records) != 1 || records[0].CostUSD != 0.02 {
+		t.Fatalf("Load since = %+v, %v", records, err)
+	}
+}
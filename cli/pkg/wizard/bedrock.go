@@ -0,0 +1,165 @@
+package wizard
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// Bedrock availability differs per region, and cross-region inference
+// profiles prefix model IDs with a geography ("us.", "eu.", "apac."). The
+// setup flow therefore collects the region from a select list, offers only
+// models served there, and rejects profile IDs whose geography does not match
+// the region.
+
+// bedrockRegions lists the regions serving the catalog's Bedrock models.
+var bedrockRegions = []string{
+	"us-east-1",
+	"us-east-2",
+	"us-west-2",
+	"eu-central-1",
+	"eu-west-1",
+	"eu-west-3",
+	"ap-northeast-1",
+	"ap-southeast-1",
+	"ap-southeast-2",
+}
+
+// bedrockModelGeos maps base model IDs to the geographies serving them.
+var bedrockModelGeos = map[string][]string{
+	"anthropic.claude-sonnet-4-20250514-v1:0":  {"us", "eu", "apac"},
+	"anthropic.claude-3-5-haiku-20241022-v1:0": {"us", "eu"},
+}
+
+// bedrockGeo maps a region to its inference-profile geography prefix.
+func bedrockGeo(region string) string {
+	switch {
+	case strings.HasPrefix(region, "us-"):
+		return "us"
+	case strings.HasPrefix(region, "eu-"):
+		return "eu"
+	case strings.HasPrefix(region, "ap-"):
+		return "apac"
+	}
+	return ""
+}
+
+// bedrockModelsForRegion filters the static catalog to models served in the
+// region; models missing from the availability table are assumed global.
+func bedrockModelsForRegion(def *generated.ProviderDefinition, region string) []generated.ModelInfo {
+	geo := bedrockGeo(region)
+	var available []generated.ModelInfo
+	for _, m := range def.Models {
+		geos, known := bedrockModelGeos[m.ID]
+		if !known {
+			available = append(available, m)
+			continue
+		}
+		for _, g := range geos {
+			if g == geo {
+				available = append(available, m)
+				break
+			}
+		}
+	}
+	return available
+}
+
+// ValidateBedrockProfile checks a model or inference-profile ID against the
+// configured region: a "eu." profile needs an eu- region, and the base model
+// must be served there at all.
+func ValidateBedrockProfile(modelID, region string) error {
+	geo := bedrockGeo(region)
+	if geo == "" {
+		return fmt.Errorf("unknown AWS region %q", region)
+	}
+	base := modelID
+	for _, prefix := range []string{"us.", "eu.", "apac."} {
+		if strings.HasPrefix(modelID, prefix) {
+			if strings.TrimSuffix(prefix, ".") != geo {
+				return fmt.Errorf("inference profile %q is for the %s geography, but region %s is %s",
+					modelID, strings.TrimSuffix(prefix, "."), region, geo)
+			}
+			base = strings.TrimPrefix(modelID, prefix)
+			break
+		}
+	}
+	geos, known := bedrockModelGeos[base]
+	if !known {
+		return nil
+	}
+	for _, g := range geos {
+		if g == geo {
+			return nil
+		}
+	}
+	return fmt.Errorf("model %q is not served in %s (available in: %s)", base, region, strings.Join(geos, ", "))
+}
+
+// promptBedrockRegion collects the region from a numbered select list instead
+// of free text, since a typo here surfaces much later as an opaque API error.
+func (w *SetupWizard) promptBedrockRegion() (string, error) {
+	fmt.Fprintln(w.out, "AWS regions with Bedrock model access:")
+	for i, region := range bedrockRegions {
+		fmt.Fprintf(w.out, "  %d. %s\n", i+1, region)
+	}
+	answer, err := w.prompt(generated.ConfigField{Name: "awsRegion", Label: "Select a region [1]"})
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return bedrockRegions[0], nil
+	}
+	var pick int
+	if _, err := fmt.Sscanf(answer, "%d", &pick); err == nil && pick >= 1 && pick <= len(bedrockRegions) {
+		return bedrockRegions[pick-1], nil
+	}
+	// Typed region names are accepted when they are on the list.
+	for _, region := range bedrockRegions {
+		if answer == region {
+			return region, nil
+		}
+	}
+	return "", fmt.Errorf("invalid region selection %q", answer)
+}
+
+// selectBedrockModel resolves the entry's model against the configured
+// region: an existing choice is validated, an empty one is picked from a
+// select list of the models and cross-region profiles served there.
+func (w *SetupWizard) selectBedrockModel(def *generated.ProviderDefinition, entry *config.ProviderConfig) error {
+	region := entry.Field("awsRegion")
+	if region == "" {
+		return nil
+	}
+	if entry.ModelID != "" {
+		return ValidateBedrockProfile(entry.ModelID, region)
+	}
+	available := bedrockModelsForRegion(def, region)
+	if len(available) == 0 {
+		return fmt.Errorf("no catalog models are served in %s", region)
+	}
+	geo := bedrockGeo(region)
+	var choices []string
+	for _, m := range available {
+		choices = append(choices, m.ID, geo+"."+m.ID+" (cross-region profile)")
+	}
+	fmt.Fprintf(w.out, "Models available in %s:\n", region)
+	for i, choice := range choices {
+		fmt.Fprintf(w.out, "  %d. %s\n", i+1, choice)
+	}
+	answer, err := w.prompt(generated.ConfigField{Name: "modelId", Label: "Select a model [1]"})
+	if err != nil {
+		return err
+	}
+	pick := 1
+	if answer != "" {
+		if _, err := fmt.Sscanf(answer, "%d", &pick); err != nil || pick < 1 || pick > len(choices) {
+			return fmt.Errorf("invalid model selection %q", answer)
+		}
+	}
+	modelID, _, _ := strings.Cut(choices[pick-1], " ")
+	entry.ModelID = modelID
+	return nil
+}
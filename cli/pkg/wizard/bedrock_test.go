@@ -0,0 +1,50 @@
+package wizard
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestValidateBedrockProfile(t *testing.T) {
+	cases := []struct {
+		modelID, region string
+		wantErr         bool
+	}{
+		{"anthropic.claude-sonnet-4-20250514-v1:0", "us-east-1", false},
+		{"us.anthropic.claude-sonnet-4-20250514-v1:0", "us-west-2", false},
+		{"eu.anthropic.claude-sonnet-4-20250514-v1:0", "eu-central-1", false},
+		{"eu.anthropic.claude-sonnet-4-20250514-v1:0", "us-east-1", true},
+		{"apac.anthropic.claude-3-5-haiku-20241022-v1:0", "ap-southeast-2", true}, // haiku has no apac geo
+		{"anthropic.claude-3-5-haiku-20241022-v1:0", "ap-northeast-1", true},
+		{"anthropic.claude-sonnet-4-20250514-v1:0", "mars-north-1", true},
+	}
+	for _, tc := range cases {
+		err := ValidateBedrockProfile(tc.modelID, tc.region)
+		if (err != nil) != tc.wantErr {
+			t.Errorf("ValidateBedrockProfile(%q, %q) = %v, wantErr %v", tc.modelID, tc.region, err, tc.wantErr)
+		}
+	}
+}
+
+func TestBedrockModelsForRegion(t *testing.T) {
+	def := &generated.ProviderDefinition{Models: []generated.ModelInfo{
+		{ID: "anthropic.claude-sonnet-4-20250514-v1:0"},
+		{ID: "anthropic.claude-3-5-haiku-20241022-v1:0"},
+	}}
+	ids := func(list []generated.ModelInfo) string {
+		var names []string
+		for _, m := range list {
+			names = append(names, m.ID)
+		}
+		return strings.Join(names, ",")
+	}
+	if got := bedrockModelsForRegion(def, "us-east-1"); len(got) != 2 {
+		t.Errorf("us-east-1 models = %s", ids(got))
+	}
+	if got := bedrockModelsForRegion(def, "ap-northeast-1"); len(got) != 1 ||
+		got[0].ID != "anthropic.claude-sonnet-4-20250514-v1:0" {
+		t.Errorf("ap-northeast-1 models = %s", ids(got))
+	}
+}
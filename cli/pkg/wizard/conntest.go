@@ -0,0 +1,196 @@
+package wizard
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/stats"
+)
+
+const (
+	// testWorkers bounds how many providers are probed at once.
+	testWorkers = 4
+	// perProviderTimeout bounds a single provider's reachability check so one
+	// slow endpoint doesn't stall the whole run.
+	perProviderTimeout = 5 * time.Second
+)
+
+// TestLevel selects how deep a provider test goes.
+type TestLevel int
+
+const (
+	// LevelConfig only validates required fields, no network traffic.
+	LevelConfig TestLevel = iota + 1
+	// LevelAuth performs an authenticated metadata call.
+	LevelAuth
+	// LevelCompletion runs a one-token completion and reports latency and
+	// estimated cost.
+	LevelCompletion
+)
+
+// ParseTestLevel maps the --level flag values onto TestLevel.
+func ParseTestLevel(s string) (TestLevel, error) {
+	switch s {
+	case "config":
+		return LevelConfig, nil
+	case "auth":
+		return LevelAuth, nil
+	case "completion":
+		return LevelCompletion, nil
+	}
+	return 0, fmt.Errorf("unknown test level %q (want config, auth or completion)", s)
+}
+
+// RunConnectionTests validates and probes every configured provider at the
+// given depth using a worker pool, sending each result on results as soon as
+// it is known. The channel is closed when all providers have been tested.
+func RunConnectionTests(ctx context.Context, registry *generated.ProviderRegistry, cfg *config.ClineConfig, level TestLevel, results chan<- TestResult) {
+	ids := sortedProviderIDs(cfg)
+	jobs := make(chan string)
+	var wg sync.WaitGroup
+	for i := 0; i < testWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for id := range jobs {
+				results <- testProvider(ctx, registry, id, cfg.Providers[id], level)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for _, id := range ids {
+			select {
+			case jobs <- id:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+}
+
+// testProvider checks one provider at the requested depth. Required fields are
+// always validated first; deeper levels add an authenticated metadata call and
+// finally a one-token completion.
+func testProvider(ctx context.Context, registry *generated.ProviderRegistry, id string, entry *config.ProviderConfig, level TestLevel) TestResult {
+	def, err := registry.Provider(id)
+	if err != nil {
+		return TestResult{Provider: id, Detail: err.Error()}
+	}
+	if err := ValidateRequiredFields(def, entry); err != nil {
+		return TestResult{Provider: id, Detail: err.Error()}
+	}
+	if level <= LevelConfig {
+		return TestResult{Provider: id, OK: true, Detail: "required fields present"}
+	}
+	endpoint := entry.BaseURL
+	if endpoint == "" {
+		endpoint = def.BaseURL
+	}
+	if endpoint == "" {
+		return TestResult{Provider: id, OK: true, Detail: "configured (no endpoint to probe)"}
+	}
+	ctx, cancel := context.WithTimeout(ctx, perProviderTimeout)
+	defer cancel()
+	start := time.Now()
+	var result TestResult
+	if level >= LevelCompletion {
+		result = completionProbe(ctx, def, id, entry, endpoint)
+	} else {
+		result = authProbe(ctx, id, entry, endpoint)
+	}
+	stats.Record(id, time.Since(start).Seconds(), !result.OK)
+	return result
+}
+
+// authProbe hits the provider's model metadata endpoint with credentials
+// attached. An explicit auth rejection fails the probe; any other response
+// means the credentials were at least accepted at the transport level.
+func authProbe(ctx context.Context, id string, entry *config.ProviderConfig, endpoint string) TestResult {
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(endpoint, "/")+"/models", nil)
+	if err != nil {
+		return TestResult{Provider: id, Detail: fmt.Sprintf("bad endpoint %q: %v", endpoint, err)}
+	}
+	authenticate(req, id, entry)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return TestResult{Provider: id, Detail: unreachableDetail(id, err)}
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return TestResult{Provider: id, Detail: fmt.Sprintf("authentication rejected (%s)", resp.Status)}
+	}
+	return TestResult{Provider: id, OK: true, Detail: fmt.Sprintf("authenticated in %s", time.Since(start).Round(time.Millisecond))}
+}
+
+// completionProbe runs a one-token completion against an OpenAI-style chat
+// endpoint and reports round-trip latency plus the estimated cost from the
+// registry's pricing.
+func completionProbe(ctx context.Context, def *generated.ProviderDefinition, id string, entry *config.ProviderConfig, endpoint string) TestResult {
+	payload, _ := json.Marshal(map[string]any{
+		"model":      entry.ModelID,
+		"max_tokens": 1,
+		"messages":   []map[string]string{{"role": "user", "content": "ping"}},
+	})
+	start := time.Now()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(endpoint, "/")+"/chat/completions", bytes.NewReader(payload))
+	if err != nil {
+		return TestResult{Provider: id, Detail: fmt.Sprintf("bad endpoint %q: %v", endpoint, err)}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	authenticate(req, id, entry)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return TestResult{Provider: id, Detail: unreachableDetail(id, err)}
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return TestResult{Provider: id, Detail: fmt.Sprintf("completion failed (%s)", resp.Status)}
+	}
+	detail := fmt.Sprintf("completed in %s", time.Since(start).Round(time.Millisecond))
+	for _, m := range def.Models {
+		if m.ID == entry.ModelID {
+			// One prompt token in, one token out.
+			detail += fmt.Sprintf(" (~$%.6f)", (m.InputPrice+m.OutputPrice)/1_000_000)
+			break
+		}
+	}
+	return TestResult{Provider: id, OK: true, Detail: detail}
+}
+
+// unreachableDetail explains a failed connection, adding recovery guidance
+// for local servers the user may simply not have started yet.
+func unreachableDetail(id string, err error) string {
+	detail := fmt.Sprintf("unreachable: %v", err)
+	if hint := localServerHint(id); hint != "" {
+		detail += "; " + hint
+	}
+	return detail
+}
+
+// authenticate attaches provider credentials. Anthropic uses its own header
+// scheme; everything else speaks bearer tokens.
+func authenticate(req *http.Request, id string, entry *config.ProviderConfig) {
+	if entry.APIKey == "" {
+		return
+	}
+	if id == "anthropic" {
+		req.Header.Set("x-api-key", entry.APIKey)
+		req.Header.Set("anthropic-version", "2023-06-01")
+		return
+	}
+	req.Header.Set("Authorization", "Bearer "+entry.APIKey)
+}
@@ -0,0 +1,117 @@
+package wizard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func runTests(t *testing.T, cfg *config.ClineConfig, level TestLevel) map[string]TestResult {
+	t.Helper()
+	registry, err := generated.NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	results := make(chan TestResult)
+	RunConnectionTests(context.Background(), registry, cfg, level, results)
+	got := make(map[string]TestResult)
+	for res := range results {
+		got[res.Provider] = res
+	}
+	return got
+}
+
+func TestRunConnectionTestsStreamsResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.NewClineConfig()
+	reachable := cfg.Provider("openai")
+	reachable.APIKey = "sk-test"
+	reachable.BaseURL = srv.URL
+	missing := cfg.Provider("anthropic")
+	_ = missing // required apiKey left empty on purpose
+
+	got := runTests(t, cfg, LevelAuth)
+	if len(got) != 2 {
+		t.Fatalf("got %d results, want 2: %v", len(got), got)
+	}
+	if !got["openai"].OK {
+		t.Errorf("openai: %+v, want reachable", got["openai"])
+	}
+	if got["anthropic"].OK {
+		t.Errorf("anthropic: %+v, want required-field failure", got["anthropic"])
+	}
+}
+
+func TestLevelConfigStaysOffline(t *testing.T) {
+	cfg := config.NewClineConfig()
+	entry := cfg.Provider("openai")
+	entry.APIKey = "sk-test"
+	entry.BaseURL = "http://127.0.0.1:1" // would fail if dialed
+
+	got := runTests(t, cfg, LevelConfig)
+	if !got["openai"].OK {
+		t.Errorf("openai: %+v, want fields-only pass", got["openai"])
+	}
+}
+
+func TestLevelAuthRejectsBadCredentials(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bad key", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	cfg := config.NewClineConfig()
+	entry := cfg.Provider("openai")
+	entry.APIKey = "sk-wrong"
+	entry.BaseURL = srv.URL
+
+	got := runTests(t, cfg, LevelAuth)
+	if got["openai"].OK || !strings.Contains(got["openai"].Detail, "authentication rejected") {
+		t.Errorf("openai: %+v, want auth rejection", got["openai"])
+	}
+}
+
+func TestLevelCompletionReportsLatency(t *testing.T) {
+	var gotPath, gotAuth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotAuth = r.URL.Path, r.Header.Get("Authorization")
+		w.Write([]byte(`{"choices":[]}`))
+	}))
+	defer srv.Close()
+
+	cfg := config.NewClineConfig()
+	entry := cfg.Provider("openai")
+	entry.APIKey = "sk-test"
+	entry.BaseURL = srv.URL
+	entry.ModelID = "gpt-4.1"
+
+	got := runTests(t, cfg, LevelCompletion)
+	res := got["openai"]
+	if !res.OK || !strings.Contains(res.Detail, "completed in") {
+		t.Errorf("openai: %+v", res)
+	}
+	if !strings.Contains(res.Detail, "$") {
+		t.Errorf("detail %q missing cost estimate", res.Detail)
+	}
+	if gotPath != "/chat/completions" || gotAuth != "Bearer sk-test" {
+		t.Errorf("request = %s auth=%q", gotPath, gotAuth)
+	}
+}
+
+func TestParseTestLevel(t *testing.T) {
+	if lvl, err := ParseTestLevel("completion"); err != nil || lvl != LevelCompletion {
+		t.Errorf("ParseTestLevel(completion) = %v, %v", lvl, err)
+	}
+	if _, err := ParseTestLevel("deep"); err == nil {
+		t.Error("invalid level accepted")
+	}
+}
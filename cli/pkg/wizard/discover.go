@@ -0,0 +1,179 @@
+package wizard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// discoverTimeout bounds one port probe; local servers answer in milliseconds
+// and closed ports fail immediately, so this only matters for firewalled
+// setups that silently drop packets.
+const discoverTimeout = 750 * time.Millisecond
+
+// localServer describes one well-known local model server to probe.
+type localServer struct {
+	ProviderID string
+	Name       string
+	BaseURL    string
+	// ModelsPath lists the server's loaded models, relative to BaseURL.
+	ModelsPath string
+}
+
+// localServers covers the ports local inference stacks bind by default.
+var localServers = []localServer{
+	{ProviderID: "ollama", Name: "Ollama", BaseURL: "http://localhost:11434", ModelsPath: "/api/tags"},
+	{ProviderID: "lmstudio", Name: "LM Studio", BaseURL: "http://localhost:1234/v1", ModelsPath: "/models"},
+	{ProviderID: "vllm", Name: "vLLM", BaseURL: "http://localhost:8000/v1", ModelsPath: "/models"},
+}
+
+// Discovered is one local server that answered a probe.
+type Discovered struct {
+	ProviderID string
+	Name       string
+	BaseURL    string
+	Models     []string
+}
+
+// DiscoverLocalServers probes the well-known local ports concurrently and
+// returns the servers that answered, with the models they report as loaded.
+// It never returns an error: an unreachable port just means nothing is there.
+func DiscoverLocalServers(ctx context.Context) []Discovered {
+	results := make([]*Discovered, len(localServers))
+	var wg sync.WaitGroup
+	for i, server := range localServers {
+		wg.Add(1)
+		go func(i int, server localServer) {
+			defer wg.Done()
+			results[i] = probeLocalServer(ctx, server)
+		}(i, server)
+	}
+	wg.Wait()
+	var found []Discovered
+	for _, r := range results {
+		if r != nil {
+			found = append(found, *r)
+		}
+	}
+	return found
+}
+
+func probeLocalServer(ctx context.Context, server localServer) *Discovered {
+	ctx, cancel := context.WithTimeout(ctx, discoverTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.BaseURL+server.ModelsPath, nil)
+	if err != nil {
+		return nil
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil
+	}
+	// Ollama's /api/tags and the OpenAI-compatible /models shapes both appear
+	// here; decode whichever fields are present.
+	var decoded struct {
+		Models []struct {
+			Name string `json:"name"`
+		} `json:"models"`
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+		return nil
+	}
+	found := &Discovered{ProviderID: server.ProviderID, Name: server.Name, BaseURL: server.BaseURL}
+	for _, m := range decoded.Models {
+		found.Models = append(found.Models, m.Name)
+	}
+	for _, m := range decoded.Data {
+		found.Models = append(found.Models, m.ID)
+	}
+	return found
+}
+
+// ConfigureDiscovered saves a discovered local server as a configured
+// provider, picking its first reported model when the entry has none yet.
+func (w *SetupWizard) ConfigureDiscovered(d Discovered) error {
+	def, err := w.registry.Provider(d.ProviderID)
+	if err != nil {
+		return err
+	}
+	cfg, err := w.manager.Config()
+	if err != nil {
+		return err
+	}
+	entry := cfg.Provider(d.ProviderID)
+	entry.BaseURL = d.BaseURL
+	if entry.ModelID == "" && len(d.Models) > 0 {
+		entry.ModelID = d.Models[0]
+	}
+	// Local OpenAI-compatible servers ignore authentication but the provider
+	// definition may still require a key field; a placeholder keeps required
+	// field validation honest without inventing a credential.
+	for _, field := range def.Fields {
+		if field.Required && field.Secret && entry.Field(field.Name) == "" {
+			entry.SetField(field.Name, "not-needed")
+		}
+	}
+	if cfg.DefaultProvider == "" {
+		cfg.DefaultProvider = d.ProviderID
+	}
+	w.manager.MarkDirty()
+	if err := w.confirmRetention(def, cfg); err != nil {
+		return err
+	}
+	return w.manager.Save(cfg)
+}
+
+// OfferDiscovered presents discovered servers and configures the one the
+// user picks. A blank answer declines without error.
+func (w *SetupWizard) OfferDiscovered(found []Discovered) error {
+	fmt.Fprintf(w.out, "Found %d local model server(s):\n", len(found))
+	for i, d := range found {
+		models := "no models loaded"
+		if len(d.Models) > 0 {
+			models = strings.Join(previewList(d.Models, 3), ", ")
+		}
+		fmt.Fprintf(w.out, "  %d. %s at %s (%s)\n", i+1, d.Name, d.BaseURL, models)
+	}
+	answer, err := w.prompt(generated.ConfigField{
+		Name:  "discovered",
+		Label: fmt.Sprintf("Configure which? (1-%d, blank to skip)", len(found)),
+	})
+	if err != nil {
+		// Closed input declines, same as a blank answer.
+		return nil
+	}
+	if answer == "" {
+		return nil
+	}
+	var pick int
+	if _, err := fmt.Sscanf(answer, "%d", &pick); err != nil || pick < 1 || pick > len(found) {
+		return fmt.Errorf("invalid choice %q", answer)
+	}
+	choice := found[pick-1]
+	if err := w.ConfigureDiscovered(choice); err != nil {
+		return err
+	}
+	fmt.Fprintf(w.out, "Configured %s (%s).\n", choice.Name, choice.ProviderID)
+	return nil
+}
+
+// previewList truncates a list for display.
+func previewList(items []string, max int) []string {
+	if len(items) <= max {
+		return items
+	}
+	return append(append([]string(nil), items[:max]...), fmt.Sprintf("+%d more", len(items)-max))
+}
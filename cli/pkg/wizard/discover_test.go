@@ -0,0 +1,39 @@
+package wizard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDiscoverLocalServers(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/tags", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"models": [{"name": "llama3:8b"}, {"name": "qwen2:7b"}]}`))
+	})
+	mux.HandleFunc("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": [{"id": "local-model"}]}`))
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	saved := localServers
+	defer func() { localServers = saved }()
+	localServers = []localServer{
+		{ProviderID: "ollama", Name: "Ollama", BaseURL: srv.URL, ModelsPath: "/api/tags"},
+		{ProviderID: "lmstudio", Name: "LM Studio", BaseURL: srv.URL + "/v1", ModelsPath: "/models"},
+		{ProviderID: "openai", Name: "vLLM", BaseURL: "http://127.0.0.1:1", ModelsPath: "/models"},
+	}
+
+	found := DiscoverLocalServers(context.Background())
+	if len(found) != 2 {
+		t.Fatalf("found = %+v", found)
+	}
+	if found[0].ProviderID != "ollama" || len(found[0].Models) != 2 || found[0].Models[0] != "llama3:8b" {
+		t.Errorf("ollama = %+v", found[0])
+	}
+	if found[1].ProviderID != "lmstudio" || len(found[1].Models) != 1 || found[1].Models[0] != "local-model" {
+		t.Errorf("lmstudio = %+v", found[1])
+	}
+}
@@ -0,0 +1,98 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/clipboard"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// printKeySource shows where to create the provider's API key before the
+// secret prompt: an OSC 8 hyperlink most terminals make clickable, and
+// optionally a QR code for opening the dashboard on a phone.
+func (w *SetupWizard) printKeySource(def *generated.ProviderDefinition) {
+	if def.APIKeyURL == "" {
+		return
+	}
+	fmt.Fprintf(w.out, "Create an API key at: %s\n", hyperlink(def.APIKeyURL))
+	if !w.ShowQR {
+		return
+	}
+	qr, err := renderQR(def.APIKeyURL)
+	if err != nil {
+		fmt.Fprintf(w.out, "(no QR code: %v)\n", err)
+		return
+	}
+	fmt.Fprintln(w.out, qr)
+}
+
+// hyperlink wraps a URL in the OSC 8 escape so terminals render it clickable;
+// terminals without support just show the URL text.
+func hyperlink(url string) string {
+	return "\x1b]8;;" + url + "\x1b\\" + url + "\x1b]8;;\x1b\\"
+}
+
+// renderQR shells out to qrencode, leaning on a platform tool the way the
+// clipboard package does rather than carrying an encoder.
+func renderQR(url string) (string, error) {
+	if _, err := exec.LookPath("qrencode"); err != nil {
+		return "", fmt.Errorf("qrencode is not installed")
+	}
+	out, err := exec.Command("qrencode", "-t", "ANSIUTF8", "-o", "-", url).Output()
+	if err != nil {
+		return "", fmt.Errorf("running qrencode: %w", err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
+
+// keyPatterns recognizes provider API key formats, anchored so arbitrary
+// clipboard contents (code, prose) are never mistaken for a key.
+var keyPatterns = map[string]*regexp.Regexp{
+	"anthropic":  regexp.MustCompile(`^sk-ant-[A-Za-z0-9_-]{24,}$`),
+	"openai":     regexp.MustCompile(`^sk-[A-Za-z0-9_-]{24,}$`),
+	"openrouter": regexp.MustCompile(`^sk-or-[A-Za-z0-9_-]{24,}$`),
+	"gemini":     regexp.MustCompile(`^AIza[A-Za-z0-9_-]{30,}$`),
+	"xai":        regexp.MustCompile(`^xai-[A-Za-z0-9]{24,}$`),
+	"deepseek":   regexp.MustCompile(`^sk-[a-z0-9]{24,}$`),
+	"github":     regexp.MustCompile(`^(ghp_[A-Za-z0-9]{30,}|github_pat_[A-Za-z0-9_]{30,})$`),
+}
+
+// clipboardWatchInterval and clipboardWatchTimeout pace the poll loop; tests
+// shorten both.
+var (
+	clipboardWatchInterval = 500 * time.Millisecond
+	clipboardWatchTimeout  = 2 * time.Minute
+)
+
+// readClipboard is swapped in tests.
+var readClipboard = clipboard.Read
+
+// watchClipboard polls the clipboard until something matching the provider's
+// key format is copied, so the key never has to be typed into the terminal.
+func watchClipboard(ctx context.Context, providerID string) (string, error) {
+	pattern := keyPatterns[providerID]
+	if pattern == nil {
+		return "", fmt.Errorf("no known key format for %s", providerID)
+	}
+	ctx, cancel := context.WithTimeout(ctx, clipboardWatchTimeout)
+	defer cancel()
+	ticker := time.NewTicker(clipboardWatchInterval)
+	defer ticker.Stop()
+	for {
+		if text, err := readClipboard(); err == nil {
+			if key := strings.TrimSpace(text); pattern.MatchString(key) {
+				return key, nil
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("no %s key appeared on the clipboard", providerID)
+		case <-ticker.C:
+		}
+	}
+}
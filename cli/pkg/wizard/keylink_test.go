@@ -0,0 +1,41 @@
+package wizard
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchClipboard(t *testing.T) {
+	origRead, origInterval := readClipboard, clipboardWatchInterval
+	defer func() { readClipboard, clipboardWatchInterval = origRead, origInterval }()
+	clipboardWatchInterval = time.Millisecond
+
+	contents := []string{"some prose", "sk-or-" + strings.Repeat("a", 30)}
+	readClipboard = func() (string, error) {
+		next := contents[0]
+		if len(contents) > 1 {
+			contents = contents[1:]
+		}
+		return next, nil
+	}
+	key, err := watchClipboard(context.Background(), "openrouter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(key, "sk-or-") {
+		t.Errorf("key = %q", key)
+	}
+	if _, err := watchClipboard(context.Background(), "bedrock"); err == nil {
+		t.Error("provider without a key format should error")
+	}
+}
+
+func TestHyperlink(t *testing.T) {
+	link := hyperlink("https://openrouter.ai/keys")
+	if !strings.Contains(link, "\x1b]8;;https://openrouter.ai/keys\x1b\\") ||
+		!strings.Contains(link, "https://openrouter.ai/keys\x1b]8;;\x1b\\") {
+		t.Errorf("link = %q", link)
+	}
+}
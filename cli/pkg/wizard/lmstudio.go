@@ -0,0 +1,114 @@
+package wizard
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// A refused connection to LM Studio almost always means the app's local
+// server was never started, not that anything is misconfigured. These helpers
+// turn that into guidance — or an offer to start the server — instead of a
+// bare dial error.
+
+// Swapped in tests.
+var (
+	lookPath = exec.LookPath
+	statPath = os.Stat
+)
+
+// localServerHint explains a refused local-server connection; empty for
+// providers without one.
+func localServerHint(id string) string {
+	if id != "lmstudio" {
+		return ""
+	}
+	if _, err := lookPath("lms"); err == nil {
+		return "LM Studio is installed but its server is not running; start it with `lms server start`"
+	}
+	if lmStudioInstalled() {
+		return "LM Studio is installed but its server is not running; open the app's Developer tab and click \"Start Server\" (default port 1234)"
+	}
+	return "LM Studio does not appear to be installed; download it from https://lmstudio.ai"
+}
+
+// lmStudioInstalled looks for the app in its per-platform install location.
+func lmStudioInstalled() bool {
+	var candidates []string
+	switch runtime.GOOS {
+	case "darwin":
+		candidates = []string{"/Applications/LM Studio.app"}
+	case "windows":
+		candidates = []string{filepath.Join(os.Getenv("LOCALAPPDATA"), "Programs", "LM Studio")}
+	default:
+		if home, err := os.UserHomeDir(); err == nil {
+			candidates = []string{filepath.Join(home, ".lmstudio")}
+		}
+	}
+	for _, path := range candidates {
+		if _, err := statPath(path); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ensureLMStudioRunning probes the entry's endpoint during setup and, when it
+// is down and the `lms` CLI is available, offers to start the server right
+// there. Declining (or a missing CLI) just prints the recovery steps; setup
+// still succeeds so the config is ready once the server is up.
+func (w *SetupWizard) ensureLMStudioRunning(def *generated.ProviderDefinition, entry *config.ProviderConfig) error {
+	endpoint := entry.BaseURL
+	if endpoint == "" {
+		endpoint = def.BaseURL
+	}
+	if endpoint == "" || probeEndpoint(endpoint) {
+		return nil
+	}
+	fmt.Fprintf(w.out, "%s is not responding at %s.\n", def.Name, endpoint)
+	if _, err := lookPath("lms"); err != nil {
+		fmt.Fprintf(w.out, "%s.\n", localServerHint(def.ID))
+		return nil
+	}
+	answer, err := w.prompt(generated.ConfigField{
+		Name:  "startServer",
+		Label: "Start the LM Studio server now with `lms server start`? (yes/no)",
+	})
+	if err != nil {
+		return err
+	}
+	if answer != "yes" && answer != "y" {
+		fmt.Fprintln(w.out, "Start it later with `lms server start`.")
+		return nil
+	}
+	if out, err := exec.Command("lms", "server", "start").CombinedOutput(); err != nil {
+		return fmt.Errorf("starting LM Studio server: %v (%s)", err, strings.TrimSpace(string(out)))
+	}
+	fmt.Fprintln(w.out, "LM Studio server started.")
+	return nil
+}
+
+// probeEndpoint reports whether an OpenAI-compatible endpoint answers its
+// /models route within the discovery timeout.
+func probeEndpoint(baseURL string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), discoverTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimSuffix(baseURL, "/")+"/models", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
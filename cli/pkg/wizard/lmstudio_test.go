@@ -0,0 +1,33 @@
+package wizard
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestLocalServerHint(t *testing.T) {
+	origLook, origStat := lookPath, statPath
+	defer func() { lookPath, statPath = origLook, origStat }()
+
+	if hint := localServerHint("openai"); hint != "" {
+		t.Errorf("remote provider got a hint: %q", hint)
+	}
+
+	lookPath = func(string) (string, error) { return "/usr/local/bin/lms", nil }
+	if hint := localServerHint("lmstudio"); !strings.Contains(hint, "lms server start") {
+		t.Errorf("hint with lms installed = %q", hint)
+	}
+
+	lookPath = func(string) (string, error) { return "", fmt.Errorf("not found") }
+	statPath = func(string) (os.FileInfo, error) { return nil, nil }
+	if hint := localServerHint("lmstudio"); !strings.Contains(hint, "Start Server") {
+		t.Errorf("hint with app installed = %q", hint)
+	}
+
+	statPath = func(string) (os.FileInfo, error) { return nil, os.ErrNotExist }
+	if hint := localServerHint("lmstudio"); !strings.Contains(hint, "lmstudio.ai") {
+		t.Errorf("hint with nothing installed = %q", hint)
+	}
+}
@@ -0,0 +1,134 @@
+package wizard
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// An OpenRouter key only reaches the upstreams the account has credits or
+// BYOK keys for; a model from anywhere else 404s on the first task. Setup
+// therefore asks OpenRouter which models this key can actually see and shows
+// the per-upstream breakdown.
+
+const openRouterKeyCheckTimeout = 5 * time.Second
+
+// OpenRouterKeyInfo is what the key endpoints report about the user's key.
+type OpenRouterKeyInfo struct {
+	Label    string
+	Usage    float64
+	Limit    float64 // 0 means no spend limit
+	FreeTier bool
+	// Upstreams counts the models this key can reach per upstream provider.
+	Upstreams []UpstreamModels
+}
+
+// UpstreamModels is one upstream provider's share of the reachable models.
+type UpstreamModels struct {
+	Name   string
+	Models int
+}
+
+// CheckOpenRouterKey queries OpenRouter for the key's metadata and the model
+// list as filtered for this key (which reflects BYOK and credit state).
+func CheckOpenRouterKey(ctx context.Context, entry *config.ProviderConfig) (*OpenRouterKeyInfo, error) {
+	base := strings.TrimSuffix(entry.BaseURL, "/")
+	if base == "" {
+		base = "https://openrouter.ai/api/v1"
+	}
+	info := &OpenRouterKeyInfo{}
+	var key struct {
+		Data struct {
+			Label      string  `json:"label"`
+			Usage      float64 `json:"usage"`
+			Limit      float64 `json:"limit"`
+			IsFreeTier bool    `json:"is_free_tier"`
+		} `json:"data"`
+	}
+	if err := openRouterGet(ctx, base+"/key", entry.APIKey, &key); err != nil {
+		return nil, fmt.Errorf("checking OpenRouter key: %w", err)
+	}
+	info.Label = key.Data.Label
+	info.Usage = key.Data.Usage
+	info.Limit = key.Data.Limit
+	info.FreeTier = key.Data.IsFreeTier
+
+	var models struct {
+		Data []struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := openRouterGet(ctx, base+"/models/user", entry.APIKey, &models); err != nil {
+		return nil, fmt.Errorf("listing models for this key: %w", err)
+	}
+	counts := make(map[string]int)
+	for _, m := range models.Data {
+		if upstream, _, ok := strings.Cut(m.ID, "/"); ok {
+			counts[upstream]++
+		}
+	}
+	for name, n := range counts {
+		info.Upstreams = append(info.Upstreams, UpstreamModels{Name: name, Models: n})
+	}
+	sort.Slice(info.Upstreams, func(i, j int) bool { return info.Upstreams[i].Name < info.Upstreams[j].Name })
+	return info, nil
+}
+
+func openRouterGet(ctx context.Context, url, apiKey string, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, openRouterKeyCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+apiKey)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// reportOpenRouterAccess prints the key's reach during setup. Failures only
+// warn: the key may still work, and setup should not hinge on a metadata
+// endpoint.
+func (w *SetupWizard) reportOpenRouterAccess(def *generated.ProviderDefinition, entry *config.ProviderConfig) {
+	info, err := CheckOpenRouterKey(context.Background(), entry)
+	if err != nil {
+		fmt.Fprintf(w.out, "warning: could not verify key access: %v\n", err)
+		return
+	}
+	label := info.Label
+	if label == "" {
+		label = "unnamed"
+	}
+	if info.Limit > 0 {
+		fmt.Fprintf(w.out, "OpenRouter key %q: $%.2f of $%.2f used", label, info.Usage, info.Limit)
+	} else {
+		fmt.Fprintf(w.out, "OpenRouter key %q: $%.2f used, no spend limit", label, info.Usage)
+	}
+	if info.FreeTier {
+		fmt.Fprint(w.out, " (free tier)")
+	}
+	fmt.Fprintln(w.out)
+	if len(info.Upstreams) == 0 {
+		fmt.Fprintln(w.out, "No upstream providers are reachable with this key; add credits or BYOK keys at https://openrouter.ai/settings/integrations")
+		return
+	}
+	fmt.Fprintln(w.out, "Upstream providers reachable with this key:")
+	for _, u := range info.Upstreams {
+		fmt.Fprintf(w.out, "  %-20s %d model(s)\n", u.Name, u.Models)
+	}
+	fmt.Fprintln(w.out, "Upstreams missing above need credits or a BYOK key (https://openrouter.ai/settings/integrations).")
+}
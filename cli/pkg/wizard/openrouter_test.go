@@ -0,0 +1,47 @@
+package wizard
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+)
+
+func TestCheckOpenRouterKey(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Header.Get("Authorization") != "Bearer sk-or-test" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch req.URL.Path {
+		case "/key":
+			rw.Write([]byte(`{"data":{"label":"ci","usage":1.5,"limit":10,"is_free_tier":false}}`))
+		case "/models/user":
+			rw.Write([]byte(`{"data":[{"id":"anthropic/claude-sonnet-4"},{"id":"anthropic/claude-3-5-haiku"},{"id":"openai/gpt-4.1"}]}`))
+		default:
+			http.NotFound(rw, req)
+		}
+	}))
+	defer srv.Close()
+
+	entry := &config.ProviderConfig{Provider: "openrouter", APIKey: "sk-or-test", BaseURL: srv.URL}
+	info, err := CheckOpenRouterKey(context.Background(), entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Label != "ci" || info.Usage != 1.5 || info.Limit != 10 || info.FreeTier {
+		t.Errorf("key info = %+v", info)
+	}
+	if len(info.Upstreams) != 2 ||
+		info.Upstreams[0] != (UpstreamModels{Name: "anthropic", Models: 2}) ||
+		info.Upstreams[1] != (UpstreamModels{Name: "openai", Models: 1}) {
+		t.Errorf("upstreams = %+v", info.Upstreams)
+	}
+
+	entry.APIKey = "wrong"
+	if _, err := CheckOpenRouterKey(context.Background(), entry); err == nil {
+		t.Error("rejected key did not error")
+	}
+}
@@ -0,0 +1,72 @@
+package wizard
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// FieldError identifies one missing or invalid provider field, including the
+// prompt that collects it so users know exactly what to answer.
+type FieldError struct {
+	Provider string
+	Field    string
+	Label    string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("provider %s: required field %q is not set (prompted as %q; run `cline auth add --provider %s`)",
+		e.Provider, e.Field, e.Label, e.Provider)
+}
+
+// ValidateRequiredFields checks every required field of def against entry and
+// returns all problems joined into one error, so users can fix everything in
+// a single pass rather than iterating failure-by-failure.
+func ValidateRequiredFields(def *generated.ProviderDefinition, entry *config.ProviderConfig) error {
+	var errs []error
+	for _, field := range def.Fields {
+		if field.Required && entry.Field(field.Name) == "" {
+			errs = append(errs, &FieldError{Provider: def.ID, Field: field.Name, Label: field.Label})
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ValidateAll validates every configured provider concurrently and returns
+// the aggregated problems, ordered by provider ID.
+func ValidateAll(registry *generated.ProviderRegistry, cfg *config.ClineConfig) []error {
+	ids := sortedProviderIDs(cfg)
+	errsByID := make([]error, len(ids))
+	var wg sync.WaitGroup
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			def, err := registry.Provider(id)
+			if err != nil {
+				errsByID[i] = err
+				return
+			}
+			errsByID[i] = ValidateRequiredFields(def, cfg.Providers[id])
+		}(i, id)
+	}
+	wg.Wait()
+	var flat []error
+	for _, err := range errsByID {
+		if err == nil {
+			continue
+		}
+		var joined interface{ Unwrap() []error }
+		if errors.As(err, &joined) {
+			flat = append(flat, joined.Unwrap()...)
+		} else {
+			flat = append(flat, err)
+		}
+	}
+	sort.SliceStable(flat, func(i, j int) bool { return flat[i].Error() < flat[j].Error() })
+	return flat
+}
@@ -0,0 +1,57 @@
+package wizard
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestValidateRequiredFieldsAggregatesAllProblems(t *testing.T) {
+	registry, err := generated.NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	def, err := registry.Provider("bedrock") // three required fields
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := &config.ProviderConfig{Provider: "bedrock"}
+	entry.SetField("awsRegion", "us-east-1")
+
+	err = ValidateRequiredFields(def, entry)
+	if err == nil {
+		t.Fatal("validation passed with missing credentials")
+	}
+	var fieldErr *FieldError
+	if !errors.As(err, &fieldErr) {
+		t.Fatalf("error %T does not wrap FieldError", err)
+	}
+	msg := err.Error()
+	for _, want := range []string{"awsAccessKey", "awsSecretKey"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("aggregated error missing %s: %s", want, msg)
+		}
+	}
+	if strings.Contains(msg, "awsRegion") {
+		t.Errorf("aggregated error flags a field that is set: %s", msg)
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	registry, err := generated.NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.NewClineConfig()
+	cfg.Provider("openai").APIKey = "sk-test"
+	cfg.Provider("anthropic") // apiKey missing
+	cfg.Provider("gemini")    // apiKey missing
+
+	errs := ValidateAll(registry, cfg)
+	if len(errs) != 2 {
+		t.Fatalf("got %d errors, want 2: %v", len(errs), errs)
+	}
+}
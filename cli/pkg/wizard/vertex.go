@@ -0,0 +1,70 @@
+package wizard
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+// Vertex serves each model from a handful of regions, and a mismatch only
+// surfaces as a 404 on the first real request. The compatibility table below
+// lets setup reject the combination immediately instead.
+
+// vertexModelRegions maps catalog model IDs to the regions serving them.
+// Models missing from the table are not constrained.
+var vertexModelRegions = map[string][]string{
+	"claude-sonnet-4@20250514": {"us-east5", "europe-west1", "asia-east1"},
+	"gemini-2.5-pro":           {"us-central1", "us-east5", "europe-west1", "europe-west4", "asia-northeast1"},
+}
+
+// ValidateVertexModelRegion reports whether the model is served in the
+// region, with the serving regions in the error so the fix is obvious.
+func ValidateVertexModelRegion(modelID, region string) error {
+	regions, known := vertexModelRegions[modelID]
+	if !known {
+		return nil
+	}
+	for _, r := range regions {
+		if r == region {
+			return nil
+		}
+	}
+	return fmt.Errorf("model %q is not served in %s (available in: %s)", modelID, region, strings.Join(regions, ", "))
+}
+
+// vertexRegions returns every region named in the compatibility table, for
+// the error shown when a region serves nothing.
+func vertexRegions() []string {
+	seen := make(map[string]bool)
+	var regions []string
+	for _, list := range vertexModelRegions {
+		for _, r := range list {
+			if !seen[r] {
+				seen[r] = true
+				regions = append(regions, r)
+			}
+		}
+	}
+	sort.Strings(regions)
+	return regions
+}
+
+// checkVertexRegion validates the entry's model/region combination at setup
+// time. An empty model falls forward to the default, which is validated too.
+func (w *SetupWizard) checkVertexRegion(def *generated.ProviderDefinition, entry *config.ProviderConfig) error {
+	region := entry.Field("region")
+	if region == "" {
+		return nil
+	}
+	modelID := entry.ModelID
+	if modelID == "" {
+		modelID = def.DefaultModelID
+	}
+	if err := ValidateVertexModelRegion(modelID, region); err != nil {
+		return fmt.Errorf("%w; known Vertex regions: %s", err, strings.Join(vertexRegions(), ", "))
+	}
+	return nil
+}
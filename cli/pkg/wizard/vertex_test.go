@@ -0,0 +1,22 @@
+package wizard
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateVertexModelRegion(t *testing.T) {
+	if err := ValidateVertexModelRegion("claude-sonnet-4@20250514", "us-east5"); err != nil {
+		t.Errorf("valid combination rejected: %v", err)
+	}
+	err := ValidateVertexModelRegion("claude-sonnet-4@20250514", "us-central1")
+	if err == nil {
+		t.Fatal("mismatched region accepted")
+	}
+	if !strings.Contains(err.Error(), "us-east5") {
+		t.Errorf("error does not name a serving region: %v", err)
+	}
+	if err := ValidateVertexModelRegion("some-future-model", "us-central1"); err != nil {
+		t.Errorf("unknown model constrained: %v", err)
+	}
+}
@@ -0,0 +1,436 @@
+// Package wizard implements the interactive provider setup flows: the full
+// guided wizard, the single-provider FastSetup used by `cline auth add`, and
+// the connection test loop.
+package wizard
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+	"github.com/cline/cline/cli/pkg/guardrails"
+	"github.com/cline/cline/cli/pkg/theme"
+)
+
+// SetupWizard drives interactive provider configuration on a terminal.
+type SetupWizard struct {
+	in       *bufio.Reader
+	out      io.Writer
+	registry *generated.ProviderRegistry
+	manager  *config.ConfigManager
+	// prompter answers the wizard's questions: a terminal reader by default,
+	// a ScriptedPrompter in tests.
+	prompter Prompter
+	// getenv resolves process environment variables. Tests replace it so a
+	// developer's real keys never leak into a run.
+	getenv func(string) string
+	// env holds extra variables (e.g. from a dotenv file) consulted before
+	// the process environment when filling fields.
+	env map[string]string
+	// RequireRetentionAck (from the repo policy) makes setup insist the
+	// provider's data-retention notice is acknowledged before saving.
+	RequireRetentionAck bool
+	// ShowQR renders the provider's API-key URL as a terminal QR code so the
+	// dashboard can be opened on a phone.
+	ShowQR bool
+	// ClipboardWatch fills secret fields by watching the clipboard for a
+	// string matching the provider's key format instead of prompting.
+	ClipboardWatch bool
+	// Theme colors status output; colorless until a caller provides one.
+	Theme *theme.Theme
+}
+
+// NewSetupWizard builds a wizard over the given streams.
+func NewSetupWizard(in io.Reader, out io.Writer, registry *generated.ProviderRegistry, manager *config.ConfigManager) *SetupWizard {
+	w := &SetupWizard{in: bufio.NewReader(in), out: out, registry: registry, manager: manager, getenv: os.Getenv, Theme: theme.None()}
+	w.prompter = &streamPrompter{w: w}
+	return w
+}
+
+// Prompter answers a wizard question. The default implementation reads the
+// wizard's input stream; tests install a ScriptedPrompter to drive full
+// flows without a terminal.
+type Prompter interface {
+	Ask(field generated.ConfigField) (string, error)
+}
+
+// SetPrompter replaces how the wizard collects answers.
+func (w *SetupWizard) SetPrompter(p Prompter) {
+	w.prompter = p
+}
+
+// SetGetenv replaces the process-environment lookup used when filling
+// fields. The default is os.Getenv.
+func (w *SetupWizard) SetGetenv(getenv func(string) string) {
+	w.getenv = getenv
+}
+
+// streamPrompter is the terminal Prompter: it writes the question to the
+// wizard's output and reads one line of input.
+type streamPrompter struct {
+	w *SetupWizard
+}
+
+func (p *streamPrompter) Ask(field generated.ConfigField) (string, error) {
+	// A leading "*" mirrors editor convention: there are unsaved changes in
+	// this session.
+	marker := ""
+	if p.w.manager.Dirty() {
+		marker = "* "
+	}
+	fmt.Fprintf(p.w.out, "%s%s: ", marker, field.Label)
+	line, err := p.w.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", fmt.Errorf("reading %s: %w", field.Name, err)
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// ScriptedPrompter answers questions from a fixed script keyed by field
+// name, so tests can exercise complete interactive flows.
+type ScriptedPrompter struct {
+	// Answers maps field names to the answer to give. An explicit empty
+	// string is a valid blank answer; a missing name is an error, so a test
+	// fails loudly when a flow asks something its script did not expect.
+	Answers map[string]string
+}
+
+func (p *ScriptedPrompter) Ask(field generated.ConfigField) (string, error) {
+	answer, ok := p.Answers[field.Name]
+	if !ok {
+		return "", fmt.Errorf("no scripted answer for %q", field.Name)
+	}
+	return answer, nil
+}
+
+// SetEnv supplies extra variables, typically loaded from a dotenv file, that
+// take precedence over the process environment during setup.
+func (w *SetupWizard) SetEnv(env map[string]string) {
+	w.env = env
+}
+
+// lookupEnv resolves a field value from the extra env or the process
+// environment, trying the field's declared variable first and then the
+// conventional <PROVIDER>_<FIELD> name (e.g. OPENAI_API_KEY).
+func (w *SetupWizard) lookupEnv(providerID string, field generated.ConfigField) string {
+	names := []string{field.EnvVar, conventionalEnvVar(providerID, field.Name)}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if v, ok := w.env[name]; ok && v != "" {
+			return v
+		}
+	}
+	for _, name := range names {
+		if name == "" {
+			continue
+		}
+		if v := w.getenv(name); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// conventionalEnvVar maps a provider and camelCase field name to the
+// SCREAMING_SNAKE variable teams put in .env files: ("openai", "apiKey")
+// becomes OPENAI_API_KEY.
+func conventionalEnvVar(providerID, fieldName string) string {
+	var b strings.Builder
+	for _, r := range providerID {
+		if r == '-' || r == '.' {
+			b.WriteByte('_')
+			continue
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	b.WriteByte('_')
+	for i, r := range fieldName {
+		if r == '.' {
+			b.WriteByte('_')
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			b.WriteByte('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// optionalSettings are client-side knobs available for every provider on top
+// of its generated fields: the retry/fallback policy applied to 429 and 5xx
+// responses. They are filled from the environment (e.g. OPENAI_RETRY_MAX_ATTEMPTS)
+// and never prompted for.
+var optionalSettings = []generated.ConfigField{
+	{Name: "retry.maxAttempts", Label: "Max request attempts (including the first)"},
+	{Name: "retry.maxBackoffMs", Label: "Backoff cap between retries in milliseconds"},
+	{Name: "retry.fallbackProvider", Label: "Provider to fall back to on rate limits and server errors"},
+	{Name: "promptCache.enabled", Label: "Prompt caching for models that support it (true/false)"},
+	{Name: "promptCache.breakpoints", Label: "Cache breakpoints strategy (final/all)"},
+	{Name: "requestTimeoutMs", Label: "Request timeout in milliseconds"},
+	{Name: "connectTimeoutMs", Label: "Connect timeout in milliseconds"},
+	{Name: "keepAliveSeconds", Label: "TCP keepalive interval in seconds"},
+}
+
+// FastSetup configures a single provider non-interactively where possible:
+// required fields are filled from environment variables when set, otherwise
+// prompted for, and the result is saved immediately.
+func (w *SetupWizard) FastSetup(providerID string) error {
+	def, err := w.registry.Provider(providerID)
+	if err != nil {
+		return err
+	}
+	cfg, err := w.manager.Config()
+	if err != nil {
+		return err
+	}
+	entry := cfg.Provider(providerID)
+	setField := func(name, value string) {
+		entry.SetField(name, value)
+		w.manager.MarkDirty()
+	}
+	fields := append(append([]generated.ConfigField(nil), def.Fields...), optionalSettings...)
+	for _, field := range fields {
+		if entry.Field(field.Name) != "" {
+			continue
+		}
+		if v := w.lookupEnv(providerID, field); v != "" {
+			setField(field.Name, v)
+			continue
+		}
+		if !field.Required {
+			if field.Default != "" {
+				setField(field.Name, field.Default)
+			}
+			continue
+		}
+		if def.ID == "bedrock" && field.Name == "awsRegion" {
+			region, err := w.promptBedrockRegion()
+			if err != nil {
+				return err
+			}
+			setField(field.Name, region)
+			continue
+		}
+		if field.Secret {
+			w.printKeySource(def)
+			if w.ClipboardWatch {
+				fmt.Fprintf(w.out, "Watching the clipboard for a %s key; copy it from the dashboard...\n", def.Name)
+				if key, err := watchClipboard(context.Background(), def.ID); err == nil {
+					fmt.Fprintln(w.out, "Key detected on the clipboard.")
+					setField(field.Name, key)
+					continue
+				} else {
+					fmt.Fprintf(w.out, "%v; falling back to manual entry.\n", err)
+				}
+			}
+		}
+		value, err := w.prompt(field)
+		if err != nil {
+			return err
+		}
+		setField(field.Name, value)
+	}
+	if def.ID == "bedrock" {
+		if err := w.selectBedrockModel(def, entry); err != nil {
+			return err
+		}
+	}
+	if def.ID == "vertex" {
+		if err := w.checkVertexRegion(def, entry); err != nil {
+			return err
+		}
+	}
+	if def.ID == "lmstudio" {
+		if err := w.ensureLMStudioRunning(def, entry); err != nil {
+			return err
+		}
+	}
+	if def.ID == "openrouter" && entry.APIKey != "" {
+		w.reportOpenRouterAccess(def, entry)
+	}
+	if entry.ModelID == "" {
+		entry.ModelID = def.DefaultModelID
+	}
+	if err := w.promptModelKnobs(def, entry, setField); err != nil {
+		return err
+	}
+	if err := w.confirmModelPrice(def, entry, cfg); err != nil {
+		return err
+	}
+	if err := w.confirmRetention(def, cfg); err != nil {
+		return err
+	}
+	if cfg.DefaultProvider == "" {
+		cfg.DefaultProvider = providerID
+	}
+	if err := ValidateRequiredFields(def, entry); err != nil {
+		return err
+	}
+	return w.manager.Save(cfg)
+}
+
+// confirmModelPrice warns when the selected model's blended price is over the
+// cost.warnThreshold setting and aborts setup unless the user confirms, so
+// nobody lands on o1-class pricing by accident.
+func (w *SetupWizard) confirmModelPrice(def *generated.ProviderDefinition, entry *config.ProviderConfig, cfg *config.ClineConfig) error {
+	var model *generated.ModelInfo
+	for i := range def.Models {
+		if def.Models[i].ID == entry.ModelID {
+			model = &def.Models[i]
+			break
+		}
+	}
+	if model == nil {
+		return nil
+	}
+	warning, err := guardrails.CheckPrice(model, cfg.Settings)
+	if err != nil || warning == nil {
+		return err
+	}
+	fmt.Fprintf(w.out, "%s costs ~$%.2f per million tokens blended ($%.2f in / $%.2f out), over the $%.2f threshold.\n",
+		model.ID, warning.BlendedPrice, model.InputPrice, model.OutputPrice, warning.Threshold)
+	fmt.Fprintf(w.out, "A typical task would run about $%.2f.\n", warning.TypicalTaskUSD)
+	answer, err := w.prompt(generated.ConfigField{
+		Name:  "modelPrice",
+		Label: fmt.Sprintf("Keep %s anyway? (yes/no)", model.ID),
+	})
+	if err != nil {
+		return err
+	}
+	if answer != "yes" && answer != "y" {
+		return fmt.Errorf("setup aborted: %s was not confirmed at its price", model.ID)
+	}
+	return nil
+}
+
+// confirmRetention surfaces the provider's data-retention summary so the user
+// knows where their code goes. When the repo policy demands it, the notice
+// must be acknowledged once per provider; the acknowledgment is recorded in
+// settings so later asks do not prompt again.
+func (w *SetupWizard) confirmRetention(def *generated.ProviderDefinition, cfg *config.ClineConfig) error {
+	if def.DataRetention != "" {
+		fmt.Fprintf(w.out, "Data retention (%s): %s\n", def.Name, def.DataRetention)
+	}
+	if !w.RequireRetentionAck {
+		return nil
+	}
+	key := config.RetentionAckSetting(def.ID)
+	if cfg.Settings[key] == "true" {
+		return nil
+	}
+	answer, err := w.prompt(generated.ConfigField{
+		Name:  key,
+		Label: "Your organization requires acknowledging this notice (yes/no)",
+	})
+	if err != nil {
+		return err
+	}
+	if answer != "yes" && answer != "y" {
+		return fmt.Errorf("setup aborted: data-retention notice for %s was not acknowledged", def.ID)
+	}
+	if cfg.Settings == nil {
+		cfg.Settings = make(map[string]string)
+	}
+	cfg.Settings[key] = "true"
+	w.manager.MarkDirty()
+	return nil
+}
+
+// promptModelKnobs asks for reasoning effort and thinking budget when the
+// selected model supports them and the entry has no value yet. Blank answers
+// leave the knob unset; both default off since they change cost and quality.
+func (w *SetupWizard) promptModelKnobs(def *generated.ProviderDefinition, entry *config.ProviderConfig, setField func(name, value string)) error {
+	var model *generated.ModelInfo
+	for i := range def.Models {
+		if def.Models[i].ID == entry.ModelID {
+			model = &def.Models[i]
+			break
+		}
+	}
+	if model == nil {
+		return nil
+	}
+	knobs := []struct {
+		supported bool
+		field     generated.ConfigField
+	}{
+		{model.SupportsReasoningEffort, generated.ConfigField{
+			Name: "reasoningEffort", Label: "Reasoning effort (low/medium/high, blank for default)",
+		}},
+		{model.SupportsExtendedThinking, generated.ConfigField{
+			Name: "thinkingBudgetTokens", Label: "Extended thinking budget in tokens (blank to disable)",
+		}},
+	}
+	for _, knob := range knobs {
+		if !knob.supported || entry.Field(knob.field.Name) != "" {
+			continue
+		}
+		if v := w.lookupEnv(def.ID, knob.field); v != "" {
+			setField(knob.field.Name, v)
+			continue
+		}
+		value, err := w.prompt(knob.field)
+		if err != nil {
+			return err
+		}
+		if value != "" {
+			setField(knob.field.Name, value)
+		}
+	}
+	return nil
+}
+
+func (w *SetupWizard) prompt(field generated.ConfigField) (string, error) {
+	return w.prompter.Ask(field)
+}
+
+// TestResult is the outcome of testing one configured provider.
+type TestResult struct {
+	Provider string
+	OK       bool
+	Detail   string
+}
+
+// TestProviders probes all configured providers concurrently at the given
+// depth and renders each result as it arrives.
+func (w *SetupWizard) TestProviders(ctx context.Context, level TestLevel) error {
+	cfg, err := w.manager.Config()
+	if err != nil {
+		return err
+	}
+	results := make(chan TestResult)
+	RunConnectionTests(ctx, w.registry, cfg, level, results)
+	failed := 0
+	for res := range results {
+		// Pad before coloring: escape codes would throw off %-4s.
+		status := w.Theme.Success(fmt.Sprintf("%-4s", "ok"))
+		if !res.OK {
+			status = w.Theme.Failure(fmt.Sprintf("%-4s", "FAIL"))
+			failed++
+		}
+		fmt.Fprintf(w.out, "%-14s %s %s\n", res.Provider, status, res.Detail)
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d provider(s) failed", failed)
+	}
+	return nil
+}
+
+func sortedProviderIDs(cfg *config.ClineConfig) []string {
+	ids := make([]string, 0, len(cfg.Providers))
+	for id := range cfg.Providers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
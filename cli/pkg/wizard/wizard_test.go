@@ -0,0 +1,88 @@
+package wizard
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/generated"
+)
+
+func TestConventionalEnvVar(t *testing.T) {
+	cases := []struct{ provider, field, want string }{
+		{"openai", "apiKey", "OPENAI_API_KEY"},
+		{"openai", "baseUrl", "OPENAI_BASE_URL"},
+		{"bedrock", "awsAccessKey", "BEDROCK_AWS_ACCESS_KEY"},
+		{"openai-codex", "apiKey", "OPENAI_CODEX_API_KEY"},
+	}
+	for _, c := range cases {
+		if got := conventionalEnvVar(c.provider, c.field); got != c.want {
+			t.Errorf("conventionalEnvVar(%q, %q) = %q, want %q", c.provider, c.field, got, c.want)
+		}
+	}
+}
+
+func TestFastSetupScriptedFlow(t *testing.T) {
+	registry, err := generated.NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager := config.NewConfigManagerAt(filepath.Join(t.TempDir(), "config.json"))
+	w := NewSetupWizard(strings.NewReader(""), &strings.Builder{}, registry, manager)
+	w.SetGetenv(func(string) string { return "" })
+	w.RequireRetentionAck = true
+	w.SetPrompter(&ScriptedPrompter{Answers: map[string]string{
+		"apiKey":                                "sk-ant-scripted",
+		"thinkingBudgetTokens":                  "",
+		config.RetentionAckSetting("anthropic"): "yes",
+	}})
+	if err := w.FastSetup("anthropic"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := cfg.Provider("anthropic")
+	if entry.APIKey != "sk-ant-scripted" || entry.ModelID == "" {
+		t.Errorf("entry = %+v", entry)
+	}
+	if cfg.DefaultProvider != "anthropic" {
+		t.Errorf("default provider = %q", cfg.DefaultProvider)
+	}
+	if cfg.Settings[config.RetentionAckSetting("anthropic")] != "true" {
+		t.Error("retention ack was not recorded")
+	}
+}
+
+func TestScriptedPrompterRejectsUnexpectedQuestions(t *testing.T) {
+	p := &ScriptedPrompter{Answers: map[string]string{"apiKey": "sk-x"}}
+	if v, err := p.Ask(generated.ConfigField{Name: "apiKey"}); err != nil || v != "sk-x" {
+		t.Errorf("Ask = %q, %v", v, err)
+	}
+	if _, err := p.Ask(generated.ConfigField{Name: "baseUrl"}); err == nil {
+		t.Error("unscripted question did not error")
+	}
+}
+
+func TestFastSetupFillsFieldsFromDotenv(t *testing.T) {
+	registry, err := generated.NewProviderRegistry()
+	if err != nil {
+		t.Fatal(err)
+	}
+	manager := config.NewConfigManagerAt(filepath.Join(t.TempDir(), "config.json"))
+	w := NewSetupWizard(strings.NewReader(""), &strings.Builder{}, registry, manager)
+	w.SetGetenv(func(string) string { return "" })
+	w.SetEnv(map[string]string{"OPENAI_API_KEY": "sk-from-dotenv"})
+	if err := w.FastSetup("openai"); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := manager.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Provider("openai").APIKey; got != "sk-from-dotenv" {
+		t.Errorf("APIKey = %q", got)
+	}
+}
@@ -0,0 +1,197 @@
+// Package workspace summarizes the current repository — its file tree,
+// language mix, and key manifest files — into a compact text block that can
+// be attached to prompts, so the model knows what project it is in without
+// the user pasting directory listings. Summaries are cached per workspace
+// under the data directory.
+package workspace
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/cline/cline/cli/pkg/config"
+	"github.com/cline/cline/cli/pkg/ignore"
+)
+
+// maxTreeDepth bounds how deep the tree section descends.
+const maxTreeDepth = 3
+
+// maxTreeEntries bounds the tree section's size for very large repos.
+const maxTreeEntries = 200
+
+// keyFileLines is how many leading lines of each key file are included.
+const keyFileLines = 30
+
+// skippedDirs are directories that add bulk without describing the project.
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+	"__pycache__":  true,
+}
+
+// keyFiles are manifests worth quoting: whichever exist describe the
+// project better than any generated summary could.
+var keyFiles = []string{
+	"README.md", "README", "go.mod", "package.json", "Cargo.toml",
+	"pyproject.toml", "Makefile",
+}
+
+// Build walks the workspace and renders the summary.
+func Build(root string) (string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return "", err
+	}
+	if !info.IsDir() {
+		return "", fmt.Errorf("%s is not a directory", root)
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Workspace: %s\n", filepath.Base(root))
+	tree, stats := scan(root, ignore.Load(root))
+	b.WriteString("\n## File tree\n")
+	b.WriteString(tree)
+	if len(stats) > 0 {
+		b.WriteString("\n## Languages\n")
+		b.WriteString(renderStats(stats))
+	}
+	for _, name := range keyFiles {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			continue
+		}
+		lines := strings.Split(string(data), "\n")
+		truncated := false
+		if len(lines) > keyFileLines {
+			lines, truncated = lines[:keyFileLines], true
+		}
+		fmt.Fprintf(&b, "\n## %s\n%s\n", name, strings.TrimRight(strings.Join(lines, "\n"), "\n"))
+		if truncated {
+			b.WriteString("[truncated]\n")
+		}
+	}
+	return b.String(), nil
+}
+
+// scan renders the depth-limited tree and collects per-extension file counts
+// in one walk, honoring the workspace's ignore files.
+func scan(root string, ignored *ignore.Matcher) (string, map[string]int) {
+	var b strings.Builder
+	stats := map[string]int{}
+	entries := 0
+	var walk func(dir string, depth int)
+	walk = func(dir string, depth int) {
+		names, err := os.ReadDir(dir)
+		if err != nil {
+			return
+		}
+		for _, entry := range names {
+			name := entry.Name()
+			if strings.HasPrefix(name, ".") || skippedDirs[name] {
+				continue
+			}
+			if rel, err := filepath.Rel(root, filepath.Join(dir, name)); err == nil && ignored.Excluded(rel, entry.IsDir()) {
+				continue
+			}
+			if entry.IsDir() {
+				if depth < maxTreeDepth {
+					if entries < maxTreeEntries {
+						fmt.Fprintf(&b, "%s%s/\n", strings.Repeat("  ", depth), name)
+						entries++
+					}
+					walk(filepath.Join(dir, name), depth+1)
+				}
+				continue
+			}
+			if ext := strings.TrimPrefix(filepath.Ext(name), "."); ext != "" {
+				stats[ext]++
+			}
+			if entries < maxTreeEntries {
+				fmt.Fprintf(&b, "%s%s\n", strings.Repeat("  ", depth), name)
+				entries++
+			}
+		}
+	}
+	walk(root, 0)
+	if entries == maxTreeEntries {
+		b.WriteString("[tree truncated]\n")
+	}
+	return b.String(), stats
+}
+
+// renderStats lists the most common file extensions with their share.
+func renderStats(stats map[string]int) string {
+	type ext struct {
+		name  string
+		count int
+	}
+	var exts []ext
+	total := 0
+	for name, count := range stats {
+		exts = append(exts, ext{name, count})
+		total += count
+	}
+	sort.Slice(exts, func(i, j int) bool {
+		if exts[i].count != exts[j].count {
+			return exts[i].count > exts[j].count
+		}
+		return exts[i].name < exts[j].name
+	})
+	if len(exts) > 8 {
+		exts = exts[:8]
+	}
+	var b strings.Builder
+	for _, e := range exts {
+		fmt.Fprintf(&b, "%s: %d file(s), %.0f%%\n", e.name, e.count, 100*float64(e.count)/float64(total))
+	}
+	return b.String()
+}
+
+// CachePath is where a workspace's summary is cached, keyed by the absolute
+// path so unrelated checkouts never collide.
+func CachePath(root string) (string, error) {
+	dir, err := config.GetDataDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256([]byte(abs))
+	return filepath.Join(dir, "contexts", hex.EncodeToString(sum[:8])+".md"), nil
+}
+
+// Cached returns the cached summary for a workspace, if one was built.
+func Cached(root string) (string, bool) {
+	path, err := CachePath(root)
+	if err != nil {
+		return "", false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// Save caches a built summary; failing to cache is not an error worth
+// surfacing, the next build just repeats the walk.
+func Save(root, summary string) {
+	path, err := CachePath(root)
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return
+	}
+	_ = os.WriteFile(path, []byte(summary), 0o600)
+}
@@ -0,0 +1,91 @@
+package workspace
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestBuild(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "README.md"), "# demo project\nDoes things.\n")
+	writeFile(t, filepath.Join(root, "go.mod"), "module example.com/demo\n")
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n")
+	writeFile(t, filepath.Join(root, "pkg", "api", "api.go"), "package api\n")
+	writeFile(t, filepath.Join(root, "docs", "notes.md"), "notes\n")
+	writeFile(t, filepath.Join(root, ".git", "config"), "ignored\n")
+	writeFile(t, filepath.Join(root, "node_modules", "x", "x.js"), "ignored\n")
+
+	summary, err := Build(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"## File tree", "pkg/", "api.go", "## Languages", "go: 2 file(s)", "## README.md", "# demo project", "module example.com/demo"} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("summary missing %q:\n%s", want, summary)
+		}
+	}
+	for _, unwanted := range []string{".git", "node_modules"} {
+		if strings.Contains(summary, unwanted) {
+			t.Errorf("summary should not include %q", unwanted)
+		}
+	}
+}
+
+func TestBuildRejectsNonDirectory(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "file"), "x")
+	if _, err := Build(filepath.Join(root, "file")); err == nil {
+		t.Error("Build on a file should fail")
+	}
+	if _, err := Build(filepath.Join(root, "missing")); err == nil {
+		t.Error("Build on a missing path should fail")
+	}
+}
+
+func TestCacheRoundTrip(t *testing.T) {
+	t.Setenv("CLINE_CONFIG_DIR", t.TempDir())
+	root := t.TempDir()
+	if _, ok := Cached(root); ok {
+		t.Fatal("empty cache should miss")
+	}
+	Save(root, "summary text")
+	if got, ok := Cached(root); !ok || got != "summary text" {
+		t.Fatalf("Cached = %q, %v", got, ok)
+	}
+	if got, ok := Cached(t.TempDir()); ok {
+		t.Fatalf("other workspace should miss, got %q", got)
+	}
+}
+
+func TestBuildHonorsIgnoreFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, ".clineignore"), "generated/\n*.log\n")
+	writeFile(t, filepath.Join(root, "main.go"), "package main\n")
+	writeFile(t, filepath.Join(root, "debug.log"), "noise\n")
+	writeFile(t, filepath.Join(root, "generated", "api.go"), "package api\n")
+
+	summary, err := Build(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(summary, "main.go") {
+		t.Error("summary should include main.go")
+	}
+	for _, unwanted := range []string{"generated", "debug.log"} {
+		if strings.Contains(summary, unwanted) {
+			t.Errorf("summary should not include %q", unwanted)
+		}
+	}
+}